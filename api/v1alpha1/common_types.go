@@ -1,8 +1,16 @@
 package v1alpha1
 
 import (
+	"net/url"
+	"strings"
+	"text/template"
+
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 // Autoscaling configuration for scalable workloads.
@@ -36,6 +44,93 @@ type IstioConfig struct {
 	// authentication policy that takes precedence over a global and/or
 	// namespace-wide policy.
 	MutualTLSMode string `json:"istioMutualTLSMode,omitempty"`
+	// Istio configures the Istio resources generated for the cluster. It is
+	// ignored unless Istio support is enabled in the operator.
+	Istio *IstioResourcesConfig `json:"istio,omitempty"`
+}
+
+// IstioResourcesConfig defines the Istio resources generated for a cluster.
+type IstioResourcesConfig struct {
+	// Authorization controls the creation of an AuthorizationPolicy that
+	// mirrors the cluster network policies using Istio identities.
+	Authorization *IstioAuthorizationConfig `json:"authorization,omitempty"`
+	// Sidecar controls the creation of a Sidecar that limits the mesh
+	// configuration pushed to the proxies of cluster pods.
+	Sidecar *IstioSidecarConfig `json:"sidecar,omitempty"`
+	// DestinationRule controls the creation of DestinationRules that require
+	// Istio mutual TLS for traffic sent to the cluster services.
+	DestinationRule *IstioDestinationRuleConfig `json:"destinationRule,omitempty"`
+	// EnvoyFilter configures the EnvoyFilter that overrides the idle timeout
+	// of TCP connections proxied for the cluster pods.
+	EnvoyFilter *IstioEnvoyFilterConfig `json:"envoyFilter,omitempty"`
+}
+
+// IstioEnvoyFilterConfig defines the TCP proxy settings patched into the
+// proxies of cluster pods.
+type IstioEnvoyFilterConfig struct {
+	// IdleTimeout of TCP connections. Long-lived framework connections are
+	// closed by Envoy after one hour of inactivity by default, so the timeout
+	// is disabled with "0s" unless provided.
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+	// WorkloadLabels select the pods patched by the filter instead of the
+	// cluster pods.
+	WorkloadLabels map[string]string `json:"workloadLabels,omitempty"`
+}
+
+// IstioSidecarConfig defines the hosts reachable from cluster pods through
+// the mesh. The cluster services and the services of the authorization
+// client namespaces are always reachable. Clients running in the cluster
+// namespace must list it in the client namespaces.
+type IstioSidecarConfig struct {
+	// Enabled creates a Sidecar for the cluster pods.
+	Enabled bool `json:"enabled,omitempty"`
+	// EgressHosts reachable from cluster pods in addition to the cluster
+	// services, in the Istio "namespace/dnsName" format, e.g.
+	// "istio-system/*" or "*/api.example.com".
+	EgressHosts []string `json:"egressHosts,omitempty"`
+}
+
+// IstioDestinationRuleConfig defines the DestinationRules generated for the
+// cluster services.
+type IstioDestinationRuleConfig struct {
+	// Enabled creates a DestinationRule with ISTIO_MUTUAL TLS for every
+	// cluster service.
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// IstioAuthorizationConfig defines the workloads allowed to reach cluster
+// pods when authorization policies are enabled. Cluster pods always accept
+// traffic from the cluster service account.
+type IstioAuthorizationConfig struct {
+	// Enabled creates an ALLOW AuthorizationPolicy for the cluster pods.
+	Enabled bool `json:"enabled,omitempty"`
+	// ClientPrincipals are the Istio principals, e.g.
+	// "cluster.local/ns/<namespace>/sa/<service account>", allowed to reach
+	// the cluster client port(s).
+	ClientPrincipals []string `json:"clientPrincipals,omitempty"`
+	// ClientNamespaces whose workloads are allowed to reach the cluster
+	// client port(s). Cluster pods can reach their services when the sidecar
+	// is enabled, so entries must be namespace names or "*" in that case.
+	ClientNamespaces []string `json:"clientNamespaces,omitempty"`
+	// DashboardPrincipals are the Istio principals allowed to reach the
+	// cluster dashboard.
+	DashboardPrincipals []string `json:"dashboardPrincipals,omitempty"`
+	// DashboardNamespaces whose workloads are allowed to reach the cluster
+	// dashboard.
+	DashboardNamespaces []string `json:"dashboardNamespaces,omitempty"`
+	// MonitoringPrincipals are the Istio principals allowed to scrape the
+	// cluster metrics ports when monitoring is enabled.
+	MonitoringPrincipals []string `json:"monitoringPrincipals,omitempty"`
+	// MonitoringNamespaces whose workloads, e.g. Prometheus, are allowed to
+	// scrape the cluster metrics ports when monitoring is enabled.
+	MonitoringNamespaces []string `json:"monitoringNamespaces,omitempty"`
+	// Waypoint is the name of the service account used by the waypoint proxy
+	// of the cluster namespace. Traffic forwarded by the waypoint is allowed
+	// when the operator runs in ambient mode and ignored otherwise. The
+	// client, dashboard and monitoring rules are then enforced by a waypoint
+	// policy attached to the cluster services, which requires Istio 1.22 or
+	// later.
+	Waypoint string `json:"waypoint,omitempty"`
 }
 
 // NetworkPolicyConfig defines network policy configuration options.
@@ -46,12 +141,34 @@ type NetworkPolicyConfig struct {
 	// ClientLabels defines the pod selector clause that grants ingress access
 	// to the cluster client port(s).
 	ClientLabels map[string]string `json:"clientLabels,omitempty"`
+	// ClientNamespaceLabels defines the namespace selector clause that, combined
+	// with ClientLabels, grants ingress access to the cluster client port(s)
+	// from pods in other namespaces.
+	ClientNamespaceLabels map[string]string `json:"clientNamespaceLabels,omitempty"`
+	// ClientIPBlocks grants ingress access to the cluster client port(s) from
+	// the given CIDR ranges, e.g. clients running outside the kubernetes cluster.
+	ClientIPBlocks []networkingv1.IPBlock `json:"clientIPBlocks,omitempty"`
 	// Defines the pod selector clause that grants ingress
 	// access to the cluster dashboard.
 	DashboardLabels map[string]string `json:"dashboardLabels,omitempty"`
 	// Defines the namespace selector clause that grants ingress
 	// access to the cluster dashboard.
 	DashboardNamespaceLabels map[string]string `json:"dashboardNamespaceLabels,omitempty"`
+	// Egress restricts the outbound traffic of cluster pods.
+	Egress *NetworkPolicyEgressConfig `json:"egress,omitempty"`
+}
+
+// NetworkPolicyEgressConfig defines the destinations cluster pods can reach
+// when egress traffic is restricted.
+type NetworkPolicyEgressConfig struct {
+	// Enabled denies all egress traffic of cluster pods except traffic to
+	// other cluster pods, clients, DNS servers, and the configured rules.
+	Enabled bool `json:"enabled,omitempty"`
+	// AllowKubernetesAPI grants access to the kubernetes API servers.
+	AllowKubernetesAPI bool `json:"allowKubernetesAPI,omitempty"`
+	// Rules grant access to additional destinations, e.g. CIDRs of external
+	// services or pods in namespaces selected by label, on the given ports.
+	Rules []networkingv1.NetworkPolicyEgressRule `json:"rules,omitempty"`
 }
 
 // OCIImageDefinition describes where and how to fetch a container image.
@@ -62,6 +179,9 @@ type OCIImageDefinition struct {
 	Repository string `json:"repository,omitempty"`
 	// Tag points to a specific container image variant.
 	Tag string `json:"tag,omitempty"`
+	// Digest pins the container image to immutable content, e.g.
+	// "sha256:<hex>". The tag is optional when a digest is provided.
+	Digest string `json:"digest,omitempty"`
 	// PullPolicy used to fetch container image.
 	PullPolicy corev1.PullPolicy `json:"pullPolicy,omitempty"`
 }
@@ -86,14 +206,154 @@ type ServiceAccountConfig struct {
 	AutomountServiceAccountToken bool `json:"automountServiceAccountToken,omitempty"`
 }
 
+// KerberosKeytabSecretReference selects the key of a Secret that holds a keytab.
+type KerberosKeytabSecretReference struct {
+	// Name of the Secret in the namespace of the cluster.
+	Name string `json:"name"`
+	// Key of the keytab file in the Secret. Defaults to "keytab".
+	Key string `json:"key,omitempty"`
+}
+
 // KerberosKeytabConfig defines kerberos key table configuration options.
 type KerberosKeytabConfig struct {
-	// Contents are the binary data stored in the keytab file.
+	// Contents are the binary data stored in the keytab file. Inline contents
+	// are readable by anyone who can view the cluster, prefer SecretRef. They
+	// are copied into a Secret and a warning event is recorded on the cluster.
 	Contents []byte `json:"contents,omitempty"`
+	// SecretRef references an existing Secret holding the keytab file. It
+	// cannot be combined with Contents.
+	SecretRef *KerberosKeytabSecretReference `json:"secretRef,omitempty"`
 	// MountPath where the keytab file should be created inside a pod.
 	MountPath string `json:"mountPath,omitempty"`
 }
 
+const keytabDefaultSecretKey = "keytab"
+
+// applyDefaults fills in the key of a referenced keytab Secret.
+func (kc *KerberosKeytabConfig) applyDefaults(log logr.Logger) {
+	if kc != nil && kc.SecretRef != nil && kc.SecretRef.Key == "" {
+		log.Info("Setting default keytab secret key", "value", keytabDefaultSecretKey)
+		kc.SecretRef.Key = keytabDefaultSecretKey
+	}
+}
+
+// KerberosConfig defines how cluster nodes obtain kerberos tickets.
+type KerberosConfig struct {
+	// Enabled adds a sidecar that obtains a ticket with the keytab provided
+	// by KerberosKeytab and renews it for as long as the pod runs. Framework
+	// containers find the ticket cache and krb5.conf through KRB5CCNAME and
+	// KRB5_CONFIG.
+	Enabled bool `json:"enabled,omitempty"`
+	// Principal of the keytab, e.g. "svc-analytics". The realm is appended
+	// when the principal does not include one.
+	Principal string `json:"principal,omitempty"`
+	// Realm of the principal and the default realm of a generated krb5.conf.
+	Realm string `json:"realm,omitempty"`
+	// Config holds the contents of krb5.conf. When both Config and
+	// ConfigMapRef are omitted, a krb5.conf that locates the KDCs of Realm
+	// through DNS is generated.
+	Config string `json:"config,omitempty"`
+	// ConfigMapRef references an existing ConfigMap holding krb5.conf under
+	// the "krb5.conf" key. It cannot be combined with Config.
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+	// RenewIntervalSeconds is how often the ticket is renewed.
+	RenewIntervalSeconds *int32 `json:"renewIntervalSeconds,omitempty"`
+	// Image of the sidecar; it must provide kinit. Defaults to the cluster image.
+	Image *OCIImageDefinition `json:"image,omitempty"`
+	// Resources required by the sidecar.
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+var kerberosDefaultRenewIntervalSeconds = pointer.Int32(3600)
+
+// applyDefaults fills in the renewal interval of enabled kerberos sidecars.
+func (kc *KerberosConfig) applyDefaults(log logr.Logger) {
+	if kc == nil || !kc.Enabled {
+		return
+	}
+	if kc.RenewIntervalSeconds == nil {
+		log.Info("Setting default kerberos renew interval", "value", *kerberosDefaultRenewIntervalSeconds)
+		kc.RenewIntervalSeconds = kerberosDefaultRenewIntervalSeconds
+	}
+}
+
+// CertManagerIssuerReference identifies the cert-manager issuer that signs
+// cluster certificates.
+type CertManagerIssuerReference struct {
+	// Name of the issuer.
+	Name string `json:"name"`
+	// Kind of the issuer, e.g. "Issuer" or "ClusterIssuer". cert-manager
+	// uses "Issuer" when blank.
+	Kind string `json:"kind,omitempty"`
+	// Group of the issuer. cert-manager uses "cert-manager.io" when blank.
+	Group string `json:"group,omitempty"`
+}
+
+// TLSConfig defines options for encrypting cluster traffic without a service
+// mesh. Cluster nodes and clients authenticate each other with certificates
+// signed by the same CA.
+type TLSConfig struct {
+	// Enabled issues certificates to cluster nodes and switches cluster
+	// communication to TLS.
+	Enabled bool `json:"enabled,omitempty"`
+	// IssuerRef is the cert-manager issuer used to sign certificates. When
+	// blank, certificates are signed by a CA that is generated for each
+	// cluster and kept in a Secret. Issuers must publish their CA certificate
+	// in the "ca.crt" key of issued Secrets.
+	IssuerRef *CertManagerIssuerReference `json:"issuerRef,omitempty"`
+}
+
+// GangSchedulingProvider selects the PodGroup API used for gang scheduling.
+type GangSchedulingProvider string
+
+const (
+	// GangSchedulingCoscheduling uses the PodGroup API of the coscheduling
+	// plugin from kubernetes-sigs/scheduler-plugins.
+	GangSchedulingCoscheduling GangSchedulingProvider = "coscheduling"
+	// GangSchedulingVolcano uses the PodGroup API of the Volcano scheduler.
+	GangSchedulingVolcano GangSchedulingProvider = "volcano"
+)
+
+// GangSchedulingConfig defines options for scheduling all cluster pods
+// together, so that no pod starts until the minimum set of pods fits.
+type GangSchedulingConfig struct {
+	// Enabled creates a PodGroup for the cluster and assigns cluster pods to it.
+	Enabled bool `json:"enabled,omitempty"`
+	// Provider of the PodGroup API, either "coscheduling" or "volcano".
+	Provider GangSchedulingProvider `json:"provider,omitempty"`
+	// SchedulerName of the gang-aware scheduler set on cluster pods.
+	SchedulerName string `json:"schedulerName,omitempty"`
+	// ScheduleTimeoutSeconds is how long the coscheduling plugin waits for
+	// the whole group to become schedulable.
+	ScheduleTimeoutSeconds *int32 `json:"scheduleTimeoutSeconds,omitempty"`
+	// Queue is the Volcano queue the PodGroup is submitted to.
+	Queue string `json:"queue,omitempty"`
+}
+
+const (
+	coschedulingDefaultSchedulerName = "scheduler-plugins-scheduler"
+	volcanoDefaultSchedulerName      = "volcano"
+)
+
+// applyDefaults fills in the provider and scheduler of enabled gang scheduling.
+func (gs *GangSchedulingConfig) applyDefaults(log logr.Logger) {
+	if gs == nil || !gs.Enabled {
+		return
+	}
+	if gs.Provider == "" {
+		log.Info("Setting default gang scheduling provider", "value", GangSchedulingCoscheduling)
+		gs.Provider = GangSchedulingCoscheduling
+	}
+	if gs.SchedulerName == "" {
+		name := coschedulingDefaultSchedulerName
+		if gs.Provider == GangSchedulingVolcano {
+			name = volcanoDefaultSchedulerName
+		}
+		log.Info("Setting default gang scheduler name", "value", name)
+		gs.SchedulerName = name
+	}
+}
+
 // ClusterConfig defines high-level cluster options.
 type ClusterConfig struct {
 	// IstioConfig overrides for a cluster.
@@ -108,6 +368,8 @@ type ClusterConfig struct {
 	ServiceAccount ServiceAccountConfig `json:"serviceAccount,omitempty"`
 	// KerberosKeytab parameters used to add kerberos authentication.
 	KerberosKeytab *KerberosKeytabConfig `json:"kerberosKeytab,omitempty"`
+	// Kerberos configures the renewal of kerberos tickets for cluster nodes.
+	Kerberos *KerberosConfig `json:"kerberos,omitempty"`
 	// ImagePullSecrets are references to secrets with pull credentials to
 	// private registries where cluster image are stored.
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
@@ -116,8 +378,16 @@ type ClusterConfig struct {
 	// EnvVars added to all every cluster container.
 	EnvVars []corev1.EnvVar `json:"envVars,omitempty"`
 	// PodSecurityPolicy name can be provided to restrict and/or provide
-	// execution permissions to processes running within cluster pods.
+	// execution permissions to processes running within cluster pods. It is
+	// ignored on clusters that do not serve the PodSecurityPolicy API.
 	PodSecurityPolicy string `json:"podSecurityPolicy,omitempty"`
+	// SecurityProfile is the Pod Security Standard (privileged, baseline or
+	// restricted) that cluster pods must meet. Generated pod specs are
+	// hardened accordingly, and the profile must be admitted by the
+	// "pod-security.kubernetes.io/enforce" label of the cluster namespace.
+	SecurityProfile SecurityProfile `json:"securityProfile,omitempty"`
+	// GangScheduling parameters used to schedule cluster pods as a group.
+	GangScheduling *GangSchedulingConfig `json:"gangScheduling,omitempty"`
 }
 
 // ScalableClusterConfig defines high-level cluster options with autoscaling.
@@ -126,6 +396,261 @@ type ScalableClusterConfig struct {
 	ClusterConfig `json:",inline"`
 	// Autoscaling parameters used to scale up/down cluster nodes.
 	Autoscaling *Autoscaling `json:"autoscaling,omitempty"`
+	// Sync adds a file sync sidecar to worker pods.
+	Sync *SyncConfig `json:"sync,omitempty"`
+	// Monitoring parameters used to scrape framework metrics.
+	Monitoring *MonitoringConfig `json:"monitoring,omitempty"`
+	// Dashboard parameters used to publish the cluster dashboard.
+	Dashboard *DashboardConfig `json:"dashboard,omitempty"`
+}
+
+// DashboardExposureType selects the API used to expose a cluster dashboard.
+type DashboardExposureType string
+
+const (
+	// DashboardExposureIngress exposes the dashboard with a networking.k8s.io
+	// Ingress.
+	DashboardExposureIngress DashboardExposureType = "Ingress"
+	// DashboardExposureHTTPRoute exposes the dashboard with a Gateway API
+	// HTTPRoute.
+	DashboardExposureHTTPRoute DashboardExposureType = "HTTPRoute"
+)
+
+// GatewayReference identifies the Gateway an HTTPRoute is attached to.
+type GatewayReference struct {
+	// Name of the Gateway.
+	Name string `json:"name"`
+	// Namespace of the Gateway. The cluster namespace is used when blank.
+	Namespace string `json:"namespace,omitempty"`
+	// SectionName of the Gateway listener.
+	SectionName string `json:"sectionName,omitempty"`
+	// HTTPS should be set when the listener terminates TLS. It only affects
+	// the dashboard URL published in status.
+	HTTPS bool `json:"https,omitempty"`
+}
+
+// DashboardExposeConfig defines how a cluster dashboard is exposed outside
+// of the cluster.
+type DashboardExposeConfig struct {
+	// Enabled creates an Ingress or HTTPRoute that routes to the dashboard.
+	Enabled bool `json:"enabled,omitempty"`
+	// Type of the routing object, either "Ingress" or "HTTPRoute".
+	Type DashboardExposureType `json:"type,omitempty"`
+	// Host serving the dashboard. It is a Go template that may refer to the
+	// cluster {{ .Name }} and {{ .Namespace }}.
+	Host string `json:"host,omitempty"`
+	// Path prefix serving the dashboard. It is templated like Host and
+	// defaults to "/".
+	Path string `json:"path,omitempty"`
+	// IngressClassName of the Ingress.
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+	// Annotations added to the Ingress or HTTPRoute.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// TLSSecretName is the Secret holding the certificate of the Ingress host.
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+	// Gateway the HTTPRoute is attached to.
+	Gateway *GatewayReference `json:"gateway,omitempty"`
+	// ControllerLabels are the labels of the ingress controller or gateway
+	// pods admitted to the dashboard when network policies are enabled.
+	ControllerLabels map[string]string `json:"controllerLabels,omitempty"`
+	// ControllerNamespaceLabels are the labels of the namespace where the
+	// ingress controller or gateway runs.
+	ControllerNamespaceLabels map[string]string `json:"controllerNamespaceLabels,omitempty"`
+}
+
+// DashboardAuthType selects the proxy that authenticates dashboard requests.
+type DashboardAuthType string
+
+const (
+	// DashboardAuthOIDC signs users in with an OpenID Connect provider using
+	// oauth2-proxy.
+	DashboardAuthOIDC DashboardAuthType = "OIDC"
+	// DashboardAuthTokenReview validates bearer tokens with the Kubernetes
+	// TokenReview API using kube-rbac-proxy. Requests are authorized against
+	// the "dashboard" subresource of the cluster object, with the verb derived
+	// from the HTTP method.
+	DashboardAuthTokenReview DashboardAuthType = "TokenReview"
+)
+
+// OIDCConfig defines the OpenID Connect client of the dashboard proxy.
+type OIDCConfig struct {
+	// IssuerURL of the OpenID Connect provider.
+	IssuerURL string `json:"issuerURL,omitempty"`
+	// ClientID registered with the provider.
+	ClientID string `json:"clientID,omitempty"`
+	// SecretName of a Secret in the cluster namespace holding the
+	// "client-secret" and "cookie-secret" keys.
+	SecretName string `json:"secretName,omitempty"`
+	// EmailDomains of the users allowed to sign in. All domains are allowed
+	// when blank.
+	EmailDomains []string `json:"emailDomains,omitempty"`
+}
+
+// DashboardAuthConfig defines an authenticating proxy that runs next to the
+// dashboard server. The proxy port replaces the dashboard port in the cluster
+// service and network policy.
+//
+// The cluster service account is bound to the "system:auth-delegator"
+// cluster role when the TokenReview proxy is used, and the proxy receives its
+// own service account token. Egress network policies must allow access to the
+// kubernetes API.
+type DashboardAuthConfig struct {
+	// Enabled adds the proxy to the pod serving the dashboard.
+	Enabled bool `json:"enabled,omitempty"`
+	// Type of the proxy, either "OIDC" or "TokenReview".
+	Type DashboardAuthType `json:"type,omitempty"`
+	// Image of the proxy. Defaults to an oauth2-proxy or kube-rbac-proxy image
+	// according to the type.
+	Image *OCIImageDefinition `json:"image,omitempty"`
+	// Port the proxy listens on. Both proxies serve plain HTTP.
+	Port int32 `json:"port,omitempty"`
+	// OIDC client used by the "OIDC" type.
+	OIDC *OIDCConfig `json:"oidc,omitempty"`
+	// ExtraArgs are appended to the proxy command line.
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+	// Resources of the proxy container.
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// DashboardConfig defines options of the cluster dashboard.
+type DashboardConfig struct {
+	// Expose parameters used to route external traffic to the dashboard.
+	Expose *DashboardExposeConfig `json:"expose,omitempty"`
+	// Auth parameters used to require authentication in front of the dashboard.
+	Auth *DashboardAuthConfig `json:"auth,omitempty"`
+}
+
+const (
+	dashboardDefaultPath     = "/"
+	dashboardAuthDefaultPort = int32(4180)
+)
+
+// applyDefaults fills in the type and path of an exposed dashboard, and the
+// type and port of the dashboard proxy.
+func (dc *DashboardConfig) applyDefaults(log logr.Logger) {
+	if dc == nil {
+		return
+	}
+	if dc.Expose != nil && dc.Expose.Enabled {
+		if dc.Expose.Type == "" {
+			log.Info("Setting default dashboard exposure type", "value", DashboardExposureIngress)
+			dc.Expose.Type = DashboardExposureIngress
+		}
+		if dc.Expose.Path == "" {
+			log.Info("Setting default dashboard path", "value", dashboardDefaultPath)
+			dc.Expose.Path = dashboardDefaultPath
+		}
+	}
+	if dc.Auth != nil && dc.Auth.Enabled {
+		if dc.Auth.Type == "" {
+			log.Info("Setting default dashboard auth type", "value", DashboardAuthTokenReview)
+			dc.Auth.Type = DashboardAuthTokenReview
+		}
+		if dc.Auth.Port == 0 {
+			log.Info("Setting default dashboard auth port", "value", dashboardAuthDefaultPort)
+			dc.Auth.Port = dashboardAuthDefaultPort
+		}
+	}
+}
+
+// AuthEnabled returns true when the dashboard proxy is requested.
+func (dc *DashboardConfig) AuthEnabled() bool {
+	return dc != nil && dc.Auth != nil && dc.Auth.Enabled
+}
+
+// Endpoint renders the host and path serving the dashboard of the named cluster.
+func (ec *DashboardExposeConfig) Endpoint(name, namespace string) (host, path string, err error) {
+	values := struct{ Name, Namespace string }{name, namespace}
+
+	if host, err = renderTemplate("host", ec.Host, values); err != nil {
+		return "", "", err
+	}
+	if path, err = renderTemplate("path", ec.Path, values); err != nil {
+		return "", "", err
+	}
+	if path == "" {
+		path = dashboardDefaultPath
+	}
+
+	return host, path, nil
+}
+
+// URL returns the external URL of the dashboard of the named cluster.
+func (ec *DashboardExposeConfig) URL(name, namespace string) (string, error) {
+	host, path, err := ec.Endpoint(name, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	scheme := "http"
+	if ec.TLSSecretName != "" || (ec.Gateway != nil && ec.Gateway.HTTPS) {
+		scheme = "https"
+	}
+
+	return (&url.URL{Scheme: scheme, Host: host, Path: path}).String(), nil
+}
+
+func renderTemplate(name, text string, data interface{}) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err = tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// MonitoringConfig defines how the Prometheus Operator scrapes the metrics
+// exposed by cluster frameworks.
+type MonitoringConfig struct {
+	// Enabled creates a PodMonitor that scrapes the metrics endpoints of
+	// cluster pods. It requires the Prometheus Operator CRDs.
+	Enabled bool `json:"enabled,omitempty"`
+	// Interval between scrapes, e.g. "30s". The Prometheus default is used
+	// when blank.
+	Interval string `json:"interval,omitempty"`
+	// Labels added to the PodMonitor so that it is selected by a Prometheus
+	// instance.
+	Labels map[string]string `json:"labels,omitempty"`
+	// PrometheusLabels are the labels of the Prometheus pods admitted to
+	// metrics ports when network policies are enabled.
+	PrometheusLabels map[string]string `json:"prometheusLabels,omitempty"`
+	// PrometheusNamespaceLabels are the labels of the namespace where
+	// Prometheus runs.
+	PrometheusNamespaceLabels map[string]string `json:"prometheusNamespaceLabels,omitempty"`
+}
+
+// SyncModule defines a directory exported by the file sync sidecar.
+type SyncModule struct {
+	// Name is the rsync module name used by clients.
+	Name string `json:"name"`
+	// Path is the directory inside the sidecar container served by this module.
+	Path string `json:"path"`
+	// ReadOnly prevents clients from uploading files into this module.
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+const (
+	syncDefaultPort      int32 = 2223
+	syncDefaultMountPath       = "/mnt/sync"
+)
+
+// SyncConfig defines an rsync sidecar that lets clients push code and data
+// to every worker. Credentials are published in a Secret referenced in status.
+type SyncConfig struct {
+	// Enabled adds the sync sidecar to worker pods.
+	Enabled bool `json:"enabled,omitempty"`
+	// Port used by the rsync daemon.
+	Port int32 `json:"port,omitempty"`
+	// MountPath of the scratch volume shared by the sidecar and the framework container.
+	MountPath string `json:"mountPath,omitempty"`
+	// Modules exported by the sidecar. When empty, a single read-write "sync"
+	// module serving MountPath is exported.
+	Modules []SyncModule `json:"modules,omitempty"`
 }
 
 // WorkloadConfig defines options common to all cluster nodes.
@@ -173,6 +698,77 @@ type ClusterStatusConfig struct {
 	WorkerReplicas int32 `json:"workerReplicas,omitempty"`
 	// WorkerSelector is the `scale.status.selector` subresource field.
 	WorkerSelector string `json:"workerSelector,omitempty"`
+	// SyncSecret references the Secret holding credentials for the file sync sidecar.
+	SyncSecret *corev1.LocalObjectReference `json:"syncSecret,omitempty"`
+	// SyncNodes reports the readiness of the file sync sidecar on each worker.
+	SyncNodes []SyncNodeStatus `json:"syncNodes,omitempty"`
+	// AuthSecret references the Secret holding the shared secret that clients
+	// use to authenticate with the cluster.
+	AuthSecret *corev1.LocalObjectReference `json:"authSecret,omitempty"`
+	// PodGroupPhase is the phase of the cluster PodGroup when gang scheduling is enabled.
+	PodGroupPhase string `json:"podGroupPhase,omitempty"`
+	// Queued is true while the cluster waits for admission by its queue. No
+	// cluster pods are started until the workload is admitted.
+	Queued bool `json:"queued,omitempty"`
+	// Resources totals the compute resources of the cluster pods.
+	Resources *ClusterResources `json:"resources,omitempty"`
+	// Usage accumulates the resources requested by cluster pods over their
+	// lifetimes. It is updated periodically while pods are running.
+	Usage *ClusterUsage `json:"usage,omitempty"`
+	// DashboardURL is the external URL of the cluster dashboard when it is exposed.
+	DashboardURL string `json:"dashboardURL,omitempty"`
+	// ClientPeers are the effective network policy peers granted ingress
+	// access to the cluster client port(s) when network policies are enabled.
+	ClientPeers []networkingv1.NetworkPolicyPeer `json:"clientPeers,omitempty"`
+}
+
+// ResourceTotals are the summed requests and limits of a group of pods. Pod
+// totals include init containers and overhead the same way the scheduler does.
+type ResourceTotals struct {
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+	Limits   corev1.ResourceList `json:"limits,omitempty"`
+}
+
+// NodeResources totals the resources of the head or worker nodes of a cluster.
+type NodeResources struct {
+	// Current totals the live pods.
+	Current ResourceTotals `json:"current,omitempty"`
+	// Max totals the pods when the cluster runs its maximum number of nodes,
+	// including the maximum replicas allowed by autoscaling.
+	Max ResourceTotals `json:"max,omitempty"`
+}
+
+// ComponentUsage accumulates the resources requested by the pods of a single
+// cluster component, e.g. "head" or "worker".
+type ComponentUsage struct {
+	Component string `json:"component"`
+	// PodSeconds is the sum of the lifetimes of all pods.
+	PodSeconds int64 `json:"podSeconds"`
+	// CPUCoreSeconds is the sum of requested CPU cores multiplied by pod lifetimes.
+	CPUCoreSeconds resource.Quantity `json:"cpuCoreSeconds"`
+	// MemoryGiBSeconds is the sum of requested memory in GiB multiplied by pod lifetimes.
+	MemoryGiBSeconds resource.Quantity `json:"memoryGiBSeconds"`
+}
+
+// ClusterUsage accumulates the resources requested by cluster pods.
+type ClusterUsage struct {
+	// LastUpdateTime is the time up to which usage has been accumulated.
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+	// Components holds the usage of each cluster component.
+	Components []ComponentUsage `json:"components,omitempty"`
+}
+
+// ClusterResources totals the resources of the head and worker nodes of a
+// cluster. Clusters without a head node only report workers.
+type ClusterResources struct {
+	Head    NodeResources `json:"head,omitempty"`
+	Workers NodeResources `json:"workers,omitempty"`
+}
+
+// SyncNodeStatus reports the readiness of the file sync sidecar on a single pod.
+type SyncNodeStatus struct {
+	Pod   string `json:"pod"`
+	Ready bool   `json:"ready"`
 }
 
 const (
@@ -181,8 +777,13 @@ const (
 	RunningStatus  ClusterStatusType = "Running"
 	StoppingStatus ClusterStatusType = "Stopping"
 	FailedStatus   ClusterStatusType = "Failed"
+	QueuedStatus   ClusterStatusType = "Queued"
 )
 
+// QueueNameLabel submits a cluster to the named queue of an admission
+// controller. Such clusters remain suspended until their workload is admitted.
+const QueueNameLabel = "kueue.x-k8s.io/queue-name"
+
 func IsPodReady(pod corev1.Pod) bool {
 	for _, cond := range pod.Status.Conditions {
 		if cond.Type == corev1.PodReady {
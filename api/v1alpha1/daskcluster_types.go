@@ -25,6 +25,11 @@ type DaskClusterSpec struct {
 
 	// AdditionalClientPorts are extra ports through which cluster nodes could connect to the client.
 	AdditionalClientPorts []corev1.ServicePort `json:"additionalClientPorts,omitempty"`
+
+	// TLS secures communication between the client, scheduler and workers.
+	// Clients authenticate with the certificate published in the
+	// "<name>-dask-client-tls" Secret.
+	TLS *TLSConfig `json:"tls,omitempty"`
 }
 
 // DaskClusterStatus defines the observed state of DaskCluster
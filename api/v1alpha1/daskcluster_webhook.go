@@ -87,6 +87,20 @@ func (dc *DaskCluster) Default() {
 		log.Info("Setting default network policy dashboard pod labels", "values", daskDefaultNetworkPolicyPodLabels)
 		spec.NetworkPolicy.DashboardLabels = daskDefaultNetworkPolicyPodLabels
 	}
+	if spec.Sync != nil && spec.Sync.Enabled {
+		if spec.Sync.Port == 0 {
+			log.Info("Setting default sync port", "value", syncDefaultPort)
+			spec.Sync.Port = syncDefaultPort
+		}
+		if spec.Sync.MountPath == "" {
+			log.Info("Setting default sync mount path", "value", syncDefaultMountPath)
+			spec.Sync.MountPath = syncDefaultMountPath
+		}
+	}
+	spec.GangScheduling.applyDefaults(log)
+	spec.KerberosKeytab.applyDefaults(log)
+	spec.Kerberos.applyDefaults(log)
+	spec.Dashboard.applyDefaults(log)
 }
 
 //+kubebuilder:webhook:path=/validate-distributed-compute-dominodatalab-com-v1alpha1-daskcluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=distributed-compute.dominodatalab.com,resources=daskclusters,verbs=create;update,versions=v1alpha1,name=vdaskcluster.kb.io,admissionReviewVersions={v1,v1beta1}
@@ -96,13 +110,13 @@ var _ webhook.Validator = &DaskCluster{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (dc *DaskCluster) ValidateCreate() error {
 	daskLogger.WithValues("daskcluster", client.ObjectKeyFromObject(dc)).Info("Validating create")
-	return dc.validateDaskCluster()
+	return dc.validateDaskCluster(nil)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (dc *DaskCluster) ValidateUpdate(_ runtime.Object) error {
+func (dc *DaskCluster) ValidateUpdate(old runtime.Object) error {
 	daskLogger.WithValues("daskcluster", client.ObjectKeyFromObject(dc)).Info("Validating update")
-	return dc.validateDaskCluster()
+	return dc.validateDaskCluster(old.(*DaskCluster))
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -111,16 +125,19 @@ func (dc *DaskCluster) ValidateDelete() error {
 	return nil
 }
 
-func (dc *DaskCluster) validateDaskCluster() error {
+func (dc *DaskCluster) validateDaskCluster(old *DaskCluster) error {
 	var errList field.ErrorList
 
 	if err := validateIstioMutualTLSMode(dc.Spec.MutualTLSMode); err != nil {
 		errList = append(errList, err)
 	}
+	if errs := validateIstioResources(dc.Spec.IstioConfig); errs != nil {
+		errList = append(errList, errs...)
+	}
 	if err := validateWorkerReplicas(dc.Spec.Worker.Replicas); err != nil {
 		errList = append(errList, err)
 	}
-	if errs := validateImage(dc.Spec.Image); errs != nil {
+	if errs := validateImage(field.NewPath("spec", "image"), dc.Spec.Image); errs != nil {
 		errList = append(errList, errs...)
 	}
 	if errs := validateAutoscaler(dc.Spec.Autoscaling); errs != nil {
@@ -132,6 +149,52 @@ func (dc *DaskCluster) validateDaskCluster() error {
 	if errs := validateKerberosKeytab(dc.Spec.KerberosKeytab); errs != nil {
 		errList = append(errList, errs...)
 	}
+	if errs := validateKerberos(dc.Spec.Kerberos, dc.Spec.KerberosKeytab); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateNetworkPolicy(dc.Spec.NetworkPolicy); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateGangScheduling(dc.Spec.GangScheduling); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateMonitoring(dc.Spec.Monitoring); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if dc.Spec.Dashboard.AuthEnabled() && dc.Spec.Monitoring != nil && dc.Spec.Monitoring.Enabled {
+		errList = append(errList, field.Forbidden(
+			field.NewPath("spec", "monitoring", "enabled"),
+			"dask serves metrics on the dashboard port, which only the dashboard proxy can reach when dashboard auth is enabled",
+		))
+	}
+	if errs := validateDashboard(dc.Spec.Dashboard, dc.Name, dc.Namespace); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateTokenReview(dc.Spec.Dashboard, dc.Spec.NetworkPolicy); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateTLS(dc.Spec.TLS); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateSync(dc.Spec.Sync); errs != nil {
+		errList = append(errList, errs...)
+	}
+	var oldProfile SecurityProfile
+	if old != nil {
+		oldProfile = old.Spec.SecurityProfile
+	}
+	if errs := validateSecurityProfile(
+		dc,
+		oldProfile,
+		&dc.Spec.ClusterConfig,
+		workloadAt(field.NewPath("spec", "scheduler"), &dc.Spec.Scheduler),
+		workloadAt(field.NewPath("spec", "worker"), &dc.Spec.Worker.WorkloadConfig),
+	); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validatePolicies(dc.policyTarget(old)); errs != nil {
+		errList = append(errList, errs...)
+	}
 
 	ports := map[string]int32{
 		"schedulerPort": dc.Spec.SchedulerPort,
@@ -139,9 +202,32 @@ func (dc *DaskCluster) validateDaskCluster() error {
 		"dashboardPort": dc.Spec.DashboardPort,
 		"nannyPort":     dc.Spec.NannyPort,
 	}
+	if dc.Spec.Sync != nil && dc.Spec.Sync.Enabled {
+		ports["sync.port"] = dc.Spec.Sync.Port
+	}
+	if dc.Spec.Dashboard.AuthEnabled() {
+		ports["dashboard.auth.port"] = dc.Spec.Dashboard.Auth.Port
+	}
 	if errs := validatePorts(ports); errs != nil {
 		errList = append(errList, errs...)
 	}
 
 	return invalidIfNotEmpty("DaskCluster", dc.Name, errList)
 }
+
+func (dc *DaskCluster) policyTarget(old *DaskCluster) *policyTarget {
+	t := &policyTarget{
+		object:      dc,
+		config:      &dc.Spec.ClusterConfig,
+		images:      []imageField{imageAt(field.NewPath("spec", "image"), dc.Spec.Image)},
+		singletons:  []workloadField{workloadAt(field.NewPath("spec", "scheduler"), &dc.Spec.Scheduler)},
+		worker:      workloadAt(field.NewPath("spec", "worker"), &dc.Spec.Worker.WorkloadConfig),
+		replicas:    dc.Spec.Worker.Replicas,
+		autoscaling: dc.Spec.Autoscaling,
+	}
+	if old != nil {
+		t.old = old.policyTarget(nil)
+	}
+
+	return t
+}
@@ -0,0 +1,72 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AllowedImage matches the container images that clusters may use.
+type AllowedImage struct {
+	// Registry hosting the image. A blank registry only matches images that
+	// do not specify a registry.
+	Registry string `json:"registry,omitempty"`
+	// Repository of the image. A trailing "*" matches repositories by prefix
+	// and a blank repository matches every repository in the registry.
+	Repository string `json:"repository,omitempty"`
+}
+
+// DistributedComputePolicySpec defines the guardrails enforced on every
+// cluster created in the namespace of the policy.
+type DistributedComputePolicySpec struct {
+	// MaxWorkers limits the number of worker replicas, including the maximum
+	// number of replicas an autoscaler can scale up to.
+	MaxWorkers *int32 `json:"maxWorkers,omitempty"`
+	// MaxPodResources limits the resource requests and limits of every
+	// cluster pod, e.g. "cpu" and "memory".
+	MaxPodResources corev1.ResourceList `json:"maxPodResources,omitempty"`
+	// MaxClusterResources limits the sum of the resource requests of all
+	// cluster pods when the cluster runs its maximum number of workers.
+	MaxClusterResources corev1.ResourceList `json:"maxClusterResources,omitempty"`
+	// AllowedImages restricts the images used by clusters. Any image is
+	// allowed when empty.
+	AllowedImages []AllowedImage `json:"allowedImages,omitempty"`
+	// AllowedIstioMutualTLSModes restricts the Istio mutual TLS modes that
+	// clusters can request. Any mode is allowed when empty.
+	AllowedIstioMutualTLSModes []string `json:"allowedIstioMutualTLSModes,omitempty"`
+	// RequireNetworkPolicy rejects clusters that disable network policies.
+	RequireNetworkPolicy bool `json:"requireNetworkPolicy,omitempty"`
+	// ForbidInlineKeytabs rejects clusters that embed kerberos keytab
+	// contents in their spec instead of referencing a Secret.
+	ForbidInlineKeytabs bool `json:"forbidInlineKeytabs,omitempty"`
+	// MaxClusters limits the number of clusters of all kinds that can exist
+	// in the namespace at the same time.
+	MaxClusters *int32 `json:"maxClusters,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=dcpolicy
+//+kubebuilder:printcolumn:name="Max Workers",type=integer,JSONPath=".spec.maxWorkers"
+//+kubebuilder:printcolumn:name="Max Clusters",type=integer,JSONPath=".spec.maxClusters"
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+
+// DistributedComputePolicy is the Schema for the distributedcomputepolicies API.
+// Validating webhooks reject clusters that violate any policy in their namespace.
+type DistributedComputePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DistributedComputePolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DistributedComputePolicyList contains a list of DistributedComputePolicy.
+type DistributedComputePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DistributedComputePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DistributedComputePolicy{}, &DistributedComputePolicyList{})
+}
@@ -5,16 +5,35 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// MPIBootstrapMode selects how MPI rank processes are started on workers.
+type MPIBootstrapMode string
+
+const (
+	// MPIBootstrapSSH starts rank processes through sshd running in every worker.
+	MPIBootstrapSSH MPIBootstrapMode = "ssh"
+	// MPIBootstrapAgent starts rank processes through a rank agent that
+	// authenticates launch requests with a per-cluster token.
+	MPIBootstrapAgent MPIBootstrapMode = "agent"
+)
+
 // MPIClusterWorker defines worker-specific workload settings.
 type MPIClusterWorker struct {
-	WorkloadConfig  `json:",inline"`
-	Replicas        *int32 `json:"replicas,omitempty"`
-	SharedSSHSecret string `json:"sharedSSHSecret"`
-	UserName        string `json:"userName,omitempty"`
-	UserID          *int64 `json:"userID,omitempty"`
-	GroupName       string `json:"groupName,omitempty"`
-	GroupID         *int64 `json:"groupID,omitempty"`
-	HomeDir         string `json:"homeDir,omitempty"`
+	WorkloadConfig `json:",inline"`
+	Replicas       *int32 `json:"replicas,omitempty"`
+
+	// SharedSSHSecret is the name of a Secret with the client public key.
+	// It is required by the "ssh" bootstrap mode and ignored otherwise.
+	SharedSSHSecret string `json:"sharedSSHSecret,omitempty"`
+
+	UserName  string `json:"userName,omitempty"`
+	UserID    *int64 `json:"userID,omitempty"`
+	GroupName string `json:"groupName,omitempty"`
+	GroupID   *int64 `json:"groupID,omitempty"`
+	HomeDir   string `json:"homeDir,omitempty"`
+
+	// SyncModules are the directories exported by the rsync sidecar. When
+	// empty, the "mnt", "repos" and "imported" modules are exported read-write.
+	SyncModules []SyncModule `json:"syncModules,omitempty"`
 }
 
 // MPIClusterSpec defines the desired state of MPICluster.
@@ -22,12 +41,38 @@ type MPIClusterSpec struct {
 	ClusterConfig `json:",inline"`
 	Worker        MPIClusterWorker `json:"worker,omitempty"`
 
+	// Bootstrap selects how rank processes are started on workers: "ssh"
+	// runs sshd in every worker, while "agent" runs a rank agent that does
+	// not require sshd, a login shell or root privileges in the worker image.
+	Bootstrap MPIBootstrapMode `json:"bootstrap,omitempty"`
+
+	// InitImage overrides the operator default image of the init container
+	// that installs MPI worker utilities. Its pull policy defaults to the
+	// pull policy of the cluster image.
+	InitImage *OCIImageDefinition `json:"initImage,omitempty"`
+	// SyncImage overrides the operator default image of the file sync
+	// sidecar. Its pull policy defaults to the pull policy of the cluster image.
+	SyncImage *OCIImageDefinition `json:"syncImage,omitempty"`
+
 	// WorkerPorts specifies the range of ports used by worker processes for communication.
 	WorkerPorts []int32 `json:"workerPorts,omitempty"`
 	// AdditionalClientPorts are extra ports through which cluster nodes could connect to the client.
 	AdditionalClientPorts []corev1.ServicePort `json:"additionalClientPorts,omitempty"`
 }
 
+// MPIClusterStatus defines the observed state of MPICluster.
+type MPIClusterStatus struct {
+	ClusterStatusConfig `json:",inline"`
+
+	// AgentSecret references the Secret with the token accepted by worker
+	// rank agents when the "agent" bootstrap mode is used.
+	AgentSecret *corev1.LocalObjectReference `json:"agentSecret,omitempty"`
+	// InitImage is the canonical reference url to the worker init container image.
+	InitImage string `json:"initImage,omitempty"`
+	// SyncImage is the canonical reference url to the file sync sidecar image.
+	SyncImage string `json:"syncImage,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:resource:shortName=mpi
 //+kubebuilder:subresource:status
@@ -35,6 +80,8 @@ type MPIClusterSpec struct {
 //+kubebuilder:printcolumn:name="Status",type=string,JSONPath=".status.clusterStatus"
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
 //+kubebuilder:printcolumn:name="Image",type=string,JSONPath=".status.image",priority=10
+//+kubebuilder:printcolumn:name="Init Image",type=string,JSONPath=".status.initImage",priority=10
+//+kubebuilder:printcolumn:name="Sync Image",type=string,JSONPath=".status.syncImage",priority=10
 //+kubebuilder:printcolumn:name="Bound PSP",type=string,JSONPath=".spec.podSecurityPolicy",priority=10
 //+kubebuilder:printcolumn:name="Network Policy",type=boolean,JSONPath=".spec.networkPolicy.enabled",priority=10
 //+kubebuilder:printcolumn:name="Pods",type=string,JSONPath=".status.nodes",priority=10
@@ -43,8 +90,8 @@ type MPIClusterSpec struct {
 type MPICluster struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
-	Spec              MPIClusterSpec      `json:"spec,omitempty"`
-	Status            ClusterStatusConfig `json:"status,omitempty"`
+	Spec              MPIClusterSpec   `json:"spec,omitempty"`
+	Status            MPIClusterStatus `json:"status,omitempty"`
 }
 
 //+kubebuilder:object:root=true
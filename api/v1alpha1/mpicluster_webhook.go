@@ -41,10 +41,17 @@ func (j *MPICluster) Default() {
 		log.Info("Setting default image", "value", mpiDefaultImage)
 		spec.Image = mpiDefaultImage
 	}
+	if spec.Bootstrap == "" {
+		log.Info("Setting default bootstrap mode", "value", MPIBootstrapSSH)
+		spec.Bootstrap = MPIBootstrapSSH
+	}
 	if spec.NetworkPolicy.Enabled == nil {
 		log.Info("Setting enable network policy flag", "value", *mpiDefaultEnableNetworkPolicy)
 		spec.NetworkPolicy.Enabled = mpiDefaultEnableNetworkPolicy
 	}
+	spec.GangScheduling.applyDefaults(log)
+	spec.KerberosKeytab.applyDefaults(log)
+	spec.Kerberos.applyDefaults(log)
 }
 
 //+kubebuilder:webhook:path=/validate-distributed-compute-dominodatalab-com-v1alpha1-mpicluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=distributed-compute.dominodatalab.com,resources=mpiclusters,verbs=create;update,versions=v1alpha1,name=vmpicluster.kb.io,admissionReviewVersions={v1,v1beta1}
@@ -60,23 +67,55 @@ func (j *MPICluster) ValidateCreate() error {
 	if err := validateIstioMutualTLSMode(j.Spec.MutualTLSMode); err != nil {
 		errList = append(errList, err)
 	}
+	if errs := validateIstioResources(j.Spec.IstioConfig); errs != nil {
+		errList = append(errList, errs...)
+	}
 	if err := validateWorkerReplicas(j.Spec.Worker.Replicas); err != nil {
 		errList = append(errList, err)
 	}
-	if errs := validateImage(j.Spec.Image); errs != nil {
+	if errs := validateImage(field.NewPath("spec", "image"), j.Spec.Image); errs != nil {
 		errList = append(errList, errs...)
 	}
+	if j.Spec.InitImage != nil {
+		errList = append(errList, validateImage(field.NewPath("spec", "initImage"), j.Spec.InitImage)...)
+	}
+	if j.Spec.SyncImage != nil {
+		errList = append(errList, validateImage(field.NewPath("spec", "syncImage"), j.Spec.SyncImage)...)
+	}
 	if errs := validateKerberosKeytab(j.Spec.KerberosKeytab); errs != nil {
 		errList = append(errList, errs...)
 	}
-	if errs := validateSharedSSHSecret(j.Spec.Worker.SharedSSHSecret); errs != nil {
+	if errs := validateKerberos(j.Spec.Kerberos, j.Spec.KerberosKeytab); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateNetworkPolicy(j.Spec.NetworkPolicy); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateGangScheduling(j.Spec.GangScheduling); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if err := validateMPIBootstrap(j.Spec.Bootstrap); err != nil {
+		errList = append(errList, err)
+	}
+	if j.Spec.Bootstrap != MPIBootstrapAgent {
+		if errs := validateSharedSSHSecret(j.Spec.Worker.SharedSSHSecret); errs != nil {
+			errList = append(errList, errs...)
+		}
+	}
+	if errs := validateSyncModules(field.NewPath("spec", "worker", "syncModules"), j.Spec.Worker.SyncModules); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := j.validateSecurity(nil); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validatePolicies(j.policyTarget(nil)); errs != nil {
 		errList = append(errList, errs...)
 	}
 	return invalidIfNotEmpty("MPICluster", j.Name, errList)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (j *MPICluster) ValidateUpdate(_ runtime.Object) error {
+func (j *MPICluster) ValidateUpdate(old runtime.Object) error {
 	mpiClusterLogger.WithValues("mpicluster", client.ObjectKeyFromObject(j)).Info("Validating update")
 
 	// TODO: reject all updates to spec, or certain fields?
@@ -86,7 +125,19 @@ func (j *MPICluster) ValidateUpdate(_ runtime.Object) error {
 	//
 	// return apierrors.NewForbidden(schema.GroupResource{}, j.Name, errors.New(""))
 
-	return nil
+	oldCluster := old.(*MPICluster)
+
+	var errList field.ErrorList
+	if errs := validateSyncModules(field.NewPath("spec", "worker", "syncModules"), j.Spec.Worker.SyncModules); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := j.validateSecurity(oldCluster); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validatePolicies(j.policyTarget(oldCluster)); errs != nil {
+		errList = append(errList, errs...)
+	}
+	return invalidIfNotEmpty("MPICluster", j.Name, errList)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -94,3 +145,46 @@ func (j *MPICluster) ValidateDelete() error {
 	// NOTE: not used, just here for interface compliance.
 	return nil
 }
+
+// validateSecurity verifies the security profile on create and update like
+// the other cluster kinds.
+func (j *MPICluster) validateSecurity(old *MPICluster) field.ErrorList {
+	var oldProfile SecurityProfile
+	if old != nil {
+		oldProfile = old.Spec.SecurityProfile
+	}
+
+	errs := validateSecurityProfile(
+		j,
+		oldProfile,
+		&j.Spec.ClusterConfig,
+		workloadAt(field.NewPath("spec", "worker"), &j.Spec.Worker.WorkloadConfig),
+	)
+	if j.Spec.SecurityProfile == SecurityProfileRestricted && j.Spec.Bootstrap != MPIBootstrapAgent {
+		errs = append(errs, field.Forbidden(
+			field.NewPath("spec", "bootstrap"),
+			"the restricted security profile requires the \"agent\" bootstrap mode because sshd runs as root",
+		))
+	}
+
+	return errs
+}
+
+func (j *MPICluster) policyTarget(old *MPICluster) *policyTarget {
+	t := &policyTarget{
+		object: j,
+		config: &j.Spec.ClusterConfig,
+		images: []imageField{
+			imageAt(field.NewPath("spec", "image"), j.Spec.Image),
+			imageAt(field.NewPath("spec", "initImage"), j.Spec.InitImage),
+			imageAt(field.NewPath("spec", "syncImage"), j.Spec.SyncImage),
+		},
+		worker:   workloadAt(field.NewPath("spec", "worker"), &j.Spec.Worker.WorkloadConfig),
+		replicas: j.Spec.Worker.Replicas,
+	}
+	if old != nil {
+		t.old = old.policyTarget(nil)
+	}
+
+	return t
+}
@@ -0,0 +1,33 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMPIClusterValidateUpdateSyncModules(t *testing.T) {
+	old := &MPICluster{ObjectMeta: metav1.ObjectMeta{Name: "mpi", Namespace: "ns"}}
+	old.Spec.Bootstrap = MPIBootstrapAgent
+	old.Spec.Worker.SyncModules = []SyncModule{{Name: "data", Path: "/mnt/data"}}
+
+	mc := old.DeepCopy()
+	require.NoError(t, mc.ValidateUpdate(old))
+
+	for _, path := range []string{"/mnt/data\n[other]", "/mnt/data\r", "/mnt/data:/etc", "mnt/data"} {
+		mc.Spec.Worker.SyncModules[0].Path = path
+
+		err := mc.ValidateUpdate(old)
+		require.Error(t, err, path)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.worker.syncModules[0].path")
+	}
+
+	mc.Spec.Worker.SyncModules = []SyncModule{{Name: "data\nuid = 0", Path: "/mnt/data"}}
+	err := mc.ValidateUpdate(old)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.worker.syncModules[0].name")
+}
@@ -0,0 +1,220 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// SecurityProfile is a Pod Security Standard that cluster pods must satisfy.
+type SecurityProfile string
+
+const (
+	// SecurityProfilePrivileged places no restrictions on cluster pods.
+	SecurityProfilePrivileged SecurityProfile = "privileged"
+	// SecurityProfileBaseline prevents known privilege escalations.
+	SecurityProfileBaseline SecurityProfile = "baseline"
+	// SecurityProfileRestricted follows current pod hardening best practices.
+	SecurityProfileRestricted SecurityProfile = "restricted"
+
+	// PodSecurityEnforceLabel is the namespace label used by Pod Security
+	// Admission to select the enforced standard.
+	PodSecurityEnforceLabel = "pod-security.kubernetes.io/enforce"
+)
+
+var (
+	securityProfileLevels = map[SecurityProfile]int{
+		SecurityProfilePrivileged: 0,
+		SecurityProfileBaseline:   1,
+		SecurityProfileRestricted: 2,
+	}
+
+	baselineCapabilities = sets.New[corev1.Capability](
+		"AUDIT_WRITE", "CHOWN", "DAC_OVERRIDE", "FOWNER", "FSETID", "KILL", "MKNOD",
+		"NET_BIND_SERVICE", "SETFCAP", "SETGID", "SETPCAP", "SETUID", "SYS_CHROOT",
+	)
+	restrictedCapabilities = sets.New[corev1.Capability]("NET_BIND_SERVICE")
+
+	restrictedVolumeTypes = []string{
+		"configMap", "csi", "downwardAPI", "emptyDir", "ephemeral", "persistentVolumeClaim", "projected", "secret",
+	}
+)
+
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+
+// Admits returns true when pods meeting profile other also meet this profile.
+func (p SecurityProfile) Admits(other SecurityProfile) bool {
+	return securityProfileLevels[other] >= securityProfileLevels[p]
+}
+
+// workloadField associates a workload config with its path in a cluster spec.
+type workloadField struct {
+	path   *field.Path
+	config *WorkloadConfig
+}
+
+func workloadAt(path *field.Path, config *WorkloadConfig) workloadField {
+	return workloadField{path: path, config: config}
+}
+
+// validateSecurityProfile verifies that the cluster spec meets its security
+// profile. The profile is only checked against the namespace when it is set
+// or changed, and never for clusters being deleted, so that finalizers can
+// still be removed after the namespace enforces a stricter standard.
+func validateSecurityProfile(obj metav1.Object, oldProfile SecurityProfile, cc *ClusterConfig, workloads ...workloadField) field.ErrorList {
+	profile := cc.SecurityProfile
+	if profile == "" {
+		return nil
+	}
+
+	fp := field.NewPath("spec", "securityProfile")
+	if _, ok := securityProfileLevels[profile]; !ok {
+		return field.ErrorList{field.NotSupported(fp, profile, []string{
+			string(SecurityProfilePrivileged),
+			string(SecurityProfileBaseline),
+			string(SecurityProfileRestricted),
+		})}
+	}
+
+	var errs field.ErrorList
+	if obj.GetDeletionTimestamp() == nil && profile != oldProfile {
+		if err := validateNamespaceSecurityProfile(fp, obj.GetNamespace(), profile); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if profile == SecurityProfilePrivileged {
+		return errs
+	}
+
+	errs = append(errs, validatePodSecurityContext(field.NewPath("spec", "podSecurityContext"), cc.PodSecurityContext, profile)...)
+	for _, wl := range workloads {
+		errs = append(errs, validateWorkloadSecurity(wl.path, wl.config, profile)...)
+	}
+
+	return errs
+}
+
+func validateNamespaceSecurityProfile(fp *field.Path, namespace string, profile SecurityProfile) *field.Error {
+	if apiReader == nil || namespace == "" {
+		return nil
+	}
+
+	var ns corev1.Namespace
+	if err := apiReader.Get(context.Background(), types.NamespacedName{Name: namespace}, &ns); err != nil {
+		return field.InternalError(fp, fmt.Errorf("cannot verify namespace %q: %w", namespace, err))
+	}
+
+	enforced := SecurityProfile(ns.Labels[PodSecurityEnforceLabel])
+	if _, ok := securityProfileLevels[enforced]; !ok {
+		enforced = SecurityProfilePrivileged
+	}
+	if !enforced.Admits(profile) {
+		return field.Forbidden(fp, fmt.Sprintf("namespace %q enforces the %q pod security standard", namespace, enforced))
+	}
+
+	return nil
+}
+
+func validatePodSecurityContext(fp *field.Path, psc *corev1.PodSecurityContext, profile SecurityProfile) field.ErrorList {
+	if psc == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	if psc.SeccompProfile != nil && psc.SeccompProfile.Type == corev1.SeccompProfileTypeUnconfined {
+		errs = append(errs, field.Forbidden(fp.Child("seccompProfile", "type"), "cannot be Unconfined"))
+	}
+	if profile == SecurityProfileRestricted {
+		if psc.RunAsNonRoot != nil && !*psc.RunAsNonRoot {
+			errs = append(errs, field.Forbidden(fp.Child("runAsNonRoot"), "cannot be false"))
+		}
+		if psc.RunAsUser != nil && *psc.RunAsUser == 0 {
+			errs = append(errs, field.Forbidden(fp.Child("runAsUser"), "cannot be 0"))
+		}
+	}
+
+	return errs
+}
+
+func validateWorkloadSecurity(fp *field.Path, wc *WorkloadConfig, profile SecurityProfile) field.ErrorList {
+	var errs field.ErrorList
+
+	for idx := range wc.Volumes {
+		errs = append(errs, validateVolumeSecurity(fp.Child("volumes").Index(idx), &wc.Volumes[idx], profile)...)
+	}
+	for idx := range wc.InitContainers {
+		cp := fp.Child("initContainers").Index(idx)
+		for pidx, port := range wc.InitContainers[idx].Ports {
+			if port.HostPort != 0 {
+				errs = append(errs, field.Forbidden(cp.Child("ports").Index(pidx).Child("hostPort"), "host ports are not allowed"))
+			}
+		}
+		errs = append(errs, validateSecurityContext(cp.Child("securityContext"), wc.InitContainers[idx].SecurityContext, profile)...)
+	}
+
+	return append(errs, validateSecurityContext(fp.Child("securityContext"), wc.SecurityContext, profile)...)
+}
+
+func validateVolumeSecurity(fp *field.Path, vol *corev1.Volume, profile SecurityProfile) field.ErrorList {
+	src := vol.VolumeSource
+	if src.HostPath != nil {
+		return field.ErrorList{field.Forbidden(fp.Child("hostPath"), "host path volumes are not allowed")}
+	}
+	if profile != SecurityProfileRestricted {
+		return nil
+	}
+
+	switch {
+	case src.ConfigMap != nil, src.CSI != nil, src.DownwardAPI != nil, src.EmptyDir != nil, src.Ephemeral != nil,
+		src.PersistentVolumeClaim != nil, src.Projected != nil, src.Secret != nil:
+		return nil
+	}
+
+	return field.ErrorList{field.Forbidden(fp, fmt.Sprintf("volume type must be one of %v", restrictedVolumeTypes))}
+}
+
+func validateSecurityContext(fp *field.Path, sc *corev1.SecurityContext, profile SecurityProfile) field.ErrorList {
+	if sc == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	if sc.Privileged != nil && *sc.Privileged {
+		errs = append(errs, field.Forbidden(fp.Child("privileged"), "cannot be true"))
+	}
+	if sc.SeccompProfile != nil && sc.SeccompProfile.Type == corev1.SeccompProfileTypeUnconfined {
+		errs = append(errs, field.Forbidden(fp.Child("seccompProfile", "type"), "cannot be Unconfined"))
+	}
+
+	allowed := baselineCapabilities
+	if profile == SecurityProfileRestricted {
+		allowed = restrictedCapabilities
+	}
+	if sc.Capabilities != nil {
+		for idx, capability := range sc.Capabilities.Add {
+			if !allowed.Has(capability) {
+				errs = append(errs, field.Forbidden(fp.Child("capabilities", "add").Index(idx),
+					fmt.Sprintf("capability %q is not allowed", capability)))
+			}
+		}
+	}
+
+	if profile == SecurityProfileRestricted {
+		if sc.AllowPrivilegeEscalation != nil && *sc.AllowPrivilegeEscalation {
+			errs = append(errs, field.Forbidden(fp.Child("allowPrivilegeEscalation"), "cannot be true"))
+		}
+		if sc.RunAsNonRoot != nil && !*sc.RunAsNonRoot {
+			errs = append(errs, field.Forbidden(fp.Child("runAsNonRoot"), "cannot be false"))
+		}
+		if sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+			errs = append(errs, field.Forbidden(fp.Child("runAsUser"), "cannot be 0"))
+		}
+	}
+
+	return errs
+}
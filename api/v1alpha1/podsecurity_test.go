@@ -0,0 +1,56 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidateSecurityProfileNamespace(t *testing.T) {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "ns",
+			Labels: map[string]string{PodSecurityEnforceLabel: string(SecurityProfileRestricted)},
+		},
+	}
+	useAPIReader(t, fake.NewClientBuilder().WithObjects(ns).Build())
+
+	dc := testPolicyCluster()
+	dc.Spec.SecurityProfile = SecurityProfileBaseline
+
+	t.Run("create", func(t *testing.T) {
+		errs := validateSecurityProfile(dc, "", &dc.Spec.ClusterConfig)
+		require.Len(t, errs, 1)
+		assert.Equal(t, field.ErrorTypeForbidden, errs[0].Type)
+		assert.Equal(t, "spec.securityProfile", errs[0].Field)
+	})
+
+	t.Run("profile_changed", func(t *testing.T) {
+		assert.Len(t, validateSecurityProfile(dc, SecurityProfileRestricted, &dc.Spec.ClusterConfig), 1)
+	})
+
+	t.Run("profile_unchanged", func(t *testing.T) {
+		assert.Empty(t, validateSecurityProfile(dc, SecurityProfileBaseline, &dc.Spec.ClusterConfig))
+	})
+
+	t.Run("deleting", func(t *testing.T) {
+		deleting := dc.DeepCopy()
+		deleting.DeletionTimestamp = &metav1.Time{}
+
+		assert.Empty(t, validateSecurityProfile(deleting, "", &deleting.Spec.ClusterConfig))
+	})
+
+	t.Run("missing_namespace", func(t *testing.T) {
+		useAPIReader(t, fake.NewClientBuilder().Build())
+
+		errs := validateSecurityProfile(dc, "", &dc.Spec.ClusterConfig)
+		require.Len(t, errs, 1)
+		assert.Equal(t, field.ErrorTypeInternal, errs[0].Type)
+		assert.Empty(t, validateSecurityProfile(dc, SecurityProfileBaseline, &dc.Spec.ClusterConfig))
+	})
+}
@@ -0,0 +1,402 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// apiReader is used by validating webhooks to look up namespaces, policies
+// and existing clusters; these checks are skipped when it has not been set.
+var apiReader client.Reader
+
+//+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=distributedcomputepolicies,verbs=get;list;watch
+
+// SetAPIReader configures the reader used by validating webhooks to verify
+// clusters against the state of their namespace.
+func SetAPIReader(r client.Reader) {
+	apiReader = r
+}
+
+// imageField associates an image with its path in a cluster spec.
+type imageField struct {
+	path  *field.Path
+	image *OCIImageDefinition
+}
+
+func imageAt(path *field.Path, image *OCIImageDefinition) imageField {
+	return imageField{path: path, image: image}
+}
+
+// policyTarget describes the parts of a cluster constrained by policies.
+type policyTarget struct {
+	object client.Object
+	// old is the target of the cluster being replaced by an update; it is nil
+	// when the cluster is created.
+	old    *policyTarget
+	config *ClusterConfig
+	images []imageField
+	// singletons are workloads with exactly one pod, e.g. a head node.
+	singletons  []workloadField
+	worker      workloadField
+	replicas    *int32
+	autoscaling *Autoscaling
+}
+
+// validatePolicies verifies a cluster against the policies of its namespace.
+// Updates are only rejected when they introduce a violation or make an
+// existing one worse, so that clusters created before a policy can still be
+// modified, e.g. to remove their finalizers. Clusters being deleted are not
+// verified at all.
+func validatePolicies(t *policyTarget) field.ErrorList {
+	namespace := t.object.GetNamespace()
+	if apiReader == nil || namespace == "" || t.object.GetDeletionTimestamp() != nil {
+		return nil
+	}
+
+	fp := field.NewPath("metadata", "namespace")
+
+	var policies DistributedComputePolicyList
+	if err := apiReader.List(context.Background(), &policies, client.InNamespace(namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return field.ErrorList{field.InternalError(fp, fmt.Errorf("cannot list distributed compute policies: %w", err))}
+	}
+
+	var errs field.ErrorList
+	for idx := range policies.Items {
+		policy := &policies.Items[idx]
+
+		errs = append(errs, t.validateWorkers(policy)...)
+		errs = append(errs, t.validatePodResources(policy)...)
+		errs = append(errs, t.validateClusterResources(policy)...)
+		errs = append(errs, t.validateImages(policy)...)
+		errs = append(errs, t.validateIstio(policy)...)
+		errs = append(errs, t.validateNetworkPolicy(policy)...)
+		errs = append(errs, t.validateKeytab(policy)...)
+
+		if t.old == nil && policy.Spec.MaxClusters != nil {
+			if err := validateMaxClusters(fp, namespace, policy); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errs
+}
+
+// workerCount returns the maximum number of workers and the path of the
+// field that sets it.
+func (t *policyTarget) workerCount() (int32, *field.Path) {
+	if t.autoscaling != nil {
+		return t.autoscaling.MaxReplicas, field.NewPath("spec", "autoscaling", "maxReplicas")
+	}
+
+	var replicas int32
+	if t.replicas != nil {
+		replicas = *t.replicas
+	}
+	return replicas, t.worker.path.Child("replicas")
+}
+
+func (t *policyTarget) validateWorkers(p *DistributedComputePolicy) field.ErrorList {
+	max := p.Spec.MaxWorkers
+	if max == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	msg := fmt.Sprintf("exceeds the maximum of %d workers allowed by policy %q", *max, p.Name)
+
+	if t.replicas != nil && *t.replicas > *max && !t.old.hasReplicas(*t.replicas) {
+		errs = append(errs, field.Invalid(t.worker.path.Child("replicas"), *t.replicas, msg))
+	}
+	if t.autoscaling != nil && t.autoscaling.MaxReplicas > *max && !t.old.hasMaxReplicas(t.autoscaling.MaxReplicas) {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "autoscaling", "maxReplicas"), t.autoscaling.MaxReplicas, msg))
+	}
+
+	return errs
+}
+
+func (t *policyTarget) validatePodResources(p *DistributedComputePolicy) field.ErrorList {
+	if len(p.Spec.MaxPodResources) == 0 {
+		return nil
+	}
+
+	var errs field.ErrorList
+	oldWorkloads := t.old.workloads()
+	for widx, wl := range t.workloads() {
+		var old *WorkloadConfig
+		if widx < len(oldWorkloads) {
+			old = oldWorkloads[widx].config
+		}
+
+		for idx := range wl.config.InitContainers {
+			var oldRR *corev1.ResourceRequirements
+			if old != nil && idx < len(old.InitContainers) {
+				oldRR = &old.InitContainers[idx].Resources
+			}
+			cp := wl.path.Child("initContainers").Index(idx).Child("resources")
+			errs = append(errs, validateResourceMaximums(cp, &wl.config.InitContainers[idx].Resources, oldRR, p)...)
+		}
+
+		var oldRR *corev1.ResourceRequirements
+		if old != nil {
+			oldRR = &old.Resources
+		}
+		errs = append(errs, validateResourceMaximums(wl.path.Child("resources"), &wl.config.Resources, oldRR, p)...)
+	}
+
+	return errs
+}
+
+// validateResourceMaximums verifies the requests and limits of a container.
+// Quantities that do not exceed the old ones of an updated container are
+// accepted.
+func validateResourceMaximums(fp *field.Path, rr, old *corev1.ResourceRequirements, p *DistributedComputePolicy) field.ErrorList {
+	if old == nil {
+		old = &corev1.ResourceRequirements{}
+	}
+
+	var errs field.ErrorList
+	for _, name := range sortedResourceNames(p.Spec.MaxPodResources) {
+		max := p.Spec.MaxPodResources[name]
+		msg := fmt.Sprintf("exceeds the maximum of %s allowed by policy %q", max.String(), p.Name)
+
+		if q, ok := rr.Requests[name]; ok && q.Cmp(max) > 0 && !quantityCovers(old.Requests, name, q) {
+			errs = append(errs, field.Invalid(fp.Child("requests").Key(string(name)), q.String(), msg))
+		}
+		if q, ok := rr.Limits[name]; ok && q.Cmp(max) > 0 && !quantityCovers(old.Limits, name, q) {
+			errs = append(errs, field.Invalid(fp.Child("limits").Key(string(name)), q.String(), msg))
+		}
+	}
+
+	return errs
+}
+
+// quantityCovers returns true when a resource list holds at least q of a resource.
+func quantityCovers(rl corev1.ResourceList, name corev1.ResourceName, q resource.Quantity) bool {
+	old, ok := rl[name]
+	return ok && old.Cmp(q) >= 0
+}
+
+func (t *policyTarget) validateClusterResources(p *DistributedComputePolicy) field.ErrorList {
+	if len(p.Spec.MaxClusterResources) == 0 {
+		return nil
+	}
+
+	var errs field.ErrorList
+	count, fp := t.workerCount()
+
+	for _, name := range sortedResourceNames(p.Spec.MaxClusterResources) {
+		total := t.totalRequests(name)
+		if t.old != nil {
+			if old := t.old.totalRequests(name); old.Cmp(total) >= 0 {
+				continue
+			}
+		}
+
+		if max := p.Spec.MaxClusterResources[name]; total.Cmp(max) > 0 {
+			errs = append(errs, field.Invalid(fp, count, fmt.Sprintf(
+				"total %s requests of %s exceed the maximum of %s allowed by policy %q",
+				name, total.String(), max.String(), p.Name,
+			)))
+		}
+	}
+
+	return errs
+}
+
+// totalRequests returns the sum of the requests of a resource by all pods
+// of the cluster, counting the maximum number of workers.
+func (t *policyTarget) totalRequests(name corev1.ResourceName) resource.Quantity {
+	total := resource.Quantity{}
+	for _, wl := range t.singletons {
+		if q, ok := wl.config.Resources.Requests[name]; ok {
+			total.Add(q)
+		}
+	}
+	if q, ok := t.worker.config.Resources.Requests[name]; ok {
+		count, _ := t.workerCount()
+		total.Add(*resource.NewMilliQuantity(q.MilliValue()*int64(count), q.Format))
+	}
+
+	return total
+}
+
+func (t *policyTarget) validateImages(p *DistributedComputePolicy) field.ErrorList {
+	if len(p.Spec.AllowedImages) == 0 {
+		return nil
+	}
+
+	var errs field.ErrorList
+	for idx, img := range t.images {
+		if img.image == nil || imageAllowed(img.image, p.Spec.AllowedImages) || t.old.hasImage(idx, img.image) {
+			continue
+		}
+
+		ref := img.image.Repository
+		if img.image.Registry != "" {
+			ref = img.image.Registry + "/" + ref
+		}
+		errs = append(errs, field.Forbidden(img.path, fmt.Sprintf("image %q is not allowed by policy %q", ref, p.Name)))
+	}
+
+	return errs
+}
+
+func imageAllowed(image *OCIImageDefinition, allowed []AllowedImage) bool {
+	for _, a := range allowed {
+		if a.Registry != image.Registry {
+			continue
+		}
+
+		switch {
+		case a.Repository == "", a.Repository == image.Repository:
+			return true
+		case strings.HasSuffix(a.Repository, "*") && strings.HasPrefix(image.Repository, strings.TrimSuffix(a.Repository, "*")):
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *policyTarget) validateIstio(p *DistributedComputePolicy) field.ErrorList {
+	mode := t.config.MutualTLSMode
+	allowed := p.Spec.AllowedIstioMutualTLSModes
+	if mode == "" || len(allowed) == 0 || (t.old != nil && t.old.config.MutualTLSMode == mode) {
+		return nil
+	}
+
+	for _, m := range allowed {
+		if m == mode {
+			return nil
+		}
+	}
+
+	return field.ErrorList{field.Forbidden(
+		field.NewPath("spec", "istioMutualTLSMode"),
+		fmt.Sprintf("mode %q is not allowed by policy %q, allowed modes are %v", mode, p.Name, allowed),
+	)}
+}
+
+func (t *policyTarget) validateNetworkPolicy(p *DistributedComputePolicy) field.ErrorList {
+	if !p.Spec.RequireNetworkPolicy || t.config.networkPolicyEnabled() {
+		return nil
+	}
+	if t.old != nil && !t.old.config.networkPolicyEnabled() {
+		return nil
+	}
+
+	return field.ErrorList{field.Forbidden(
+		field.NewPath("spec", "networkPolicy", "enabled"),
+		fmt.Sprintf("network policies are required by policy %q", p.Name),
+	)}
+}
+
+func (t *policyTarget) validateKeytab(p *DistributedComputePolicy) field.ErrorList {
+	if !p.Spec.ForbidInlineKeytabs || !t.config.inlineKeytab() {
+		return nil
+	}
+	if t.old != nil && t.old.config.inlineKeytab() {
+		return nil
+	}
+
+	return field.ErrorList{field.Forbidden(
+		field.NewPath("spec", "kerberosKeytab", "contents"),
+		fmt.Sprintf("inline keytabs are forbidden by policy %q, use secretRef instead", p.Name),
+	)}
+}
+
+// workloads returns the workloads of a target, or nil when it is nil.
+func (t *policyTarget) workloads() []workloadField {
+	if t == nil {
+		return nil
+	}
+
+	workloads := make([]workloadField, 0, len(t.singletons)+1)
+	workloads = append(workloads, t.singletons...)
+	return append(workloads, t.worker)
+}
+
+// hasReplicas returns true when a target requests at least n worker replicas.
+func (t *policyTarget) hasReplicas(n int32) bool {
+	return t != nil && t.replicas != nil && *t.replicas >= n
+}
+
+// hasMaxReplicas returns true when a target autoscales to at least n workers.
+func (t *policyTarget) hasMaxReplicas(n int32) bool {
+	return t != nil && t.autoscaling != nil && t.autoscaling.MaxReplicas >= n
+}
+
+// hasImage returns true when the image at the index of a target comes from
+// the same repository as the given image.
+func (t *policyTarget) hasImage(idx int, image *OCIImageDefinition) bool {
+	if t == nil || idx >= len(t.images) || t.images[idx].image == nil {
+		return false
+	}
+
+	old := t.images[idx].image
+	return old.Registry == image.Registry && old.Repository == image.Repository
+}
+
+func (c *ClusterConfig) networkPolicyEnabled() bool {
+	return c.NetworkPolicy.Enabled != nil && *c.NetworkPolicy.Enabled
+}
+
+func (c *ClusterConfig) inlineKeytab() bool {
+	return c.KerberosKeytab != nil && len(c.KerberosKeytab.Contents) != 0
+}
+
+func validateMaxClusters(fp *field.Path, namespace string, p *DistributedComputePolicy) *field.Error {
+	count, err := countClusters(namespace)
+	if err != nil {
+		return field.InternalError(fp, err)
+	}
+	if count < int(*p.Spec.MaxClusters) {
+		return nil
+	}
+
+	return field.Forbidden(fp, fmt.Sprintf(
+		"namespace %q already has %d clusters, the maximum allowed by policy %q", namespace, count, p.Name,
+	))
+}
+
+// countClusters returns the number of clusters of all kinds in a namespace.
+func countClusters(namespace string) (int, error) {
+	lists := []client.ObjectList{
+		&DaskClusterList{},
+		&MPIClusterList{},
+		&RayClusterList{},
+		&SparkClusterList{},
+	}
+
+	var count int
+	for _, list := range lists {
+		if err := apiReader.List(context.Background(), list, client.InNamespace(namespace)); err != nil {
+			return 0, fmt.Errorf("cannot count clusters: %w", err)
+		}
+		count += meta.LenList(list)
+	}
+
+	return count, nil
+}
+
+func sortedResourceNames(rl corev1.ResourceList) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(rl))
+	for name := range rl {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+
+	return names
+}
@@ -0,0 +1,478 @@
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// failingReader fails to list objects with a fixed error.
+type failingReader struct {
+	client.Reader
+	err error
+}
+
+func (r failingReader) List(context.Context, client.ObjectList, ...client.ListOption) error {
+	return r.err
+}
+
+func useAPIReader(t *testing.T, r client.Reader) {
+	t.Helper()
+
+	previous := apiReader
+	SetAPIReader(r)
+	t.Cleanup(func() { SetAPIReader(previous) })
+}
+
+func policyReader(t *testing.T, objs ...client.Object) client.Reader {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func testPolicy(spec DistributedComputePolicySpec) *DistributedComputePolicy {
+	return &DistributedComputePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "ns"},
+		Spec:       spec,
+	}
+}
+
+func testPolicyCluster() *DaskCluster {
+	return &DaskCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+		Spec: DaskClusterSpec{
+			ScalableClusterConfig: ScalableClusterConfig{
+				ClusterConfig: ClusterConfig{
+					Image: &OCIImageDefinition{
+						Registry:   "registry.example.com",
+						Repository: "dask/dask",
+						Tag:        "2023.1.0",
+					},
+				},
+			},
+			Scheduler: WorkloadConfig{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				},
+			},
+			Worker: DaskClusterWorker{
+				WorkloadConfig: WorkloadConfig{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+					},
+				},
+				Replicas: pointer.Int32(2),
+			},
+		},
+	}
+}
+
+func TestValidatePolicies(t *testing.T) {
+	testcases := []struct {
+		name     string
+		policy   DistributedComputePolicySpec
+		modify   func(dc *DaskCluster)
+		expected field.ErrorList
+	}{
+		{
+			name:   "compliant",
+			policy: DistributedComputePolicySpec{MaxWorkers: pointer.Int32(2)},
+		},
+		{
+			name:   "max_workers_replicas",
+			policy: DistributedComputePolicySpec{MaxWorkers: pointer.Int32(1)},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "worker", "replicas"), int32(2),
+					`exceeds the maximum of 1 workers allowed by policy "limits"`),
+			},
+		},
+		{
+			name:   "max_workers_autoscaling",
+			policy: DistributedComputePolicySpec{MaxWorkers: pointer.Int32(3)},
+			modify: func(dc *DaskCluster) {
+				dc.Spec.Autoscaling = &Autoscaling{MaxReplicas: 4}
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "autoscaling", "maxReplicas"), int32(4),
+					`exceeds the maximum of 3 workers allowed by policy "limits"`),
+			},
+		},
+		{
+			name: "max_pod_resources",
+			policy: DistributedComputePolicySpec{
+				MaxPodResources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1500m")},
+			},
+			modify: func(dc *DaskCluster) {
+				dc.Spec.Worker.InitContainers = []corev1.Container{
+					{
+						Name: "init",
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+						},
+					},
+				}
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "worker", "initContainers").Index(0).Child("resources", "limits").Key("cpu"), "3",
+					`exceeds the maximum of 1500m allowed by policy "limits"`),
+				field.Invalid(field.NewPath("spec", "worker", "resources", "requests").Key("cpu"), "2",
+					`exceeds the maximum of 1500m allowed by policy "limits"`),
+			},
+		},
+		{
+			name: "max_cluster_resources",
+			policy: DistributedComputePolicySpec{
+				MaxClusterResources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "worker", "replicas"), int32(2),
+					`total cpu requests of 5 exceed the maximum of 4 allowed by policy "limits"`),
+			},
+		},
+		{
+			name: "max_cluster_resources_milli",
+			policy: DistributedComputePolicySpec{
+				MaxClusterResources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			},
+			modify: func(dc *DaskCluster) {
+				dc.Spec.Worker.Resources.Requests[corev1.ResourceCPU] = resource.MustParse("500m")
+				dc.Spec.Worker.Replicas = pointer.Int32(3)
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "worker", "replicas"), int32(3),
+					`total cpu requests of 2500m exceed the maximum of 2 allowed by policy "limits"`),
+			},
+		},
+		{
+			name: "max_cluster_resources_autoscaling",
+			policy: DistributedComputePolicySpec{
+				MaxClusterResources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+			},
+			modify: func(dc *DaskCluster) {
+				dc.Spec.Autoscaling = &Autoscaling{MaxReplicas: 4}
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "autoscaling", "maxReplicas"), int32(4),
+					`total cpu requests of 9 exceed the maximum of 8 allowed by policy "limits"`),
+			},
+		},
+		{
+			name: "allowed_images",
+			policy: DistributedComputePolicySpec{
+				AllowedImages: []AllowedImage{{Registry: "registry.example.com", Repository: "other/*"}},
+			},
+			expected: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "image"),
+					`image "registry.example.com/dask/dask" is not allowed by policy "limits"`),
+			},
+		},
+		{
+			name:   "allowed_istio_mutual_tls_modes",
+			policy: DistributedComputePolicySpec{AllowedIstioMutualTLSModes: []string{"STRICT"}},
+			modify: func(dc *DaskCluster) {
+				dc.Spec.MutualTLSMode = "DISABLE"
+			},
+			expected: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "istioMutualTLSMode"),
+					`mode "DISABLE" is not allowed by policy "limits", allowed modes are [STRICT]`),
+			},
+		},
+		{
+			name:   "require_network_policy",
+			policy: DistributedComputePolicySpec{RequireNetworkPolicy: true},
+			expected: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "networkPolicy", "enabled"),
+					`network policies are required by policy "limits"`),
+			},
+		},
+		{
+			name:   "forbid_inline_keytabs",
+			policy: DistributedComputePolicySpec{ForbidInlineKeytabs: true},
+			modify: func(dc *DaskCluster) {
+				dc.Spec.KerberosKeytab = &KerberosKeytabConfig{Contents: []byte("keytab")}
+			},
+			expected: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "kerberosKeytab", "contents"),
+					`inline keytabs are forbidden by policy "limits", use secretRef instead`),
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			useAPIReader(t, policyReader(t, testPolicy(tc.policy)))
+
+			dc := testPolicyCluster()
+			if tc.modify != nil {
+				tc.modify(dc)
+			}
+
+			assert.Equal(t, tc.expected, validatePolicies(dc.policyTarget(nil)))
+		})
+	}
+}
+
+func TestValidatePoliciesUpdate(t *testing.T) {
+	testcases := []struct {
+		name     string
+		policy   DistributedComputePolicySpec
+		old      func(dc *DaskCluster)
+		modify   func(dc *DaskCluster)
+		expected field.ErrorList
+	}{
+		{
+			name:   "unchanged_violation",
+			policy: DistributedComputePolicySpec{MaxWorkers: pointer.Int32(1), RequireNetworkPolicy: true},
+		},
+		{
+			name:   "reduced_violation",
+			policy: DistributedComputePolicySpec{MaxWorkers: pointer.Int32(1)},
+			old: func(dc *DaskCluster) {
+				dc.Spec.Worker.Replicas = pointer.Int32(3)
+			},
+		},
+		{
+			name:   "worse_violation",
+			policy: DistributedComputePolicySpec{MaxWorkers: pointer.Int32(1)},
+			modify: func(dc *DaskCluster) {
+				dc.Spec.Worker.Replicas = pointer.Int32(3)
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "worker", "replicas"), int32(3),
+					`exceeds the maximum of 1 workers allowed by policy "limits"`),
+			},
+		},
+		{
+			name: "reduced_pod_resources",
+			policy: DistributedComputePolicySpec{
+				MaxPodResources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			},
+			old: func(dc *DaskCluster) {
+				dc.Spec.Worker.Resources.Requests[corev1.ResourceCPU] = resource.MustParse("3")
+			},
+		},
+		{
+			name: "worse_cluster_resources",
+			policy: DistributedComputePolicySpec{
+				MaxClusterResources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			},
+			modify: func(dc *DaskCluster) {
+				dc.Spec.Scheduler.Resources.Requests[corev1.ResourceCPU] = resource.MustParse("2")
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "worker", "replicas"), int32(2),
+					`total cpu requests of 6 exceed the maximum of 4 allowed by policy "limits"`),
+			},
+		},
+		{
+			name: "image_tag_changed",
+			policy: DistributedComputePolicySpec{
+				AllowedImages: []AllowedImage{{Registry: "registry.example.com", Repository: "other"}},
+			},
+			modify: func(dc *DaskCluster) {
+				dc.Spec.Image.Tag = "2023.2.0"
+			},
+		},
+		{
+			name: "image_repository_changed",
+			policy: DistributedComputePolicySpec{
+				AllowedImages: []AllowedImage{{Registry: "registry.example.com", Repository: "other"}},
+			},
+			modify: func(dc *DaskCluster) {
+				dc.Spec.Image.Repository = "dask/custom"
+			},
+			expected: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "image"),
+					`image "registry.example.com/dask/custom" is not allowed by policy "limits"`),
+			},
+		},
+		{
+			name:   "network_policy_disabled",
+			policy: DistributedComputePolicySpec{RequireNetworkPolicy: true},
+			old: func(dc *DaskCluster) {
+				dc.Spec.NetworkPolicy.Enabled = pointer.Bool(true)
+			},
+			expected: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "networkPolicy", "enabled"),
+					`network policies are required by policy "limits"`),
+			},
+		},
+		{
+			name:   "istio_mode_changed",
+			policy: DistributedComputePolicySpec{AllowedIstioMutualTLSModes: []string{"STRICT"}},
+			old: func(dc *DaskCluster) {
+				dc.Spec.MutualTLSMode = "PERMISSIVE"
+			},
+			modify: func(dc *DaskCluster) {
+				dc.Spec.MutualTLSMode = "DISABLE"
+			},
+			expected: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "istioMutualTLSMode"),
+					`mode "DISABLE" is not allowed by policy "limits", allowed modes are [STRICT]`),
+			},
+		},
+		{
+			name:   "unchanged_inline_keytab",
+			policy: DistributedComputePolicySpec{ForbidInlineKeytabs: true},
+			old: func(dc *DaskCluster) {
+				dc.Spec.KerberosKeytab = &KerberosKeytabConfig{Contents: []byte("keytab")}
+			},
+			modify: func(dc *DaskCluster) {
+				dc.Spec.KerberosKeytab = &KerberosKeytabConfig{Contents: []byte("keytab")}
+			},
+		},
+		{
+			name:   "deleting",
+			policy: DistributedComputePolicySpec{MaxWorkers: pointer.Int32(1)},
+			modify: func(dc *DaskCluster) {
+				now := metav1.Now()
+				dc.DeletionTimestamp = &now
+				dc.Finalizers = nil
+				dc.Spec.Worker.Replicas = pointer.Int32(3)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			useAPIReader(t, policyReader(t, testPolicy(tc.policy)))
+
+			old := testPolicyCluster()
+			if tc.old != nil {
+				tc.old(old)
+			}
+			dc := testPolicyCluster()
+			if tc.modify != nil {
+				tc.modify(dc)
+			}
+
+			assert.Equal(t, tc.expected, validatePolicies(dc.policyTarget(old)))
+		})
+	}
+}
+
+func TestValidatePoliciesMaxClusters(t *testing.T) {
+	policy := testPolicy(DistributedComputePolicySpec{MaxClusters: pointer.Int32(1)})
+	existing := &RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "ns"}}
+	useAPIReader(t, policyReader(t, policy, existing))
+
+	dc := testPolicyCluster()
+	assert.Equal(t, field.ErrorList{
+		field.Forbidden(field.NewPath("metadata", "namespace"),
+			`namespace "ns" already has 1 clusters, the maximum allowed by policy "limits"`),
+	}, validatePolicies(dc.policyTarget(nil)))
+
+	assert.Empty(t, validatePolicies(dc.policyTarget(testPolicyCluster())))
+}
+
+func TestValidatePoliciesReader(t *testing.T) {
+	dc := testPolicyCluster()
+
+	t.Run("unset", func(t *testing.T) {
+		useAPIReader(t, nil)
+		assert.Empty(t, validatePolicies(dc.policyTarget(nil)))
+	})
+
+	t.Run("no_match", func(t *testing.T) {
+		useAPIReader(t, failingReader{err: &meta.NoKindMatchError{
+			GroupKind: schema.GroupKind{Group: GroupVersion.Group, Kind: "DistributedComputePolicy"},
+		}})
+		assert.Empty(t, validatePolicies(dc.policyTarget(nil)))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		useAPIReader(t, failingReader{err: errors.New("boom")})
+
+		errs := validatePolicies(dc.policyTarget(nil))
+		require.Len(t, errs, 1)
+		assert.Equal(t, field.ErrorTypeInternal, errs[0].Type)
+		assert.Equal(t, "metadata.namespace", errs[0].Field)
+	})
+}
+
+func TestCountClusters(t *testing.T) {
+	useAPIReader(t, policyReader(t,
+		&DaskCluster{ObjectMeta: metav1.ObjectMeta{Name: "dask", Namespace: "ns"}},
+		&MPICluster{ObjectMeta: metav1.ObjectMeta{Name: "mpi", Namespace: "ns"}},
+		&RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "ray", Namespace: "ns"}},
+		&SparkCluster{ObjectMeta: metav1.ObjectMeta{Name: "spark", Namespace: "ns"}},
+		&SparkCluster{ObjectMeta: metav1.ObjectMeta{Name: "spark", Namespace: "other"}},
+	))
+
+	count, err := countClusters("ns")
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+
+	count, err = countClusters("empty")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestImageAllowed(t *testing.T) {
+	image := &OCIImageDefinition{Registry: "registry.example.com", Repository: "dask/dask"}
+
+	testcases := []struct {
+		name     string
+		allowed  []AllowedImage
+		expected bool
+	}{
+		{
+			name:     "exact",
+			allowed:  []AllowedImage{{Registry: "registry.example.com", Repository: "dask/dask"}},
+			expected: true,
+		},
+		{
+			name:     "any_repository",
+			allowed:  []AllowedImage{{Registry: "registry.example.com"}},
+			expected: true,
+		},
+		{
+			name:     "wildcard",
+			allowed:  []AllowedImage{{Registry: "registry.example.com", Repository: "dask/*"}},
+			expected: true,
+		},
+		{
+			name:     "wildcard_prefix",
+			allowed:  []AllowedImage{{Registry: "registry.example.com", Repository: "da*"}},
+			expected: true,
+		},
+		{
+			name:    "wildcard_mismatch",
+			allowed: []AllowedImage{{Registry: "registry.example.com", Repository: "ray/*"}},
+		},
+		{
+			name:    "repository_mismatch",
+			allowed: []AllowedImage{{Registry: "registry.example.com", Repository: "dask"}},
+		},
+		{
+			name:    "registry_mismatch",
+			allowed: []AllowedImage{{Registry: "docker.io", Repository: "dask/dask"}},
+		},
+		{
+			name:    "none",
+			allowed: nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, imageAllowed(image, tc.allowed))
+		})
+	}
+}
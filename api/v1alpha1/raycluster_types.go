@@ -47,10 +47,19 @@ type RayClusterSpec struct {
 	ObjectStoreMemoryBytes *int64 `json:"objectStoreMemoryBytes,omitempty"`
 	// DashboardPort is the port used by the dashboard server.
 	DashboardPort int32 `json:"dashboardPort,omitempty"`
+	// MetricsExportPort is the port on which every node exports Prometheus
+	// metrics when monitoring is enabled.
+	MetricsExportPort int32 `json:"metricsExportPort,omitempty"`
 	// EnableDashboard starts the dashboard web UI.
 	EnableDashboard *bool `json:"enableDashboard,omitempty"`
 	// AdditionalClientPorts are extra ports through which cluster nodes could connect to the client.
 	AdditionalClientPorts []corev1.ServicePort `json:"additionalClientPorts,omitempty"`
+	// TLS secures gRPC communication between ray nodes and clients. Clients
+	// connecting with "ray://" authenticate with the certificate published in
+	// the "<name>-ray-client-tls" Secret. Every pod signs a certificate for its
+	// IP address at startup with the openssl binary of the ray image, so an
+	// IssuerRef must be able to sign intermediate CA certificates.
+	TLS *TLSConfig `json:"tls,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -19,6 +19,7 @@ var (
 	rayDefaultNodeManagerPort        int32 = 2385
 	rayDefaultGCSServerPort          int32 = 2386
 	rayDefaultDashboardPort          int32 = 8265
+	rayDefaultMetricsExportPort      int32 = 8080
 	rayDefaultRedisShardPorts              = []int32{6380, 6381}
 	rayDefaultEnableDashboard              = pointer.Bool(true)
 	rayDefaultEnableNetworkPolicy          = pointer.Bool(true)
@@ -81,6 +82,10 @@ func (rc *RayCluster) Default() {
 		log.Info("Setting default dashboard port", "value", rayDefaultDashboardPort)
 		rc.Spec.DashboardPort = rayDefaultDashboardPort
 	}
+	if spec.MetricsExportPort == 0 {
+		log.Info("Setting default metrics export port", "value", rayDefaultMetricsExportPort)
+		rc.Spec.MetricsExportPort = rayDefaultMetricsExportPort
+	}
 	if spec.EnableDashboard == nil {
 		log.Info("Setting enable dashboard flag", "value", *rayDefaultEnableDashboard)
 		rc.Spec.EnableDashboard = rayDefaultEnableDashboard
@@ -105,6 +110,20 @@ func (rc *RayCluster) Default() {
 		log.Info("Setting default image", "value", *rayDefaultImage)
 		rc.Spec.Image = rayDefaultImage
 	}
+	if spec.Sync != nil && spec.Sync.Enabled {
+		if spec.Sync.Port == 0 {
+			log.Info("Setting default sync port", "value", syncDefaultPort)
+			spec.Sync.Port = syncDefaultPort
+		}
+		if spec.Sync.MountPath == "" {
+			log.Info("Setting default sync mount path", "value", syncDefaultMountPath)
+			spec.Sync.MountPath = syncDefaultMountPath
+		}
+	}
+	spec.GangScheduling.applyDefaults(log)
+	spec.KerberosKeytab.applyDefaults(log)
+	spec.Kerberos.applyDefaults(log)
+	spec.Dashboard.applyDefaults(log)
 }
 
 //+kubebuilder:webhook:path=/validate-distributed-compute-dominodatalab-com-v1alpha1-raycluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=distributed-compute.dominodatalab.com,resources=rayclusters,verbs=create;update,versions=v1alpha1,name=vraycluster.kb.io,admissionReviewVersions={v1,v1beta1}
@@ -114,13 +133,13 @@ var _ webhook.Validator = &RayCluster{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (rc *RayCluster) ValidateCreate() error {
 	rayLogger.WithValues("raycluster", client.ObjectKeyFromObject(rc)).Info("Validating create")
-	return rc.validateRayCluster()
+	return rc.validateRayCluster(nil)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (rc *RayCluster) ValidateUpdate(runtime.Object) error {
+func (rc *RayCluster) ValidateUpdate(old runtime.Object) error {
 	rayLogger.WithValues("raycluster", client.ObjectKeyFromObject(rc)).Info("Validating update")
-	return rc.validateRayCluster()
+	return rc.validateRayCluster(old.(*RayCluster))
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -129,13 +148,16 @@ func (rc *RayCluster) ValidateDelete() error {
 	return nil
 }
 
-func (rc *RayCluster) validateRayCluster() error {
+func (rc *RayCluster) validateRayCluster(old *RayCluster) error {
 	var errList field.ErrorList
 
 	if err := validateIstioMutualTLSMode(rc.Spec.MutualTLSMode); err != nil {
 		errList = append(errList, err)
 	}
-	if errs := validateImage(rc.Spec.Image); errs != nil {
+	if errs := validateIstioResources(rc.Spec.IstioConfig); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateImage(field.NewPath("spec", "image"), rc.Spec.Image); errs != nil {
 		errList = append(errList, errs...)
 	}
 	if errs := validateAutoscaler(rc.Spec.Autoscaling); errs != nil {
@@ -144,6 +166,49 @@ func (rc *RayCluster) validateRayCluster() error {
 	if errs := validateKerberosKeytab(rc.Spec.KerberosKeytab); errs != nil {
 		errList = append(errList, errs...)
 	}
+	if errs := validateKerberos(rc.Spec.Kerberos, rc.Spec.KerberosKeytab); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateNetworkPolicy(rc.Spec.NetworkPolicy); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateGangScheduling(rc.Spec.GangScheduling); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateMonitoring(rc.Spec.Monitoring); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateDashboard(rc.Spec.Dashboard, rc.Name, rc.Namespace); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateTokenReview(rc.Spec.Dashboard, rc.Spec.NetworkPolicy); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateDashboardEnabled(rc.Spec.EnableDashboard, rc.Spec.Dashboard); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateTLS(rc.Spec.TLS); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateSync(rc.Spec.Sync); errs != nil {
+		errList = append(errList, errs...)
+	}
+	var oldProfile SecurityProfile
+	if old != nil {
+		oldProfile = old.Spec.SecurityProfile
+	}
+	if errs := validateSecurityProfile(
+		rc,
+		oldProfile,
+		&rc.Spec.ClusterConfig,
+		workloadAt(field.NewPath("spec", "head"), &rc.Spec.Head),
+		workloadAt(field.NewPath("spec", "worker"), &rc.Spec.Worker.WorkloadConfig),
+	); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validatePolicies(rc.policyTarget(old)); errs != nil {
+		errList = append(errList, errs...)
+	}
 	if err := validateWorkerReplicas(rc.Spec.Worker.Replicas); err != nil {
 		errList = append(errList, err)
 	}
@@ -161,6 +226,7 @@ func (rc *RayCluster) validateRayCluster() error {
 		"nodeManagerPort":   rc.Spec.NodeManagerPort,
 		"gcsServerPort":     rc.Spec.GCSServerPort,
 		"dashboardPort":     rc.Spec.DashboardPort,
+		"metricsExportPort": rc.Spec.MetricsExportPort,
 	}
 	for idx, port := range rc.Spec.RedisShardPorts {
 		ports[fmt.Sprintf("redisShardPorts[%d]", idx)] = port
@@ -168,6 +234,12 @@ func (rc *RayCluster) validateRayCluster() error {
 	for idx, port := range rc.Spec.WorkerPorts {
 		ports[fmt.Sprintf("workerPorts[%d]", idx)] = port
 	}
+	if rc.Spec.Sync != nil && rc.Spec.Sync.Enabled {
+		ports["sync.port"] = rc.Spec.Sync.Port
+	}
+	if rc.Spec.Dashboard.AuthEnabled() {
+		ports["dashboard.auth.port"] = rc.Spec.Dashboard.Auth.Port
+	}
 	if errs := validatePorts(ports); errs != nil {
 		errList = append(errList, errs...)
 	}
@@ -175,6 +247,41 @@ func (rc *RayCluster) validateRayCluster() error {
 	return invalidIfNotEmpty("RayCluster", rc.Name, errList)
 }
 
+func (rc *RayCluster) policyTarget(old *RayCluster) *policyTarget {
+	t := &policyTarget{
+		object:      rc,
+		config:      &rc.Spec.ClusterConfig,
+		images:      []imageField{imageAt(field.NewPath("spec", "image"), rc.Spec.Image)},
+		singletons:  []workloadField{workloadAt(field.NewPath("spec", "head"), &rc.Spec.Head)},
+		worker:      workloadAt(field.NewPath("spec", "worker"), &rc.Spec.Worker.WorkloadConfig),
+		replicas:    rc.Spec.Worker.Replicas,
+		autoscaling: rc.Spec.Autoscaling,
+	}
+	if old != nil {
+		t.old = old.policyTarget(nil)
+	}
+
+	return t
+}
+
+func validateDashboardEnabled(enabled *bool, dc *DashboardConfig) field.ErrorList {
+	if dc == nil || enabled == nil || *enabled {
+		return nil
+	}
+
+	var errs field.ErrorList
+	fp := field.NewPath("spec", "dashboard")
+
+	if dc.Expose != nil && dc.Expose.Enabled {
+		errs = append(errs, field.Forbidden(fp.Child("expose"), "requires enableDashboard"))
+	}
+	if dc.AuthEnabled() {
+		errs = append(errs, field.Forbidden(fp.Child("auth"), "requires enableDashboard"))
+	}
+
+	return errs
+}
+
 func validateObjectStoreMemoryBytes(memBytes *int64) *field.Error {
 	if memBytes == nil || *memBytes >= 78643200 {
 		return nil
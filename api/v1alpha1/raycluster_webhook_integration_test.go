@@ -81,6 +81,10 @@ var _ = Describe("RayCluster", func() {
 				BeNumerically("==", 8265),
 				"dashboard port should equal 8265",
 			)
+			Expect(rc.Spec.MetricsExportPort).To(
+				BeNumerically("==", 8080),
+				"metrics export port should equal 8080",
+			)
 			Expect(rc.Spec.EnableDashboard).To(
 				PointTo(Equal(true)),
 				"enable dashboard should point to true",
@@ -355,6 +359,99 @@ var _ = Describe("RayCluster", func() {
 			})
 		})
 
+		Context("With dashboard exposure enabled", func() {
+			clusterWithExposedDashboard := func() *RayCluster {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.Dashboard = &DashboardConfig{
+					Expose: &DashboardExposeConfig{
+						Enabled: true,
+						Host:    "{{ .Name }}.example.com",
+					},
+				}
+
+				return rc
+			}
+
+			It("passes when valid", func() {
+				rc := clusterWithExposedDashboard()
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("requires a host that renders to a valid hostname", func() {
+				rc := clusterWithExposedDashboard()
+
+				rc.Spec.Dashboard.Expose.Host = ""
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+
+				rc.Spec.Dashboard.Expose.Host = "{{ .Missing }}.example.com"
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("requires a gateway for http routes", func() {
+				rc := clusterWithExposedDashboard()
+				rc.Spec.Dashboard.Expose.Type = DashboardExposureHTTPRoute
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+
+				rc.Spec.Dashboard.Expose.Gateway = &GatewayReference{Name: "public"}
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("requires the dashboard to be enabled", func() {
+				rc := clusterWithExposedDashboard()
+				rc.Spec.EnableDashboard = pointer.Bool(false)
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+		})
+
+		Context("With dashboard auth enabled", func() {
+			clusterWithDashboardAuth := func() *RayCluster {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.Dashboard = &DashboardConfig{
+					Auth: &DashboardAuthConfig{Enabled: true},
+				}
+
+				return rc
+			}
+
+			It("defaults the proxy type and port", func() {
+				rc := clusterWithDashboardAuth()
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+
+				Expect(rc.Spec.Dashboard.Auth.Type).To(Equal(DashboardAuthTokenReview))
+				Expect(rc.Spec.Dashboard.Auth.Port).To(BeNumerically("==", 4180))
+			})
+
+			It("requires an oidc client for the OIDC type", func() {
+				rc := clusterWithDashboardAuth()
+				rc.Spec.Dashboard.Auth.Type = DashboardAuthOIDC
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+
+				rc.Spec.Dashboard.Auth.OIDC = &OIDCConfig{
+					IssuerURL:  "https://idp.example.com",
+					ClientID:   "dashboards",
+					SecretName: "dashboard-oidc",
+				}
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("rejects a proxy port used by the cluster", func() {
+				rc := clusterWithDashboardAuth()
+				rc.Spec.Dashboard.Auth.Port = 8265
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("requires kubernetes api egress for the TokenReview type", func() {
+				rc := clusterWithDashboardAuth()
+				rc.Spec.NetworkPolicy.Egress = &NetworkPolicyEgressConfig{Enabled: true}
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+
+				rc.Spec.NetworkPolicy.Egress.AllowKubernetesAPI = true
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+		})
+
 		DescribeTable("With mutual tls mode set",
 			func(smode string, expectErr bool) {
 				rc := rayFixture(testNS.Name)
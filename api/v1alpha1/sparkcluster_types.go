@@ -44,6 +44,19 @@ type SparkClusterDriver struct {
 	Selector map[string]string `json:"selector,omitempty"`
 }
 
+// SparkClusterSecurity secures the RPC communication between the master,
+// workers and drivers of a SparkCluster.
+type SparkClusterSecurity struct {
+	// Authenticate requires spark processes to authenticate with a shared
+	// secret generated for the cluster. The secret is stored under the
+	// "auth-secret" key of the Secret reported in status.authSecret; drivers
+	// must set it as "spark.authenticate.secret" to join the cluster.
+	Authenticate bool `json:"authenticate,omitempty"`
+	// Encrypt enables AES-based encryption of RPC connections. It requires
+	// Authenticate.
+	Encrypt bool `json:"encrypt,omitempty"`
+}
+
 // SparkClusterSpec defines the desired state of a SparkCluster resource.
 type SparkClusterSpec struct {
 	ScalableClusterConfig `json:",inline"`
@@ -69,6 +82,8 @@ type SparkClusterSpec struct {
 	WorkerWebPort int32 `json:"workerWebPort,omitempty"`
 	// AdditionalClientPorts are extra ports through which cluster nodes could connect to the client.
 	AdditionalClientPorts []corev1.ServicePort `json:"additionalClientPorts,omitempty"`
+	// Security configures the authentication and encryption of spark RPCs.
+	Security *SparkClusterSecurity `json:"security,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -1,6 +1,9 @@
 package v1alpha1
 
 import (
+	"sort"
+	"strings"
+
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -93,6 +96,16 @@ func (sc *SparkCluster) Default() {
 		log.Info("Setting default image", "value", *sparkDefaultImage)
 		spec.Image = sparkDefaultImage
 	}
+	if spec.Sync != nil && spec.Sync.Enabled {
+		if spec.Sync.Port == 0 {
+			log.Info("Setting default sync port", "value", syncDefaultPort)
+			spec.Sync.Port = syncDefaultPort
+		}
+		if spec.Sync.MountPath == "" {
+			log.Info("Setting default sync mount path", "value", syncDefaultMountPath)
+			spec.Sync.MountPath = syncDefaultMountPath
+		}
+	}
 
 	nodes := []*SparkClusterNode{&sc.Spec.Master, &sc.Spec.Worker.SparkClusterNode}
 	for i := range nodes {
@@ -101,6 +114,10 @@ func (sc *SparkCluster) Default() {
 			node.Annotations = make(map[string]string)
 		}
 	}
+	spec.GangScheduling.applyDefaults(log)
+	spec.KerberosKeytab.applyDefaults(log)
+	spec.Kerberos.applyDefaults(log)
+	spec.Dashboard.applyDefaults(log)
 }
 
 //+kubebuilder:webhook:path=/validate-distributed-compute-dominodatalab-com-v1alpha1-sparkcluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=distributed-compute.dominodatalab.com,resources=sparkclusters,verbs=create;update,versions=v1alpha1,name=vsparkcluster.kb.io,admissionReviewVersions={v1,v1beta1}
@@ -110,13 +127,13 @@ var _ webhook.Validator = &SparkCluster{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (sc *SparkCluster) ValidateCreate() error {
 	sparkLogger.WithValues("sparkcluster", client.ObjectKeyFromObject(sc)).Info("Validating create")
-	return sc.validateSparkCluster()
+	return sc.validateSparkCluster(nil)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (sc *SparkCluster) ValidateUpdate(runtime.Object) error {
+func (sc *SparkCluster) ValidateUpdate(old runtime.Object) error {
 	sparkLogger.WithValues("sparkcluster", client.ObjectKeyFromObject(sc)).Info("Validating update")
-	return sc.validateSparkCluster()
+	return sc.validateSparkCluster(old.(*SparkCluster))
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -125,7 +142,7 @@ func (sc *SparkCluster) ValidateDelete() error {
 	return nil
 }
 
-func (sc *SparkCluster) validateSparkCluster() error {
+func (sc *SparkCluster) validateSparkCluster(old *SparkCluster) error {
 	var errList field.ErrorList
 
 	if sc.IsIncompatibleVersion() {
@@ -136,10 +153,13 @@ func (sc *SparkCluster) validateSparkCluster() error {
 	if err := validateIstioMutualTLSMode(sc.Spec.MutualTLSMode); err != nil {
 		errList = append(errList, err)
 	}
+	if errs := validateIstioResources(sc.Spec.IstioConfig); errs != nil {
+		errList = append(errList, errs...)
+	}
 	if err := validateWorkerReplicas(sc.Spec.Worker.Replicas); err != nil {
 		errList = append(errList, err)
 	}
-	if errs := validateImage(sc.Spec.Image); errs != nil {
+	if errs := validateImage(field.NewPath("spec", "image"), sc.Spec.Image); errs != nil {
 		errList = append(errList, errs...)
 	}
 	if errs := validateAutoscaler(sc.Spec.Autoscaling); errs != nil {
@@ -151,6 +171,43 @@ func (sc *SparkCluster) validateSparkCluster() error {
 	if errs := validateKerberosKeytab(sc.Spec.KerberosKeytab); errs != nil {
 		errList = append(errList, errs...)
 	}
+	if errs := validateKerberos(sc.Spec.Kerberos, sc.Spec.KerberosKeytab); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateNetworkPolicy(sc.Spec.NetworkPolicy); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateGangScheduling(sc.Spec.GangScheduling); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateMonitoring(sc.Spec.Monitoring); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validateDashboard(sc.Spec.Dashboard, sc.Name, sc.Namespace); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if sc.Spec.Dashboard.AuthEnabled() {
+		errList = append(errList, field.Forbidden(field.NewPath("spec", "dashboard", "auth"), "is not supported by spark clusters"))
+	}
+	if errs := validateSync(sc.Spec.Sync); errs != nil {
+		errList = append(errList, errs...)
+	}
+	var oldProfile SecurityProfile
+	if old != nil {
+		oldProfile = old.Spec.SecurityProfile
+	}
+	if errs := validateSecurityProfile(
+		sc,
+		oldProfile,
+		&sc.Spec.ClusterConfig,
+		workloadAt(field.NewPath("spec", "master"), &sc.Spec.Master.WorkloadConfig),
+		workloadAt(field.NewPath("spec", "worker"), &sc.Spec.Worker.WorkloadConfig),
+	); errs != nil {
+		errList = append(errList, errs...)
+	}
+	if errs := validatePolicies(sc.policyTarget(old)); errs != nil {
+		errList = append(errList, errs...)
+	}
 
 	if err := sc.validateWorkerMemoryLimit(); err != nil {
 		errList = append(errList, err)
@@ -158,12 +215,18 @@ func (sc *SparkCluster) validateSparkCluster() error {
 	if errs := sc.validateDriverConfigs(); errs != nil {
 		errList = append(errList, errs...)
 	}
+	if errs := sc.validateSecurity(); errs != nil {
+		errList = append(errList, errs...)
+	}
 
 	ports := map[string]int32{
 		"clusterPort":   sc.Spec.ClusterPort,
 		"masterWebPort": sc.Spec.MasterWebPort,
 		"workerWebPort": sc.Spec.WorkerWebPort,
 	}
+	if sc.Spec.Sync != nil && sc.Spec.Sync.Enabled {
+		ports["sync.port"] = sc.Spec.Sync.Port
+	}
 	if errs := validatePorts(ports); errs != nil {
 		errList = append(errList, errs...)
 	}
@@ -171,6 +234,23 @@ func (sc *SparkCluster) validateSparkCluster() error {
 	return invalidIfNotEmpty("SparkCluster", sc.Name, errList)
 }
 
+func (sc *SparkCluster) policyTarget(old *SparkCluster) *policyTarget {
+	t := &policyTarget{
+		object:      sc,
+		config:      &sc.Spec.ClusterConfig,
+		images:      []imageField{imageAt(field.NewPath("spec", "image"), sc.Spec.Image)},
+		singletons:  []workloadField{workloadAt(field.NewPath("spec", "master"), &sc.Spec.Master.WorkloadConfig)},
+		worker:      workloadAt(field.NewPath("spec", "worker"), &sc.Spec.Worker.WorkloadConfig),
+		replicas:    sc.Spec.Worker.Replicas,
+		autoscaling: sc.Spec.Autoscaling,
+	}
+	if old != nil {
+		t.old = old.policyTarget(nil)
+	}
+
+	return t
+}
+
 func (sc *SparkCluster) validateWorkerMemoryLimit() *field.Error {
 	request := sc.Spec.WorkerMemoryLimit
 	fp := field.NewPath("spec", "workerMemoryLimit")
@@ -207,3 +287,42 @@ func (sc *SparkCluster) validateDriverConfigs() field.ErrorList {
 
 	return errs
 }
+
+func (sc *SparkCluster) validateSecurity() field.ErrorList {
+	sec := sc.Spec.Security
+	if sec == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	if sec.Encrypt && !sec.Authenticate {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "security", "encrypt"), sec.Encrypt, "requires authenticate"))
+	}
+	if !sec.Authenticate {
+		return errs
+	}
+
+	// generated security settings take precedence over node configuration
+	for _, node := range []struct {
+		name string
+		conf map[string]string
+	}{
+		{"master", sc.Spec.Master.DefaultConfiguration},
+		{"worker", sc.Spec.Worker.DefaultConfiguration},
+	} {
+		var keys []string
+		for key := range node.conf {
+			if strings.HasPrefix(key, "spark.authenticate") || strings.HasPrefix(key, "spark.network.crypto.") {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		fp := field.NewPath("spec", node.name, "defaultConfiguration")
+		for _, key := range keys {
+			errs = append(errs, field.Forbidden(fp.Key(key), "conflicts with spec.security"))
+		}
+	}
+
+	return errs
+}
@@ -356,6 +356,129 @@ var _ = Describe("SparkCluster", func() {
 
 				Expect(k8sClient.Create(ctx, sc)).ToNot(Succeed())
 			})
+
+			It("defaults the key of a secret reference", func() {
+				sc := sparkFixture(testNS.Name)
+				sc.Spec.KerberosKeytab = &KerberosKeytabConfig{
+					MountPath: "test/path/",
+					SecretRef: &KerberosKeytabSecretReference{Name: "keytab"},
+				}
+
+				Expect(k8sClient.Create(ctx, sc)).To(Succeed())
+				Expect(sc.Spec.KerberosKeytab.SecretRef.Key).To(Equal("keytab"))
+			})
+
+			It("rejects a config with both data and a secret reference", func() {
+				sc := sparkFixture(testNS.Name)
+				sc.Spec.KerberosKeytab = &KerberosKeytabConfig{
+					MountPath: "test/path/",
+					Contents:  []byte{'c', 'o', 'n', 'f', 'i', 'g'},
+					SecretRef: &KerberosKeytabSecretReference{Name: "keytab"},
+				}
+
+				Expect(k8sClient.Create(ctx, sc)).ToNot(Succeed())
+			})
+		})
+
+		Context("kerberos configs", func() {
+			It("defaults the renew interval", func() {
+				sc := sparkFixture(testNS.Name)
+				sc.Spec.KerberosKeytab = &KerberosKeytabConfig{
+					MountPath: "test/path/",
+					SecretRef: &KerberosKeytabSecretReference{Name: "keytab"},
+				}
+				sc.Spec.Kerberos = &KerberosConfig{Enabled: true, Principal: "spark", Realm: "EXAMPLE.COM"}
+
+				Expect(k8sClient.Create(ctx, sc)).To(Succeed())
+				Expect(sc.Spec.Kerberos.RenewIntervalSeconds).To(Equal(pointer.Int32(3600)))
+			})
+
+			It("requires a keytab", func() {
+				sc := sparkFixture(testNS.Name)
+				sc.Spec.Kerberos = &KerberosConfig{Enabled: true, Principal: "spark", Realm: "EXAMPLE.COM"}
+
+				Expect(k8sClient.Create(ctx, sc)).ToNot(Succeed())
+			})
+
+			It("rejects a config with both contents and a configmap reference", func() {
+				sc := sparkFixture(testNS.Name)
+				sc.Spec.KerberosKeytab = &KerberosKeytabConfig{
+					MountPath: "test/path/",
+					SecretRef: &KerberosKeytabSecretReference{Name: "keytab"},
+				}
+				sc.Spec.Kerberos = &KerberosConfig{
+					Enabled:      true,
+					Principal:    "spark@EXAMPLE.COM",
+					Config:       "[libdefaults]",
+					ConfigMapRef: &corev1.LocalObjectReference{Name: "krb5"},
+				}
+
+				Expect(k8sClient.Create(ctx, sc)).ToNot(Succeed())
+			})
+		})
+
+		Context("istio resources", func() {
+			It("passes with namespaced sidecar egress hosts", func() {
+				sc := sparkFixture(testNS.Name)
+				sc.Spec.Istio = &IstioResourcesConfig{
+					Sidecar: &IstioSidecarConfig{Enabled: true, EgressHosts: []string{"istio-system/*"}},
+				}
+
+				Expect(k8sClient.Create(ctx, sc)).To(Succeed())
+			})
+
+			It("rejects sidecar egress hosts without a namespace", func() {
+				sc := sparkFixture(testNS.Name)
+				sc.Spec.Istio = &IstioResourcesConfig{
+					Sidecar: &IstioSidecarConfig{Enabled: true, EgressHosts: []string{"api.example.com"}},
+				}
+
+				Expect(k8sClient.Create(ctx, sc)).ToNot(Succeed())
+			})
+
+			It("rejects client namespace patterns when the sidecar is enabled", func() {
+				sc := sparkFixture(testNS.Name)
+				sc.Spec.Istio = &IstioResourcesConfig{
+					Authorization: &IstioAuthorizationConfig{Enabled: true, ClientNamespaces: []string{"team-*"}},
+					Sidecar:       &IstioSidecarConfig{Enabled: true},
+				}
+
+				Expect(k8sClient.Create(ctx, sc)).ToNot(Succeed())
+			})
+
+			It("rejects destination rules when mutual tls is disabled", func() {
+				sc := sparkFixture(testNS.Name)
+				sc.Spec.MutualTLSMode = "DISABLE"
+				sc.Spec.Istio = &IstioResourcesConfig{
+					DestinationRule: &IstioDestinationRuleConfig{Enabled: true},
+				}
+
+				Expect(k8sClient.Create(ctx, sc)).ToNot(Succeed())
+			})
+		})
+
+		Context("security configs", func() {
+			It("passes with authentication and encryption", func() {
+				sc := sparkFixture(testNS.Name)
+				sc.Spec.Security = &SparkClusterSecurity{Authenticate: true, Encrypt: true}
+
+				Expect(k8sClient.Create(ctx, sc)).To(Succeed())
+			})
+
+			It("rejects encryption without authentication", func() {
+				sc := sparkFixture(testNS.Name)
+				sc.Spec.Security = &SparkClusterSecurity{Encrypt: true}
+
+				Expect(k8sClient.Create(ctx, sc)).ToNot(Succeed())
+			})
+
+			It("rejects node configuration that overrides security settings", func() {
+				sc := sparkFixture(testNS.Name)
+				sc.Spec.Security = &SparkClusterSecurity{Authenticate: true}
+				sc.Spec.Worker.DefaultConfiguration = map[string]string{"spark.authenticate": "false"}
+
+				Expect(k8sClient.Create(ctx, sc)).ToNot(Succeed())
+			})
 		})
 	})
 })
@@ -0,0 +1,31 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestSparkClusterValidateSecurity(t *testing.T) {
+	sc := &SparkCluster{}
+	sc.Spec.Security = &SparkClusterSecurity{Authenticate: true, Encrypt: true}
+	sc.Spec.Master.DefaultConfiguration = map[string]string{"spark.executor.cores": "2"}
+	assert.Empty(t, sc.validateSecurity())
+
+	sc.Spec.Worker.DefaultConfiguration = map[string]string{
+		"spark.network.crypto.saslFallback": "true",
+		"spark.authenticate":                "false",
+	}
+	errs := sc.validateSecurity()
+	require.Len(t, errs, 2)
+	assert.Equal(t, field.ErrorTypeForbidden, errs[0].Type)
+	assert.Equal(t, "spec.worker.defaultConfiguration[spark.authenticate]", errs[0].Field)
+	assert.Equal(t, "spec.worker.defaultConfiguration[spark.network.crypto.saslFallback]", errs[1].Field)
+
+	t.Run("disabled", func(t *testing.T) {
+		sc.Spec.Security = &SparkClusterSecurity{}
+		assert.Empty(t, sc.validateSecurity())
+	})
+}
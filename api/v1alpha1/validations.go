@@ -2,11 +2,19 @@ package v1alpha1
 
 import (
 	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
 
+	"github.com/distribution/reference"
 	securityv1beta1 "istio.io/api/security/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -15,6 +23,12 @@ const (
 	maxValidPort int32 = 65535
 )
 
+var (
+	syncModuleNameRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+	imageDigestRegexp    = regexp.MustCompile(`^` + reference.DigestRegexp.String() + `$`)
+	scrapeIntervalRegexp = regexp.MustCompile(`^([0-9]+(ms|s|m|h))+$`)
+)
+
 func validateIstioMutualTLSMode(mode string) *field.Error {
 	if mode == "" {
 		return nil
@@ -35,6 +49,55 @@ func validateIstioMutualTLSMode(mode string) *field.Error {
 	)
 }
 
+func validateIstioResources(ic IstioConfig) field.ErrorList {
+	if ic.Istio == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	fp := field.NewPath("spec", "istio")
+
+	if sc := ic.Istio.Sidecar; sc != nil {
+		for idx, host := range sc.EgressHosts {
+			ns, dnsName, found := strings.Cut(host, "/")
+			if !found || ns == "" || dnsName == "" {
+				errs = append(errs, field.Invalid(
+					fp.Child("sidecar", "egressHosts").Index(idx),
+					host,
+					`must be in the "namespace/dnsName" format`,
+				))
+			}
+		}
+	}
+	if ac := ic.Istio.Authorization; ac != nil && ic.Istio.Sidecar != nil && ic.Istio.Sidecar.Enabled {
+		for idx, ns := range ac.ClientNamespaces {
+			if ns != "*" && len(validation.IsDNS1123Label(ns)) != 0 {
+				errs = append(errs, field.Invalid(
+					fp.Child("authorization", "clientNamespaces").Index(idx),
+					ns,
+					`must be a namespace name or "*" when the sidecar is enabled`,
+				))
+			}
+		}
+	}
+	if ef := ic.Istio.EnvoyFilter; ef != nil && ef.IdleTimeout != nil && ef.IdleTimeout.Duration < 0 {
+		errs = append(errs, field.Invalid(
+			fp.Child("envoyFilter", "idleTimeout"),
+			ef.IdleTimeout.Duration.String(),
+			"must be greater than or equal to 0",
+		))
+	}
+	if dr := ic.Istio.DestinationRule; dr != nil && dr.Enabled &&
+		ic.MutualTLSMode == securityv1beta1.PeerAuthentication_MutualTLS_DISABLE.String() {
+		errs = append(errs, field.Forbidden(
+			fp.Child("destinationRule", "enabled"),
+			"cannot require Istio mutual TLS when istioMutualTLSMode is DISABLE",
+		))
+	}
+
+	return errs
+}
+
 func validateWorkerReplicas(replicas *int32) *field.Error {
 	if replicas == nil || *replicas >= 0 {
 		return nil
@@ -61,15 +124,17 @@ func validateWorkerResourceRequests(resources corev1.ResourceRequirements) field
 	return errs
 }
 
-func validateImage(image *OCIImageDefinition) field.ErrorList {
+func validateImage(fp *field.Path, image *OCIImageDefinition) field.ErrorList {
 	var errs field.ErrorList
-	fp := field.NewPath("spec", "image")
 
 	if image.Repository == "" {
 		errs = append(errs, field.Required(fp.Child("repository"), "cannot be blank"))
 	}
-	if image.Tag == "" {
-		errs = append(errs, field.Required(fp.Child("tag"), "cannot be blank"))
+	if image.Tag == "" && image.Digest == "" {
+		errs = append(errs, field.Required(fp.Child("tag"), "cannot be blank when digest is not provided"))
+	}
+	if image.Digest != "" && !imageDigestRegexp.MatchString(image.Digest) {
+		errs = append(errs, field.Invalid(fp.Child("digest"), image.Digest, "must be a valid digest, e.g. sha256:<hex>"))
 	}
 
 	return errs
@@ -166,8 +231,15 @@ func validateKerberosKeytab(keytab *KerberosKeytabConfig) field.ErrorList {
 	var errs field.ErrorList
 	fp := field.NewPath("spec", "kerberosKeytab")
 
-	if len(keytab.Contents) == 0 {
-		errs = append(errs, field.Required(fp.Child("contents"), "must contain file contents"))
+	switch {
+	case len(keytab.Contents) != 0 && keytab.SecretRef != nil:
+		errs = append(errs, field.Forbidden(fp.Child("secretRef"), "cannot be combined with contents"))
+	case keytab.SecretRef != nil:
+		if keytab.SecretRef.Name == "" {
+			errs = append(errs, field.Required(fp.Child("secretRef", "name"), "must reference a secret"))
+		}
+	case len(keytab.Contents) == 0:
+		errs = append(errs, field.Required(fp.Child("contents"), "must contain file contents or provide a secretRef"))
 	}
 	if keytab.MountPath == "" {
 		errs = append(errs, field.Required(fp.Child("mountPath"), "must be a valid file path"))
@@ -176,6 +248,81 @@ func validateKerberosKeytab(keytab *KerberosKeytabConfig) field.ErrorList {
 	return errs
 }
 
+func validateKerberos(kc *KerberosConfig, keytab *KerberosKeytabConfig) field.ErrorList {
+	if kc == nil || !kc.Enabled {
+		return nil
+	}
+
+	var errs field.ErrorList
+	fp := field.NewPath("spec", "kerberos")
+
+	if keytab == nil {
+		errs = append(errs, field.Required(field.NewPath("spec", "kerberosKeytab"), "must be provided to obtain kerberos tickets"))
+	}
+	if kc.Principal == "" {
+		errs = append(errs, field.Required(fp.Child("principal"), "must be provided"))
+	} else if kc.Realm == "" && !strings.Contains(kc.Principal, "@") {
+		errs = append(errs, field.Required(fp.Child("realm"), "must be provided when the principal has no realm"))
+	}
+	if kc.Config != "" && kc.ConfigMapRef != nil {
+		errs = append(errs, field.Forbidden(fp.Child("configMapRef"), "cannot be combined with config"))
+	}
+	if kc.ConfigMapRef != nil && kc.ConfigMapRef.Name == "" {
+		errs = append(errs, field.Required(fp.Child("configMapRef", "name"), "must reference a config map"))
+	}
+	if kc.Config == "" && kc.ConfigMapRef == nil && kc.Realm == "" {
+		errs = append(errs, field.Required(fp.Child("realm"), "must be provided to generate krb5.conf"))
+	}
+	if kc.RenewIntervalSeconds != nil && *kc.RenewIntervalSeconds <= 0 {
+		errs = append(errs, field.Invalid(fp.Child("renewIntervalSeconds"), *kc.RenewIntervalSeconds, "must be greater than 0"))
+	}
+	if kc.Image != nil {
+		errs = append(errs, validateImage(fp.Child("image"), kc.Image)...)
+	}
+
+	return errs
+}
+
+func validateNetworkPolicy(np NetworkPolicyConfig) field.ErrorList {
+	var errs field.ErrorList
+	fp := field.NewPath("spec", "networkPolicy")
+
+	for idx := range np.ClientIPBlocks {
+		errs = append(errs, validateIPBlock(fp.Child("clientIPBlocks").Index(idx), &np.ClientIPBlocks[idx])...)
+	}
+
+	if np.Egress == nil || !np.Egress.Enabled {
+		return errs
+	}
+	if np.Enabled != nil && !*np.Enabled {
+		errs = append(errs, field.Forbidden(fp.Child("egress", "enabled"), "requires network policies to be enabled"))
+	}
+	for idx, rule := range np.Egress.Rules {
+		for jdx, peer := range rule.To {
+			if peer.IPBlock != nil {
+				errs = append(errs, validateIPBlock(fp.Child("egress", "rules").Index(idx).Child("to").Index(jdx).Child("ipBlock"), peer.IPBlock)...)
+			}
+		}
+	}
+
+	return errs
+}
+
+func validateIPBlock(fp *field.Path, block *networkingv1.IPBlock) field.ErrorList {
+	var errs field.ErrorList
+
+	if _, _, err := net.ParseCIDR(block.CIDR); err != nil {
+		errs = append(errs, field.Invalid(fp.Child("cidr"), block.CIDR, "must be a valid CIDR"))
+	}
+	for idx, except := range block.Except {
+		if _, _, err := net.ParseCIDR(except); err != nil {
+			errs = append(errs, field.Invalid(fp.Child("except").Index(idx), except, "must be a valid CIDR"))
+		}
+	}
+
+	return errs
+}
+
 func validateSharedSSHSecret(secret string) field.ErrorList {
 	var errs field.ErrorList
 	fp := field.NewPath("spec", "workers", "sharedSSHSecret")
@@ -186,6 +333,241 @@ func validateSharedSSHSecret(secret string) field.ErrorList {
 	return errs
 }
 
+func validateMPIBootstrap(mode MPIBootstrapMode) *field.Error {
+	switch mode {
+	case "", MPIBootstrapSSH, MPIBootstrapAgent:
+		return nil
+	}
+
+	return field.NotSupported(
+		field.NewPath("spec", "bootstrap"),
+		mode,
+		[]string{string(MPIBootstrapSSH), string(MPIBootstrapAgent)},
+	)
+}
+
+func validateSync(sync *SyncConfig) field.ErrorList {
+	if sync == nil || !sync.Enabled {
+		return nil
+	}
+
+	var errs field.ErrorList
+	fp := field.NewPath("spec", "sync")
+
+	if sync.MountPath == "" {
+		errs = append(errs, field.Required(fp.Child("mountPath"), "cannot be blank"))
+	} else if !path.IsAbs(sync.MountPath) {
+		errs = append(errs, field.Invalid(fp.Child("mountPath"), sync.MountPath, "must be an absolute path"))
+	}
+
+	return append(errs, validateSyncModules(fp.Child("modules"), sync.Modules)...)
+}
+
+func validateSyncModules(fp *field.Path, modules []SyncModule) field.ErrorList {
+	var errs field.ErrorList
+
+	names := sets.Set[string]{}
+	for idx, module := range modules {
+		mp := fp.Index(idx)
+
+		switch {
+		case module.Name == "":
+			errs = append(errs, field.Required(mp.Child("name"), "cannot be blank"))
+		case !syncModuleNameRegexp.MatchString(module.Name):
+			errs = append(errs, field.Invalid(mp.Child("name"), module.Name, "must consist of alphanumeric characters, '-', '_' or '.'"))
+		case names.Has(module.Name):
+			errs = append(errs, field.Duplicate(mp.Child("name"), module.Name))
+		default:
+			names.Insert(module.Name)
+		}
+
+		switch {
+		case module.Path == "":
+			errs = append(errs, field.Required(mp.Child("path"), "cannot be blank"))
+		case !path.IsAbs(module.Path):
+			errs = append(errs, field.Invalid(mp.Child("path"), module.Path, "must be an absolute path"))
+		case strings.ContainsAny(module.Path, ": \t\r\n"):
+			errs = append(errs, field.Invalid(mp.Child("path"), module.Path, "cannot contain whitespace or ':'"))
+		}
+	}
+
+	return errs
+}
+
+func validateGangScheduling(gs *GangSchedulingConfig) field.ErrorList {
+	if gs == nil || !gs.Enabled {
+		return nil
+	}
+
+	var errs field.ErrorList
+	fp := field.NewPath("spec", "gangScheduling")
+
+	switch gs.Provider {
+	case GangSchedulingCoscheduling, GangSchedulingVolcano:
+	default:
+		errs = append(errs, field.NotSupported(fp.Child("provider"), gs.Provider, []string{
+			string(GangSchedulingCoscheduling),
+			string(GangSchedulingVolcano),
+		}))
+	}
+	if gs.ScheduleTimeoutSeconds != nil && *gs.ScheduleTimeoutSeconds < 1 {
+		errs = append(errs, field.Invalid(fp.Child("scheduleTimeoutSeconds"), gs.ScheduleTimeoutSeconds, "must be greater than 0"))
+	}
+	if gs.Queue != "" && gs.Provider != GangSchedulingVolcano {
+		errs = append(errs, field.Forbidden(fp.Child("queue"), "is only supported by the volcano provider"))
+	}
+
+	return errs
+}
+
+func validateMonitoring(mc *MonitoringConfig) field.ErrorList {
+	if mc == nil || !mc.Enabled {
+		return nil
+	}
+
+	var errs field.ErrorList
+	if mc.Interval != "" && !scrapeIntervalRegexp.MatchString(mc.Interval) {
+		errs = append(errs, field.Invalid(
+			field.NewPath("spec", "monitoring", "interval"),
+			mc.Interval,
+			"must be a Prometheus duration, e.g. 30s",
+		))
+	}
+
+	return errs
+}
+
+func validateDashboard(dc *DashboardConfig, name, namespace string) field.ErrorList {
+	if dc == nil {
+		return nil
+	}
+
+	return append(validateDashboardExpose(dc.Expose, name, namespace), validateDashboardAuth(dc.Auth)...)
+}
+
+func validateDashboardExpose(ec *DashboardExposeConfig, name, namespace string) field.ErrorList {
+	if ec == nil || !ec.Enabled {
+		return nil
+	}
+
+	var errs field.ErrorList
+	fp := field.NewPath("spec", "dashboard", "expose")
+
+	if ec.Host == "" {
+		errs = append(errs, field.Required(fp.Child("host"), "is required to expose the dashboard"))
+	} else if host, path, err := ec.Endpoint(name, namespace); err != nil {
+		errs = append(errs, field.Invalid(fp, ec.Host+ec.Path, fmt.Sprintf("cannot render template: %v", err)))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(host) {
+			errs = append(errs, field.Invalid(fp.Child("host"), host, msg))
+		}
+		if !strings.HasPrefix(path, "/") {
+			errs = append(errs, field.Invalid(fp.Child("path"), path, "must be an absolute path"))
+		}
+	}
+
+	switch ec.Type {
+	case DashboardExposureIngress:
+		if ec.Gateway != nil {
+			errs = append(errs, field.Forbidden(fp.Child("gateway"), "is only supported by HTTPRoute exposure"))
+		}
+	case DashboardExposureHTTPRoute:
+		if ec.Gateway == nil || ec.Gateway.Name == "" {
+			errs = append(errs, field.Required(fp.Child("gateway", "name"), "is required by HTTPRoute exposure"))
+		}
+		if ec.IngressClassName != nil {
+			errs = append(errs, field.Forbidden(fp.Child("ingressClassName"), "is only supported by Ingress exposure"))
+		}
+		if ec.TLSSecretName != "" {
+			errs = append(errs, field.Forbidden(fp.Child("tlsSecretName"), "is only supported by Ingress exposure, gateways terminate TLS on their listeners"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(fp.Child("type"), ec.Type, []string{
+			string(DashboardExposureIngress),
+			string(DashboardExposureHTTPRoute),
+		}))
+	}
+
+	return errs
+}
+
+func validateDashboardAuth(ac *DashboardAuthConfig) field.ErrorList {
+	if ac == nil || !ac.Enabled {
+		return nil
+	}
+
+	var errs field.ErrorList
+	fp := field.NewPath("spec", "dashboard", "auth")
+
+	if ac.Image != nil {
+		errs = append(errs, validateImage(fp.Child("image"), ac.Image)...)
+	}
+
+	switch ac.Type {
+	case DashboardAuthOIDC:
+		errs = append(errs, validateOIDC(fp.Child("oidc"), ac.OIDC)...)
+	case DashboardAuthTokenReview:
+		if ac.OIDC != nil {
+			errs = append(errs, field.Forbidden(fp.Child("oidc"), "is only supported by the OIDC type"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(fp.Child("type"), ac.Type, []string{
+			string(DashboardAuthOIDC),
+			string(DashboardAuthTokenReview),
+		}))
+	}
+
+	return errs
+}
+
+// validateTokenReview rejects the TokenReview proxy when egress network
+// policies prevent it from reaching the kubernetes API.
+func validateTokenReview(dc *DashboardConfig, np NetworkPolicyConfig) field.ErrorList {
+	if !dc.AuthEnabled() || dc.Auth.Type != DashboardAuthTokenReview {
+		return nil
+	}
+	if np.Egress == nil || !np.Egress.Enabled || np.Egress.AllowKubernetesAPI {
+		return nil
+	}
+
+	return field.ErrorList{field.Forbidden(
+		field.NewPath("spec", "dashboard", "auth", "type"),
+		"TokenReview requires networkPolicy.egress.allowKubernetesAPI when egress traffic is restricted",
+	)}
+}
+
+func validateOIDC(fp *field.Path, oc *OIDCConfig) field.ErrorList {
+	if oc == nil {
+		return field.ErrorList{field.Required(fp, "is required by the OIDC type")}
+	}
+
+	var errs field.ErrorList
+	if u, err := url.Parse(oc.IssuerURL); err != nil || u.Scheme != "https" || u.Host == "" {
+		errs = append(errs, field.Invalid(fp.Child("issuerURL"), oc.IssuerURL, "must be an https URL"))
+	}
+	if oc.ClientID == "" {
+		errs = append(errs, field.Required(fp.Child("clientID"), "cannot be blank"))
+	}
+	if oc.SecretName == "" {
+		errs = append(errs, field.Required(fp.Child("secretName"), "cannot be blank"))
+	}
+
+	return errs
+}
+
+func validateTLS(tc *TLSConfig) field.ErrorList {
+	if tc == nil || !tc.Enabled || tc.IssuerRef == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	if tc.IssuerRef.Name == "" {
+		errs = append(errs, field.Required(field.NewPath("spec", "tls", "issuerRef", "name"), "cannot be blank"))
+	}
+
+	return errs
+}
+
 func invalidIfNotEmpty(kind, name string, errList field.ErrorList) error {
 	if len(errList) == 0 {
 		return nil
@@ -0,0 +1,20 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateIstioResourcesClientNamespaces(t *testing.T) {
+	ic := IstioConfig{Istio: &IstioResourcesConfig{
+		Authorization: &IstioAuthorizationConfig{ClientNamespaces: []string{"clients", "*", "team-*"}},
+	}}
+	assert.Empty(t, validateIstioResources(ic))
+
+	ic.Istio.Sidecar = &IstioSidecarConfig{Enabled: true}
+	errs := validateIstioResources(ic)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.istio.authorization.clientNamespaces[2]", errs[0].Field)
+}
@@ -6,10 +6,27 @@
 package v1alpha1
 
 import (
-	"k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllowedImage) DeepCopyInto(out *AllowedImage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedImage.
+func (in *AllowedImage) DeepCopy() *AllowedImage {
+	if in == nil {
+		return nil
+	}
+	out := new(AllowedImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Autoscaling) DeepCopyInto(out *Autoscaling) {
 	*out = *in
@@ -45,10 +62,25 @@ func (in *Autoscaling) DeepCopy() *Autoscaling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerReference) DeepCopyInto(out *CertManagerIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerReference.
+func (in *CertManagerIssuerReference) DeepCopy() *CertManagerIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 	*out = *in
-	out.IstioConfig = in.IstioConfig
+	in.IstioConfig.DeepCopyInto(&out.IstioConfig)
 	if in.GlobalLabels != nil {
 		in, out := &in.GlobalLabels, &out.GlobalLabels
 		*out = make(map[string]string, len(*in))
@@ -68,23 +100,33 @@ func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 		*out = new(KerberosKeytabConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Kerberos != nil {
+		in, out := &in.Kerberos, &out.Kerberos
+		*out = new(KerberosConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]v1.LocalObjectReference, len(*in))
+		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
 	if in.PodSecurityContext != nil {
 		in, out := &in.PodSecurityContext, &out.PodSecurityContext
-		*out = new(v1.PodSecurityContext)
+		*out = new(corev1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.EnvVars != nil {
 		in, out := &in.EnvVars, &out.EnvVars
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.GangScheduling != nil {
+		in, out := &in.GangScheduling, &out.GangScheduling
+		*out = new(GangSchedulingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterConfig.
@@ -97,6 +139,23 @@ func (in *ClusterConfig) DeepCopy() *ClusterConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterResources) DeepCopyInto(out *ClusterResources) {
+	*out = *in
+	in.Head.DeepCopyInto(&out.Head)
+	in.Workers.DeepCopyInto(&out.Workers)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResources.
+func (in *ClusterResources) DeepCopy() *ClusterResources {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterStatusConfig) DeepCopyInto(out *ClusterStatusConfig) {
 	*out = *in
@@ -109,6 +168,38 @@ func (in *ClusterStatusConfig) DeepCopyInto(out *ClusterStatusConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SyncSecret != nil {
+		in, out := &in.SyncSecret, &out.SyncSecret
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SyncNodes != nil {
+		in, out := &in.SyncNodes, &out.SyncNodes
+		*out = make([]SyncNodeStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.AuthSecret != nil {
+		in, out := &in.AuthSecret, &out.AuthSecret
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ClusterResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(ClusterUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientPeers != nil {
+		in, out := &in.ClientPeers, &out.ClientPeers
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatusConfig.
@@ -121,6 +212,151 @@ func (in *ClusterStatusConfig) DeepCopy() *ClusterStatusConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUsage) DeepCopyInto(out *ClusterUsage) {
+	*out = *in
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUsage.
+func (in *ClusterUsage) DeepCopy() *ClusterUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentUsage) DeepCopyInto(out *ComponentUsage) {
+	*out = *in
+	out.CPUCoreSeconds = in.CPUCoreSeconds.DeepCopy()
+	out.MemoryGiBSeconds = in.MemoryGiBSeconds.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentUsage.
+func (in *ComponentUsage) DeepCopy() *ComponentUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardAuthConfig) DeepCopyInto(out *DashboardAuthConfig) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(OCIImageDefinition)
+		**out = **in
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDCConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardAuthConfig.
+func (in *DashboardAuthConfig) DeepCopy() *DashboardAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardConfig) DeepCopyInto(out *DashboardConfig) {
+	*out = *in
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(DashboardExposeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(DashboardAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardConfig.
+func (in *DashboardConfig) DeepCopy() *DashboardConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardExposeConfig) DeepCopyInto(out *DashboardExposeConfig) {
+	*out = *in
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(GatewayReference)
+		**out = **in
+	}
+	if in.ControllerLabels != nil {
+		in, out := &in.ControllerLabels, &out.ControllerLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ControllerNamespaceLabels != nil {
+		in, out := &in.ControllerNamespaceLabels, &out.ControllerNamespaceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardExposeConfig.
+func (in *DashboardExposeConfig) DeepCopy() *DashboardExposeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardExposeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DaskCluster) DeepCopyInto(out *DaskCluster) {
 	*out = *in
@@ -181,78 +417,403 @@ func (in *DaskClusterList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DaskClusterSpec) DeepCopyInto(out *DaskClusterSpec) {
+func (in *DaskClusterSpec) DeepCopyInto(out *DaskClusterSpec) {
+	*out = *in
+	in.ScalableClusterConfig.DeepCopyInto(&out.ScalableClusterConfig)
+	in.Scheduler.DeepCopyInto(&out.Scheduler)
+	in.Worker.DeepCopyInto(&out.Worker)
+	if in.AdditionalClientPorts != nil {
+		in, out := &in.AdditionalClientPorts, &out.AdditionalClientPorts
+		*out = make([]corev1.ServicePort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaskClusterSpec.
+func (in *DaskClusterSpec) DeepCopy() *DaskClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DaskClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DaskClusterStatus) DeepCopyInto(out *DaskClusterStatus) {
+	*out = *in
+	in.ClusterStatusConfig.DeepCopyInto(&out.ClusterStatusConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaskClusterStatus.
+func (in *DaskClusterStatus) DeepCopy() *DaskClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DaskClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DaskClusterWorker) DeepCopyInto(out *DaskClusterWorker) {
+	*out = *in
+	in.WorkloadConfig.DeepCopyInto(&out.WorkloadConfig)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaskClusterWorker.
+func (in *DaskClusterWorker) DeepCopy() *DaskClusterWorker {
+	if in == nil {
+		return nil
+	}
+	out := new(DaskClusterWorker)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DistributedComputePolicy) DeepCopyInto(out *DistributedComputePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DistributedComputePolicy.
+func (in *DistributedComputePolicy) DeepCopy() *DistributedComputePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DistributedComputePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DistributedComputePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DistributedComputePolicyList) DeepCopyInto(out *DistributedComputePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DistributedComputePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DistributedComputePolicyList.
+func (in *DistributedComputePolicyList) DeepCopy() *DistributedComputePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(DistributedComputePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DistributedComputePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DistributedComputePolicySpec) DeepCopyInto(out *DistributedComputePolicySpec) {
+	*out = *in
+	if in.MaxWorkers != nil {
+		in, out := &in.MaxWorkers, &out.MaxWorkers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxPodResources != nil {
+		in, out := &in.MaxPodResources, &out.MaxPodResources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxClusterResources != nil {
+		in, out := &in.MaxClusterResources, &out.MaxClusterResources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.AllowedImages != nil {
+		in, out := &in.AllowedImages, &out.AllowedImages
+		*out = make([]AllowedImage, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedIstioMutualTLSModes != nil {
+		in, out := &in.AllowedIstioMutualTLSModes, &out.AllowedIstioMutualTLSModes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxClusters != nil {
+		in, out := &in.MaxClusters, &out.MaxClusters
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DistributedComputePolicySpec.
+func (in *DistributedComputePolicySpec) DeepCopy() *DistributedComputePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DistributedComputePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GangSchedulingConfig) DeepCopyInto(out *GangSchedulingConfig) {
+	*out = *in
+	if in.ScheduleTimeoutSeconds != nil {
+		in, out := &in.ScheduleTimeoutSeconds, &out.ScheduleTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GangSchedulingConfig.
+func (in *GangSchedulingConfig) DeepCopy() *GangSchedulingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GangSchedulingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayReference) DeepCopyInto(out *GatewayReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayReference.
+func (in *GatewayReference) DeepCopy() *GatewayReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IstioAuthorizationConfig) DeepCopyInto(out *IstioAuthorizationConfig) {
+	*out = *in
+	if in.ClientPrincipals != nil {
+		in, out := &in.ClientPrincipals, &out.ClientPrincipals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientNamespaces != nil {
+		in, out := &in.ClientNamespaces, &out.ClientNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DashboardPrincipals != nil {
+		in, out := &in.DashboardPrincipals, &out.DashboardPrincipals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DashboardNamespaces != nil {
+		in, out := &in.DashboardNamespaces, &out.DashboardNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MonitoringPrincipals != nil {
+		in, out := &in.MonitoringPrincipals, &out.MonitoringPrincipals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MonitoringNamespaces != nil {
+		in, out := &in.MonitoringNamespaces, &out.MonitoringNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioAuthorizationConfig.
+func (in *IstioAuthorizationConfig) DeepCopy() *IstioAuthorizationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IstioAuthorizationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IstioConfig) DeepCopyInto(out *IstioConfig) {
+	*out = *in
+	if in.Istio != nil {
+		in, out := &in.Istio, &out.Istio
+		*out = new(IstioResourcesConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioConfig.
+func (in *IstioConfig) DeepCopy() *IstioConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IstioConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IstioDestinationRuleConfig) DeepCopyInto(out *IstioDestinationRuleConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioDestinationRuleConfig.
+func (in *IstioDestinationRuleConfig) DeepCopy() *IstioDestinationRuleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IstioDestinationRuleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IstioEnvoyFilterConfig) DeepCopyInto(out *IstioEnvoyFilterConfig) {
 	*out = *in
-	in.ScalableClusterConfig.DeepCopyInto(&out.ScalableClusterConfig)
-	in.Scheduler.DeepCopyInto(&out.Scheduler)
-	in.Worker.DeepCopyInto(&out.Worker)
-	if in.AdditionalClientPorts != nil {
-		in, out := &in.AdditionalClientPorts, &out.AdditionalClientPorts
-		*out = make([]v1.ServicePort, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.WorkloadLabels != nil {
+		in, out := &in.WorkloadLabels, &out.WorkloadLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaskClusterSpec.
-func (in *DaskClusterSpec) DeepCopy() *DaskClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioEnvoyFilterConfig.
+func (in *IstioEnvoyFilterConfig) DeepCopy() *IstioEnvoyFilterConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(DaskClusterSpec)
+	out := new(IstioEnvoyFilterConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DaskClusterStatus) DeepCopyInto(out *DaskClusterStatus) {
+func (in *IstioResourcesConfig) DeepCopyInto(out *IstioResourcesConfig) {
 	*out = *in
-	in.ClusterStatusConfig.DeepCopyInto(&out.ClusterStatusConfig)
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(IstioAuthorizationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sidecar != nil {
+		in, out := &in.Sidecar, &out.Sidecar
+		*out = new(IstioSidecarConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DestinationRule != nil {
+		in, out := &in.DestinationRule, &out.DestinationRule
+		*out = new(IstioDestinationRuleConfig)
+		**out = **in
+	}
+	if in.EnvoyFilter != nil {
+		in, out := &in.EnvoyFilter, &out.EnvoyFilter
+		*out = new(IstioEnvoyFilterConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaskClusterStatus.
-func (in *DaskClusterStatus) DeepCopy() *DaskClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioResourcesConfig.
+func (in *IstioResourcesConfig) DeepCopy() *IstioResourcesConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(DaskClusterStatus)
+	out := new(IstioResourcesConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DaskClusterWorker) DeepCopyInto(out *DaskClusterWorker) {
+func (in *IstioSidecarConfig) DeepCopyInto(out *IstioSidecarConfig) {
 	*out = *in
-	in.WorkloadConfig.DeepCopyInto(&out.WorkloadConfig)
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
+	if in.EgressHosts != nil {
+		in, out := &in.EgressHosts, &out.EgressHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaskClusterWorker.
-func (in *DaskClusterWorker) DeepCopy() *DaskClusterWorker {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioSidecarConfig.
+func (in *IstioSidecarConfig) DeepCopy() *IstioSidecarConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(DaskClusterWorker)
+	out := new(IstioSidecarConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IstioConfig) DeepCopyInto(out *IstioConfig) {
+func (in *KerberosConfig) DeepCopyInto(out *KerberosConfig) {
 	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.RenewIntervalSeconds != nil {
+		in, out := &in.RenewIntervalSeconds, &out.RenewIntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(OCIImageDefinition)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioConfig.
-func (in *IstioConfig) DeepCopy() *IstioConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KerberosConfig.
+func (in *KerberosConfig) DeepCopy() *KerberosConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(IstioConfig)
+	out := new(KerberosConfig)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -265,6 +826,11 @@ func (in *KerberosKeytabConfig) DeepCopyInto(out *KerberosKeytabConfig) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(KerberosKeytabSecretReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KerberosKeytabConfig.
@@ -277,6 +843,21 @@ func (in *KerberosKeytabConfig) DeepCopy() *KerberosKeytabConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KerberosKeytabSecretReference) DeepCopyInto(out *KerberosKeytabSecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KerberosKeytabSecretReference.
+func (in *KerberosKeytabSecretReference) DeepCopy() *KerberosKeytabSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(KerberosKeytabSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MPICluster) DeepCopyInto(out *MPICluster) {
 	*out = *in
@@ -341,6 +922,16 @@ func (in *MPIClusterSpec) DeepCopyInto(out *MPIClusterSpec) {
 	*out = *in
 	in.ClusterConfig.DeepCopyInto(&out.ClusterConfig)
 	in.Worker.DeepCopyInto(&out.Worker)
+	if in.InitImage != nil {
+		in, out := &in.InitImage, &out.InitImage
+		*out = new(OCIImageDefinition)
+		**out = **in
+	}
+	if in.SyncImage != nil {
+		in, out := &in.SyncImage, &out.SyncImage
+		*out = new(OCIImageDefinition)
+		**out = **in
+	}
 	if in.WorkerPorts != nil {
 		in, out := &in.WorkerPorts, &out.WorkerPorts
 		*out = make([]int32, len(*in))
@@ -348,7 +939,7 @@ func (in *MPIClusterSpec) DeepCopyInto(out *MPIClusterSpec) {
 	}
 	if in.AdditionalClientPorts != nil {
 		in, out := &in.AdditionalClientPorts, &out.AdditionalClientPorts
-		*out = make([]v1.ServicePort, len(*in))
+		*out = make([]corev1.ServicePort, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -365,6 +956,27 @@ func (in *MPIClusterSpec) DeepCopy() *MPIClusterSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MPIClusterStatus) DeepCopyInto(out *MPIClusterStatus) {
+	*out = *in
+	in.ClusterStatusConfig.DeepCopyInto(&out.ClusterStatusConfig)
+	if in.AgentSecret != nil {
+		in, out := &in.AgentSecret, &out.AgentSecret
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MPIClusterStatus.
+func (in *MPIClusterStatus) DeepCopy() *MPIClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MPIClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MPIClusterWorker) DeepCopyInto(out *MPIClusterWorker) {
 	*out = *in
@@ -384,6 +996,11 @@ func (in *MPIClusterWorker) DeepCopyInto(out *MPIClusterWorker) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.SyncModules != nil {
+		in, out := &in.SyncModules, &out.SyncModules
+		*out = make([]SyncModule, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MPIClusterWorker.
@@ -396,6 +1013,42 @@ func (in *MPIClusterWorker) DeepCopy() *MPIClusterWorker {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringConfig) DeepCopyInto(out *MonitoringConfig) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PrometheusLabels != nil {
+		in, out := &in.PrometheusLabels, &out.PrometheusLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PrometheusNamespaceLabels != nil {
+		in, out := &in.PrometheusNamespaceLabels, &out.PrometheusNamespaceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringConfig.
+func (in *MonitoringConfig) DeepCopy() *MonitoringConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkPolicyConfig) DeepCopyInto(out *NetworkPolicyConfig) {
 	*out = *in
@@ -411,6 +1064,20 @@ func (in *NetworkPolicyConfig) DeepCopyInto(out *NetworkPolicyConfig) {
 			(*out)[key] = val
 		}
 	}
+	if in.ClientNamespaceLabels != nil {
+		in, out := &in.ClientNamespaceLabels, &out.ClientNamespaceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ClientIPBlocks != nil {
+		in, out := &in.ClientIPBlocks, &out.ClientIPBlocks
+		*out = make([]networkingv1.IPBlock, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.DashboardLabels != nil {
 		in, out := &in.DashboardLabels, &out.DashboardLabels
 		*out = make(map[string]string, len(*in))
@@ -425,6 +1092,11 @@ func (in *NetworkPolicyConfig) DeepCopyInto(out *NetworkPolicyConfig) {
 			(*out)[key] = val
 		}
 	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = new(NetworkPolicyEgressConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyConfig.
@@ -437,6 +1109,45 @@ func (in *NetworkPolicyConfig) DeepCopy() *NetworkPolicyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyEgressConfig) DeepCopyInto(out *NetworkPolicyEgressConfig) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyEgressConfig.
+func (in *NetworkPolicyEgressConfig) DeepCopy() *NetworkPolicyEgressConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyEgressConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeResources) DeepCopyInto(out *NodeResources) {
+	*out = *in
+	in.Current.DeepCopyInto(&out.Current)
+	in.Max.DeepCopyInto(&out.Max)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeResources.
+func (in *NodeResources) DeepCopy() *NodeResources {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OCIImageDefinition) DeepCopyInto(out *OCIImageDefinition) {
 	*out = *in
@@ -452,6 +1163,26 @@ func (in *OCIImageDefinition) DeepCopy() *OCIImageDefinition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCConfig) DeepCopyInto(out *OIDCConfig) {
+	*out = *in
+	if in.EmailDomains != nil {
+		in, out := &in.EmailDomains, &out.EmailDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfig.
+func (in *OIDCConfig) DeepCopy() *OIDCConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PersistentVolumeClaimTemplate) DeepCopyInto(out *PersistentVolumeClaimTemplate) {
 	*out = *in
@@ -555,11 +1286,16 @@ func (in *RayClusterSpec) DeepCopyInto(out *RayClusterSpec) {
 	}
 	if in.AdditionalClientPorts != nil {
 		in, out := &in.AdditionalClientPorts, &out.AdditionalClientPorts
-		*out = make([]v1.ServicePort, len(*in))
+		*out = make([]corev1.ServicePort, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayClusterSpec.
@@ -593,6 +1329,35 @@ func (in *RayClusterWorker) DeepCopy() *RayClusterWorker {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceTotals) DeepCopyInto(out *ResourceTotals) {
+	*out = *in
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTotals.
+func (in *ResourceTotals) DeepCopy() *ResourceTotals {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceTotals)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalableClusterConfig) DeepCopyInto(out *ScalableClusterConfig) {
 	*out = *in
@@ -602,6 +1367,21 @@ func (in *ScalableClusterConfig) DeepCopyInto(out *ScalableClusterConfig) {
 		*out = new(Autoscaling)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = new(SyncConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Dashboard != nil {
+		in, out := &in.Dashboard, &out.Dashboard
+		*out = new(DashboardConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalableClusterConfig.
@@ -733,6 +1513,21 @@ func (in *SparkClusterNode) DeepCopy() *SparkClusterNode {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SparkClusterSecurity) DeepCopyInto(out *SparkClusterSecurity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SparkClusterSecurity.
+func (in *SparkClusterSecurity) DeepCopy() *SparkClusterSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(SparkClusterSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SparkClusterSpec) DeepCopyInto(out *SparkClusterSpec) {
 	*out = *in
@@ -749,11 +1544,16 @@ func (in *SparkClusterSpec) DeepCopyInto(out *SparkClusterSpec) {
 	}
 	if in.AdditionalClientPorts != nil {
 		in, out := &in.AdditionalClientPorts, &out.AdditionalClientPorts
-		*out = make([]v1.ServicePort, len(*in))
+		*out = make([]corev1.ServicePort, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(SparkClusterSecurity)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SparkClusterSpec.
@@ -787,6 +1587,76 @@ func (in *SparkClusterWorker) DeepCopy() *SparkClusterWorker {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncConfig) DeepCopyInto(out *SyncConfig) {
+	*out = *in
+	if in.Modules != nil {
+		in, out := &in.Modules, &out.Modules
+		*out = make([]SyncModule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncConfig.
+func (in *SyncConfig) DeepCopy() *SyncConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncModule) DeepCopyInto(out *SyncModule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncModule.
+func (in *SyncModule) DeepCopy() *SyncModule {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncModule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncNodeStatus) DeepCopyInto(out *SyncNodeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncNodeStatus.
+func (in *SyncNodeStatus) DeepCopy() *SyncNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertManagerIssuerReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfig.
+func (in *TLSConfig) DeepCopy() *TLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadConfig) DeepCopyInto(out *WorkloadConfig) {
 	*out = *in
@@ -814,33 +1684,33 @@ func (in *WorkloadConfig) DeepCopyInto(out *WorkloadConfig) {
 	in.Resources.DeepCopyInto(&out.Resources)
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.InitContainers != nil {
 		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]v1.Container, len(*in))
+		*out = make([]corev1.Container, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
+		*out = make([]corev1.Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.VolumeMounts != nil {
 		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
+		*out = make([]corev1.VolumeMount, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -854,7 +1724,7 @@ func (in *WorkloadConfig) DeepCopyInto(out *WorkloadConfig) {
 	}
 	if in.SecurityContext != nil {
 		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(v1.SecurityContext)
+		*out = new(corev1.SecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
 }
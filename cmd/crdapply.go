@@ -18,7 +18,12 @@ Apply Rules:
   - If a definition is already present, then it will be updated
   - Updating definitions that have not changed results in a no-op`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return crd.Apply(context.Background(), istioEnabled)
+		mode, err := istioDataPlane()
+		if err != nil {
+			return err
+		}
+
+		return crd.Apply(context.Background(), mode.Sidecar())
 	},
 }
 
@@ -17,7 +17,12 @@ Any running distributed compute resources will be decommissioned when this
 operation runs (i.e. your deployments will be deleted immediately). This will
 only attempt to remove definitions that are already present in Kubernetes.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return crd.Delete(context.Background(), istioEnabled)
+		mode, err := istioDataPlane()
+		if err != nil {
+			return err
+		}
+
+		return crd.Delete(context.Background(), mode.Sidecar())
 	},
 }
 
@@ -0,0 +1,150 @@
+// Command rank-agent starts MPI rank processes on cluster workers that do not
+// run sshd. "serve" runs on every worker, while "launch" is used by MPI
+// launchers on the client side in place of ssh, e.g.:
+//
+//	mpirun --mca plm_rsh_agent "rank-agent launch" ...
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/rankagent"
+)
+
+const defaultTokenFile = "/etc/mpi/agent/token"
+
+var (
+	port      int
+	tokenFile string
+)
+
+var rootCmd = &cobra.Command{
+	Use:           "rank-agent",
+	Short:         "Starts MPI rank processes without sshd.",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+var serveOpts struct {
+	dir         string
+	gracePeriod time.Duration
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Accept launch requests and execute rank processes",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, err := rankagent.ReadToken(tokenFile)
+		if err != nil {
+			return err
+		}
+
+		logger := log.New(os.Stderr, "rank-agent: ", log.LstdFlags)
+		server := &http.Server{
+			Addr: net.JoinHostPort("", strconv.Itoa(port)),
+			Handler: &rankagent.Server{
+				Token:       token,
+				Dir:         serveOpts.dir,
+				GracePeriod: serveOpts.gracePeriod,
+				Logger:      logger,
+			},
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		go func() {
+			<-ctx.Done()
+			_ = server.Close()
+		}()
+
+		logger.Printf("listening on %s", server.Addr)
+		if err = server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
+var launchOpts struct {
+	noShell bool
+	dir     string
+	env     []string
+}
+
+var launchCmd = &cobra.Command{
+	Use:   "launch HOST COMMAND [ARG...]",
+	Short: "Run a command on the agent of a remote worker",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, err := rankagent.ReadToken(tokenFile)
+		if err != nil {
+			return err
+		}
+
+		address := args[0]
+		if _, _, err = net.SplitHostPort(address); err != nil {
+			address = net.JoinHostPort(address, strconv.Itoa(port))
+		}
+
+		client := &rankagent.Client{
+			Address: address,
+			Token:   token,
+		}
+		req := &rankagent.LaunchRequest{
+			Args:  args[1:],
+			Env:   launchOpts.env,
+			Dir:   launchOpts.dir,
+			Shell: !launchOpts.noShell,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		exitCode, err := client.Launch(ctx, req, os.Stdout, os.Stderr)
+		if err != nil {
+			return err
+		}
+
+		os.Exit(exitCode)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().IntVar(&port, "port", rankagent.DefaultPort, "Port of the rank agent")
+	rootCmd.PersistentFlags().StringVar(&tokenFile, "token-file", defaultTokenFile, "File containing the shared agent token")
+
+	serveCmd.Flags().StringVar(&serveOpts.dir, "dir", "", "Default working directory of launched processes")
+	serveCmd.Flags().DurationVar(&serveOpts.gracePeriod, "grace-period", 10*time.Second,
+		"Time given to processes to exit after their client disconnects")
+
+	// everything after HOST belongs to the remote command
+	launchCmd.Flags().SetInterspersed(false)
+	launchCmd.Flags().BoolVar(&launchOpts.noShell, "no-shell", false, "Execute the command directly instead of with \"sh -c\"")
+	launchCmd.Flags().StringVar(&launchOpts.dir, "dir", "", "Working directory of the remote process")
+	launchCmd.Flags().StringArrayVar(&launchOpts.env, "env", nil, "Extra KEY=value environment variables for the remote process")
+
+	rootCmd.AddCommand(serveCmd, launchCmd)
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "rank-agent:", err)
+		os.Exit(255)
+	}
+}
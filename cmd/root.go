@@ -5,9 +5,14 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
 )
 
-var istioEnabled bool
+var (
+	istioEnabled bool
+	istioMode    string
+)
 
 var rootCmd = &cobra.Command{
 	Use:   "distributed-compute-operator",
@@ -27,4 +32,16 @@ func init() {
 	// NOTE: required until https://github.com/spf13/cobra/issues/587
 	rootCmd.SetHelpCommand(&cobra.Command{Hidden: true})
 	rootCmd.PersistentFlags().BoolVar(&istioEnabled, "istio-enabled", false, "Enable support for Istio sidecar container")
+	rootCmd.PersistentFlags().StringVar(&istioMode, "istio-mode", string(istio.ModeSidecar),
+		"Istio data plane mode used when Istio support is enabled: sidecar or ambient")
+}
+
+// istioDataPlane returns the Istio data plane mode, or the zero value when
+// Istio support is disabled.
+func istioDataPlane() (istio.Mode, error) {
+	if !istioEnabled {
+		return "", nil
+	}
+
+	return istio.ParseMode(istioMode)
 }
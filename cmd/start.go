@@ -2,10 +2,12 @@ package cmd
 
 import (
 	"flag"
+	"fmt"
 
 	"github.com/dominodatalab/distributed-compute-operator/controllers"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/dominodatalab/distributed-compute-operator/pkg/manager"
@@ -15,6 +17,7 @@ const WebhookPort = 9443
 
 var (
 	namespaces           []string
+	namespaceSelector    string
 	probeAddr            string
 	metricsAddr          string
 	webhookPort          int
@@ -22,22 +25,38 @@ var (
 	zapOpts              = zap.Options{}
 	mpiInitImage         string
 	mpiSyncImage         string
+	usageSinkURL         string
 )
 
 var startCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start the controller manager",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		mode, err := istioDataPlane()
+		if err != nil {
+			return err
+		}
+
+		var selector labels.Selector
+		if namespaceSelector != "" {
+			if selector, err = labels.Parse(namespaceSelector); err != nil {
+				return fmt.Errorf("invalid namespace selector %q: %w", namespaceSelector, err)
+			}
+		}
+
 		cfg := &controllers.Config{
 			Namespaces:           namespaces,
+			NamespaceSelector:    selector,
 			MetricsAddr:          metricsAddr,
 			HealthProbeAddr:      probeAddr,
 			WebhookServerPort:    webhookPort,
 			EnableLeaderElection: enableLeaderElection,
 			IstioEnabled:         istioEnabled,
+			IstioMode:            mode,
 			ZapOptions:           zapOpts,
 			MPIInitImage:         mpiInitImage,
 			MPISyncImage:         mpiSyncImage,
+			UsageSinkURL:         usageSinkURL,
 		}
 
 		return manager.Start(cfg)
@@ -53,6 +72,9 @@ func init() {
 	startCmd.Flags().AddGoFlagSet(fs)
 	startCmd.Flags().StringSliceVar(&namespaces, "namespaces", nil,
 		"Only reconcile resources in these namespaces")
+	startCmd.Flags().StringVar(&namespaceSelector, "namespace-selector", "",
+		"Only reconcile resources in namespaces whose labels match this selector; "+
+			"namespaces are added and removed at runtime as their labels change")
 	startCmd.Flags().IntVar(&webhookPort, "webhook-server-port", WebhookPort,
 		"Webhook server will bind to this port")
 	startCmd.Flags().StringVar(&metricsAddr, "metrics-bind-address", ":8080",
@@ -62,9 +84,13 @@ func init() {
 	startCmd.Flags().BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election to ensure there is only one active controller manager")
 	startCmd.Flags().StringVar(&mpiInitImage, "mpi-init-image", "",
-		"Image for MPI worker init container")
+		"Default image for MPI worker init container; may be pinned with a digest (name@sha256:...)")
 	startCmd.Flags().StringVar(&mpiSyncImage, "mpi-sync-image", "",
-		"Image for MPI worker sync container")
+		"Default image for the worker file sync sidecar used by MPI clusters and clusters with sync enabled; "+
+			"may be pinned with a digest (name@sha256:...)")
+
+	startCmd.Flags().StringVar(&usageSinkURL, "usage-sink-url", "",
+		"Send the final usage record of every deleted cluster to this URL as a CloudEvent")
 
 	rootCmd.AddCommand(startCmd)
 }
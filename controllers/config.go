@@ -1,16 +1,81 @@
 package controllers
 
-import "sigs.k8s.io/controller-runtime/pkg/log/zap"
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/distribution/reference"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/metering"
+)
 
 // Config options for the controller manager.
 type Config struct {
-	Namespaces           []string
+	Namespaces []string
+	// NamespaceSelector limits reconciliation to namespaces with matching
+	// labels. Namespaces are selected at runtime as their labels change.
+	NamespaceSelector    labels.Selector
 	MetricsAddr          string
 	HealthProbeAddr      string
 	WebhookServerPort    int
 	EnableLeaderElection bool
 	IstioEnabled         bool
-	ZapOptions           zap.Options
-	MPIInitImage         string
-	MPISyncImage         string
+	// IstioMode is the data plane mode of the mesh. It is blank when Istio
+	// support is disabled.
+	IstioMode  istio.Mode
+	ZapOptions zap.Options
+	// MPIInitImage and MPISyncImage are the default helper images used when
+	// a cluster does not override them. They may be pinned with a digest.
+	MPIInitImage string
+	MPISyncImage string
+	// UsageSinkURL receives the final usage records of deleted clusters as
+	// CloudEvents. Records are only emitted as events when blank.
+	UsageSinkURL string
+}
+
+// Validate returns an error when the options cannot be used to start the
+// controller manager.
+func (c *Config) Validate() error {
+	if len(c.Namespaces) > 0 && c.NamespaceSelector != nil {
+		return fmt.Errorf("namespaces and namespace selector are mutually exclusive")
+	}
+
+	images := []struct {
+		name, ref string
+	}{
+		{"MPI init image", c.MPIInitImage},
+		{"MPI sync image", c.MPISyncImage},
+	}
+	for _, image := range images {
+		if image.ref == "" {
+			continue
+		}
+		if _, err := reference.ParseNormalizedNamed(image.ref); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", image.name, image.ref, err)
+		}
+	}
+
+	if c.UsageSinkURL != "" {
+		u, err := url.Parse(c.UsageSinkURL)
+		if err != nil {
+			return fmt.Errorf("invalid usage sink URL %q: %w", c.UsageSinkURL, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid usage sink URL %q: must be an absolute http(s) URL", c.UsageSinkURL)
+		}
+	}
+
+	return nil
+}
+
+// UsageSink returns the sink receiving final usage records, or nil when none
+// is configured.
+func (c *Config) UsageSink() metering.Sink {
+	if c.UsageSinkURL == "" {
+		return nil
+	}
+	return metering.NewHTTPSink(c.UsageSinkURL)
 }
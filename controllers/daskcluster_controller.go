@@ -6,6 +6,7 @@ import (
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/dask"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/namespaces"
 )
 
 //+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=daskclusters,verbs=get;list;watch;create;update;patch;delete
@@ -16,8 +17,16 @@ func DaskCluster(mgr ctrl.Manager, webhooksEnabled bool, cfg *Config) error {
 	reconciler := core.NewReconciler(mgr).
 		For(&dcv1alpha1.DaskCluster{}).
 		Component("istio-peerauthentication", dask.IstioPeerAuthentication(cfg.IstioEnabled)).
+		Component("istio-authorizationpolicy", dask.IstioAuthorizationPolicy(cfg.IstioMode)).
+		Component("istio-traffic", dask.IstioTraffic(cfg.IstioMode)).
+		Component("istio-envoyfilter", dask.EnvoyFilter(cfg.IstioMode)).
 		Component("serviceaccount", dask.ServiceAccount()).
-		Component("configmap-keytab", dask.ConfigMapKeyTab()).
+		Component("secret-keytab", dask.KeytabSecret()).
+		Component("configmap-kerberos", dask.ConfigMapKerberos()).
+		Component("configmap-dashboard-auth", dask.ConfigMapDashboardAuth()).
+		Component("clusterrolebinding-dashboard-auth", dask.DashboardAuthBinding()).
+		Component("secret-sync", dask.SyncSecret()).
+		Component("clustertls", dask.ClusterTLS()).
 		Component("role-podsecuritypolicy", dask.RolePodSecurityPolicy()).
 		Component("rolebinding-podsecuritypolicy", dask.RoleBindingPodSecurityPolicy()).
 		Component("service-scheduler", dask.ServiceScheduler()).
@@ -26,11 +35,17 @@ func DaskCluster(mgr ctrl.Manager, webhooksEnabled bool, cfg *Config) error {
 		Component("networkpolicy-scheduler", dask.NetworkPolicyScheduler()).
 		Component("networkpolicy-worker", dask.NetworkPolicyWorker()).
 		Component("networkpolicy-proxy", dask.ClientPortsNetworkPolicy()).
-		Component("statefulset-scheduler", dask.StatefulSetScheduler()).
-		Component("statefulset-worker", dask.StatefulSetWorker()).
+		Component("networkpolicy-egress", dask.NetworkPolicyEgress(cfg.IstioEnabled)).
+		Component("dashboard", dask.Dashboard()).
+		Component("podgroup", dask.PodGroup()).
+		Component("podmonitor", dask.PodMonitor()).
+		Component("workload", dask.Workload(cfg.MPISyncImage, cfg.IstioMode)).
+		Component("statefulset-scheduler", dask.StatefulSetScheduler(cfg.IstioMode)).
+		Component("statefulset-worker", dask.StatefulSetWorker(cfg.MPISyncImage, cfg.IstioMode)).
 		Component("horizontalpodautoscaler", dask.HorizontalPodAutoscaler()).
-		Component("statusupdate", dask.ClusterStatusUpdate())
+		Component("statusupdate", dask.ClusterStatusUpdate(cfg.UsageSink()))
 
+	reconciler.WithNamespaces(namespaces.NewSelector(mgr.GetClient(), cfg.NamespaceSelector))
 	if webhooksEnabled {
 		reconciler.WithWebhooks()
 	}
@@ -6,6 +6,7 @@ import (
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/mpi"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/namespaces"
 )
 
 //+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=mpiclusters,verbs=get;list;watch;create;update;patch;delete
@@ -18,19 +19,29 @@ func MPICluster(mgr ctrl.Manager, webhooksEnabled bool, cfg *Config) error {
 		For(&dcv1alpha1.MPICluster{}).
 		Component("istio-peerauthentication", mpi.IstioPeerAuthentication(cfg.IstioEnabled)).
 		Component("istio-client-peerauthentication", mpi.IstioClientPeerAuthentication(cfg.IstioEnabled)).
+		Component("istio-authorizationpolicy", mpi.IstioAuthorizationPolicy(cfg.IstioMode)).
+		Component("istio-traffic", mpi.IstioTraffic(cfg.IstioMode)).
+		Component("istio-envoyfilter", mpi.EnvoyFilter(cfg.IstioMode)).
 		Component("serviceaccount", mpi.ServiceAccount()).
 		Component("role", mpi.RolePodSecurityPolicy()).
 		Component("rolebinding", mpi.RoleBindingPodSecurityPolicy()).
 		Component("configmap", mpi.ConfigMap()).
+		Component("secret-sync", mpi.SyncSecret()).
+		Component("secret-keytab", mpi.KeytabSecret()).
+		Component("secret-agent", mpi.AgentSecret()).
 		Component("service-worker", mpi.ServiceWorker()).
 		Component("service-proxy", mpi.ClientPortsService()).
 		Component("service-client", mpi.ServiceClient()).
 		Component("networkpolicy-worker", mpi.NetworkPolicyWorker()).
 		Component("networkpolicy-client", mpi.NetworkPolicyClient()).
 		Component("networkpolicy-proxy", mpi.ClientPortsNetworkPolicy()).
-		Component("workers", mpi.StatefulSet(cfg.MPIInitImage, cfg.MPISyncImage)).
-		Component("statusupdate", mpi.StatusUpdate())
+		Component("networkpolicy-egress", mpi.NetworkPolicyEgress(cfg.IstioEnabled)).
+		Component("podgroup", mpi.PodGroup()).
+		Component("workload", mpi.Workload(cfg.MPIInitImage, cfg.MPISyncImage, cfg.IstioMode)).
+		Component("workers", mpi.StatefulSet(cfg.MPIInitImage, cfg.MPISyncImage, cfg.IstioMode)).
+		Component("statusupdate", mpi.StatusUpdate(cfg.MPIInitImage, cfg.MPISyncImage, cfg.UsageSink()))
 
+	reconciler.WithNamespaces(namespaces.NewSelector(mgr.GetClient(), cfg.NamespaceSelector))
 	if webhooksEnabled {
 		reconciler.WithWebhooks()
 	}
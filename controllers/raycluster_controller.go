@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/namespaces"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -18,12 +19,14 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,42 +34,72 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/accounting"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/clientaccess"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashboard"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/kerberos"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/metering"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/ray"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/workload"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
 )
 
 // RayClusterReconciler reconciles RayCluster objects.
 type RayClusterReconciler struct {
 	client.Client
+	// APIReader reads objects that are not cached by Client.
+	APIReader    client.Reader
 	Log          logr.Logger
 	Scheme       *runtime.Scheme
+	Recorder     record.EventRecorder
 	IstioEnabled bool
+	IstioMode    istio.Mode
+	SyncImage    string
+	// UsageSink receives the final usage of deleted clusters when not nil.
+	UsageSink metering.Sink
+	// Namespaces limits reconciliation to selected namespaces when not nil.
+	Namespaces *namespaces.Selector
 }
 
 // SetupWithManager creates and registers this controller with the manager.
 func (r *RayClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&dcv1alpha1.RayCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.ServiceAccount{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
 		Owns(&networkingv1.NetworkPolicy{}).
-		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
-		Complete(r)
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{})
+
+	return r.Namespaces.Watch(b, &dcv1alpha1.RayClusterList{}).Complete(r)
 }
 
 //+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=rayclusters,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=rayclusters/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=rayclusters/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",resources=pods,verbs=list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups="",resources=services;serviceaccounts,verbs=create;update;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=create;update;delete;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=create;update;delete;list;watch
 //+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=create;update;list;watch
 //+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=create;update;delete;list;watch
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=create;update;delete;list;watch
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=create;update;delete;list;watch
+//+kubebuilder:rbac:groups=scheduling.x-k8s.io,resources=podgroups,verbs=get;create;update;delete
+//+kubebuilder:rbac:groups=scheduling.volcano.sh,resources=podgroups,verbs=get;create;update;delete
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;create;update;delete
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;create;update;delete
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;create;update;delete
 
 // Reconcile implements state reconciliation logic for RayCluster objects.
 func (r *RayClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -86,6 +119,19 @@ func (r *RayClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
+	// clusters outside of selected namespaces are only finalized
+	if rc.DeletionTimestamp.IsZero() {
+		selected, err := r.Namespaces.Selected(ctx, rc.Namespace)
+		if err != nil {
+			log.Error(err, "failed to retrieve namespace")
+			return ctrl.Result{}, err
+		}
+		if !selected {
+			log.V(1).Info("skipping reconciliation outside of selected namespaces")
+			return ctrl.Result{}, nil
+		}
+	}
+
 	if updated, err := r.manageFinalization(ctx, rc); err != nil {
 		return ctrl.Result{}, err
 	} else if updated {
@@ -107,6 +153,15 @@ func (r *RayClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
+	// pod groups and workloads are not watched, so they are polled until settled
+	if rc.Status.Queued || (podgroup.Enabled(rc.Spec.GangScheduling) && !podgroup.Settled(rc.Status.PodGroupPhase)) {
+		return ctrl.Result{RequeueAfter: pollPeriod}, nil
+	}
+	// usage is accumulated periodically while pods exist
+	if len(rc.Status.Nodes) > 0 {
+		return ctrl.Result{RequeueAfter: metering.PersistPeriod}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -130,10 +185,15 @@ func (r *RayClusterReconciler) manageFinalization(ctx context.Context, rc *dcv1a
 	}
 
 	if rc.GetDeletionTimestamp() != nil && registered {
+		pods, err := r.listPods(ctx, rc)
+		if err != nil {
+			return false, err
+		}
+
 		rc.Status.ClusterStatus = dcv1alpha1.StoppingStatus
 		rc.Status.StartTime = nil
-		err := r.Status().Update(ctx, rc)
-		if err != nil {
+		rc.Status.Usage = metering.Accumulate(rc.Status.Usage, pods, time.Now())
+		if err = r.Status().Update(ctx, rc); err != nil {
 			return false, err
 		}
 
@@ -142,6 +202,15 @@ func (r *RayClusterReconciler) manageFinalization(ctx context.Context, rc *dcv1a
 			log.Error(err, "failed to clean up storage")
 			return false, err
 		}
+		if err := components.DeleteDashboardAuthBinding(ctx, r.Client, ray.DashboardAuthInfo(rc)); err != nil {
+			log.Error(err, "failed to clean up dashboard auth binding")
+			return false, err
+		}
+
+		accounting.DeleteMetrics("RayCluster", rc.Namespace, rc.Name)
+		if err = metering.Report(ctx, r.Recorder, r.UsageSink, "RayCluster", rc, rc.Status.Usage); err != nil {
+			log.Error(err, "failed to send final usage record")
+		}
 
 		log.V(1).Info("removing finalizer", "name", DistributedComputeFinalizer)
 		controllerutil.RemoveFinalizer(rc, DistributedComputeFinalizer)
@@ -170,18 +239,48 @@ func (r *RayClusterReconciler) reconcileResources(ctx context.Context, rc *dcv1a
 	if err := r.reconcileServiceAccount(ctx, rc); err != nil {
 		return err
 	}
+	if err := r.reconcileSyncSecret(ctx, rc); err != nil {
+		return err
+	}
+	if err := r.reconcileClusterTLS(ctx, rc); err != nil {
+		return err
+	}
+	if err := r.reconcileKeytab(ctx, rc); err != nil {
+		return err
+	}
+	if err := r.reconcileKerberos(ctx, rc); err != nil {
+		return err
+	}
 	if err := r.reconcileServices(ctx, rc); err != nil {
 		return err
 	}
 	if err := r.reconcileNetworkPolicies(ctx, rc); err != nil {
 		return err
 	}
+	if err := r.reconcileEgressNetworkPolicy(ctx, rc); err != nil {
+		return err
+	}
 	if err := r.reconcilePodSecurityPolicyRBAC(ctx, rc); err != nil {
 		return err
 	}
 	if err := r.reconcileAutoscaler(ctx, rc); err != nil {
 		return err
 	}
+	if err := r.reconcilePodGroup(ctx, rc); err != nil {
+		return err
+	}
+	if err := r.reconcilePodMonitor(ctx, rc); err != nil {
+		return err
+	}
+	if err := r.reconcileDashboard(ctx, rc); err != nil {
+		return err
+	}
+	if err := r.reconcileDashboardAuth(ctx, rc); err != nil {
+		return err
+	}
+	if err := r.reconcileWorkload(ctx, rc); err != nil {
+		return err
+	}
 	if err := r.reconcileClientPorts(ctx, rc); err != nil {
 		return err
 	}
@@ -194,6 +293,25 @@ func (r *RayClusterReconciler) reconcileIstio(ctx context.Context, rc *dcv1alpha
 		return nil
 	}
 
+	envoyFilter := istio.NewEnvoyFilter(ray.EnvoyFilterInfo(rc))
+
+	// pods do not run an envoy sidecar in ambient mode
+	if r.IstioMode.Ambient() {
+		if err := r.deleteIfExists(ctx, envoyFilter); err != nil {
+			return err
+		}
+	} else if err := r.createOrUpdateOwnedResource(ctx, rc, envoyFilter); err != nil {
+		return fmt.Errorf("failed to reconcile envoy filter: %w", err)
+	}
+
+	if err := r.reconcileAuthorizationPolicy(ctx, rc); err != nil {
+		return err
+	}
+
+	if err := r.reconcileIstioTraffic(ctx, rc); err != nil {
+		return err
+	}
+
 	peerAuth := istio.NewPeerAuthentication(&istio.PeerAuthInfo{
 		Name:      ray.InstanceObjectName(rc.Name, ray.ComponentNone),
 		Namespace: rc.Namespace,
@@ -212,6 +330,66 @@ func (r *RayClusterReconciler) reconcileIstio(ctx context.Context, rc *dcv1alpha
 	return nil
 }
 
+// reconcileAuthorizationPolicy restricts the workloads allowed to reach
+// cluster pods, and the clients of the cluster services when traffic is
+// forwarded by a waypoint.
+func (r *RayClusterReconciler) reconcileAuthorizationPolicy(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
+	info := ray.AuthorizationPolicyInfo(rc, r.IstioMode)
+	authzPolicy := istio.NewAuthorizationPolicy(info)
+	waypointRef := istio.WaypointAuthorizationPolicyReference(info.Name, info.Namespace)
+
+	if !istio.AuthorizationEnabled(rc.Spec.IstioConfig) {
+		return r.deleteIfExists(ctx, authzPolicy, waypointRef)
+	}
+
+	if !istio.WaypointPolicyEnabled(info) {
+		if err := r.deleteIfExists(ctx, waypointRef); err != nil {
+			return err
+		}
+	} else {
+		waypointPolicy, err := istio.NewWaypointAuthorizationPolicy(info)
+		if err != nil {
+			return err
+		}
+		if err = r.createOrUpdateOwnedResource(ctx, rc, waypointPolicy); err != nil {
+			return fmt.Errorf("failed to reconcile waypoint authorization policy: %w", err)
+		}
+	}
+
+	if err := r.createOrUpdateOwnedResource(ctx, rc, authzPolicy); err != nil {
+		return fmt.Errorf("failed to reconcile authorization policy: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileIstioTraffic limits the mesh configuration of cluster pods with a
+// Sidecar and requires Istio mutual TLS to the cluster services.
+func (r *RayClusterReconciler) reconcileIstioTraffic(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
+	info := ray.TrafficInfo(rc, r.IstioMode)
+
+	sidecar := istio.NewSidecar(info)
+	if !istio.SidecarEnabled(rc.Spec.IstioConfig, info.Mode) {
+		if err := r.deleteIfExists(ctx, sidecar); err != nil {
+			return err
+		}
+	} else if err := r.createOrUpdateOwnedResource(ctx, rc, sidecar); err != nil {
+		return fmt.Errorf("failed to reconcile sidecar: %w", err)
+	}
+
+	for _, rule := range istio.NewDestinationRules(info) {
+		if !istio.DestinationRuleEnabled(rc.Spec.IstioConfig) {
+			if err := r.deleteIfExists(ctx, rule); err != nil {
+				return err
+			}
+		} else if err := r.createOrUpdateOwnedResource(ctx, rc, rule); err != nil {
+			return fmt.Errorf("failed to reconcile destination rule: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (r *RayClusterReconciler) reconcileClientPorts(ctx context.Context, sc *dcv1alpha1.RayCluster) error {
 	obj := client.Object(sc)
 
@@ -245,6 +423,81 @@ func (r *RayClusterReconciler) reconcileServiceAccount(ctx context.Context, rc *
 	return nil
 }
 
+// reconcileSyncSecret creates a secret holding the credentials for the worker
+// file sync sidecar when sync is enabled. The generated password is preserved
+// across updates and the secret is deleted once sync is disabled.
+func (r *RayClusterReconciler) reconcileSyncSecret(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
+	current := &corev1.Secret{}
+	key := types.NamespacedName{Name: ray.SyncSecretName(rc.Name), Namespace: rc.Namespace}
+
+	if !ray.SyncEnabled(rc) {
+		current.Name, current.Namespace = key.Name, key.Namespace
+		return r.deleteIfExists(ctx, current)
+	}
+
+	password, err := filesync.FetchPassword(ctx, r, key)
+	if err != nil {
+		return err
+	}
+
+	if err = r.createOrUpdateOwnedResource(ctx, rc, ray.NewSyncSecret(rc, password)); err != nil {
+		return fmt.Errorf("failed to reconcile sync secret: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileClusterTLS manages the certificates that secure ray gRPC traffic.
+func (r *RayClusterReconciler) reconcileClusterTLS(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
+	return components.ReconcileClusterTLS(ctx, r.Client, ray.ClusterTLSInfo(rc), components.ClusterTLSActions{
+		Apply: func(ctx context.Context, obj client.Object) error {
+			return r.createOrUpdateOwnedResource(ctx, rc, obj)
+		},
+		Delete: r.deleteIfExists,
+	})
+}
+
+// reconcileKeytab copies a keytab embedded in the cluster spec into a secret
+// and deletes it once the keytab is removed or moved to a user secret. A
+// warning event is recorded when the secret is created.
+func (r *RayClusterReconciler) reconcileKeytab(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
+	info := ray.KeytabInfo(rc)
+	if err := r.deleteIfExists(ctx, kerberos.KeytabConfigMapReference(info)); err != nil {
+		return fmt.Errorf("failed to delete keytab configmap: %w", err)
+	}
+
+	secret := kerberos.NewKeytabSecret(info)
+	if !kerberos.InlineKeytab(rc.Spec.KerberosKeytab) {
+		return r.deleteIfExists(ctx, secret)
+	}
+	err := r.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{})
+	if apierrors.IsNotFound(err) {
+		kerberos.RecordInlineKeytab(r.Recorder, rc)
+	} else if err != nil {
+		return fmt.Errorf("cannot fetch keytab secret: %w", err)
+	}
+	if err = r.createOrUpdateOwnedResource(ctx, rc, secret); err != nil {
+		return fmt.Errorf("failed to reconcile keytab secret: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileKerberos generates krb5.conf for the ticket renewal sidecar and
+// deletes it once kerberos is disabled or an existing configmap is referenced.
+func (r *RayClusterReconciler) reconcileKerberos(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
+	cm := kerberos.NewConfigMap(ray.KerberosInfo(rc))
+
+	if !kerberos.ConfigMapEnabled(rc.Spec.Kerberos) {
+		return r.deleteIfExists(ctx, cm)
+	}
+	if err := r.createOrUpdateOwnedResource(ctx, rc, cm); err != nil {
+		return fmt.Errorf("failed to reconcile kerberos configmap: %w", err)
+	}
+
+	return nil
+}
+
 // reconcileServices creates services that point to head and worker pods and
 // applies updates when the parent CR changes.
 func (r *RayClusterReconciler) reconcileServices(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
@@ -273,9 +526,27 @@ func (r RayClusterReconciler) reconcileNetworkPolicies(ctx context.Context, rc *
 	clusterNetpol := ray.NewClusterNetworkPolicy(rc)
 	clientNetpol := ray.NewHeadClientNetworkPolicy(rc)
 	dashboardNetpol := ray.NewHeadDashboardNetworkPolicy(rc)
+	syncNetpol := ray.NewWorkerSyncNetworkPolicy(rc)
+	metricsNetpol := ray.NewMetricsNetworkPolicy(rc)
 
 	if util.BoolPtrIsNilOrFalse(rc.Spec.NetworkPolicy.Enabled) {
-		return r.deleteIfExists(ctx, dashboardNetpol, clientNetpol, clusterNetpol)
+		return r.deleteIfExists(ctx, metricsNetpol, syncNetpol, dashboardNetpol, clientNetpol, clusterNetpol)
+	}
+
+	if !monitoring.Enabled(rc.Spec.Monitoring) {
+		if err := r.deleteIfExists(ctx, metricsNetpol); err != nil {
+			return err
+		}
+	} else if err := r.createOrUpdateOwnedResource(ctx, rc, metricsNetpol); err != nil {
+		return fmt.Errorf("failed to reconcile metrics network policy: %w", err)
+	}
+
+	if !ray.SyncEnabled(rc) {
+		if err := r.deleteIfExists(ctx, syncNetpol); err != nil {
+			return err
+		}
+	} else if err := r.createOrUpdateOwnedResource(ctx, rc, syncNetpol); err != nil {
+		return fmt.Errorf("failed to reconcile worker sync network policy: %w", err)
 	}
 
 	if err := r.createOrUpdateOwnedResource(ctx, rc, clusterNetpol); err != nil {
@@ -291,6 +562,25 @@ func (r RayClusterReconciler) reconcileNetworkPolicies(ctx context.Context, rc *
 	return nil
 }
 
+// reconcileEgressNetworkPolicy restricts the egress traffic of cluster pods
+// when requested and deletes the policy otherwise.
+func (r *RayClusterReconciler) reconcileEgressNetworkPolicy(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
+	rules, err := components.ControlPlaneEgressRules(ctx, r.APIReader, rc.Spec.NetworkPolicy, r.IstioEnabled)
+	if err != nil {
+		return err
+	}
+	netpol := components.NewEgressNetworkPolicy(rc, rc.Spec.NetworkPolicy, rules, ray.Meta)
+
+	if !components.EgressEnabled(rc.Spec.NetworkPolicy) {
+		return r.deleteIfExists(ctx, netpol)
+	}
+	if err := r.createOrUpdateOwnedResource(ctx, rc, netpol); err != nil {
+		return fmt.Errorf("failed to reconcile egress network policy: %w", err)
+	}
+
+	return nil
+}
+
 // nolint:dupl
 // reconcilePodSecurityPolicyRBAC optionally creates a role and role binding
 // that allows the Ray pods to "use" the specified pod security policy.
@@ -301,7 +591,16 @@ func (r *RayClusterReconciler) reconcilePodSecurityPolicyRBAC(ctx context.Contex
 		return r.deleteIfExists(ctx, role, binding)
 	}
 
-	err := r.Get(ctx, types.NamespacedName{Name: rc.Spec.PodSecurityPolicy}, &policyv1beta1.PodSecurityPolicy{})
+	available, err := util.PodSecurityPolicyAvailable(r.RESTMapper())
+	if err != nil {
+		return err
+	}
+	if !available {
+		logr.FromContextOrDiscard(ctx).V(1).Info("skipping pod security policy rbac, api is not available")
+		return nil
+	}
+
+	err = r.Get(ctx, types.NamespacedName{Name: rc.Spec.PodSecurityPolicy}, &policyv1beta1.PodSecurityPolicy{})
 	if err != nil {
 		return fmt.Errorf("cannot verify pod security policy: %w", err)
 	}
@@ -339,6 +638,163 @@ func (r *RayClusterReconciler) reconcileAutoscaler(ctx context.Context, rc *dcv1
 	return nil
 }
 
+// reconcilePodGroup optionally creates a PodGroup that gang schedules the
+// ray pods and removes the PodGroups of any other scheduler.
+func (r *RayClusterReconciler) reconcilePodGroup(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
+	info := ray.PodGroupInfo(rc)
+
+	for _, pg := range podgroup.Stale(info.Config, info.Name, info.Namespace) {
+		if err := util.IgnoreMissing(r.deleteIfExists(ctx, pg)); err != nil {
+			return fmt.Errorf("failed to delete pod group: %w", err)
+		}
+	}
+
+	if !podgroup.Enabled(info.Config) {
+		return nil
+	}
+	if err := r.createOrUpdateOwnedResource(ctx, rc, podgroup.New(info)); err != nil {
+		return fmt.Errorf("failed to reconcile pod group: %w", err)
+	}
+
+	return nil
+}
+
+// reconcilePodMonitor optionally creates a PodMonitor that scrapes the
+// metrics of all ray nodes and removes it when monitoring is disabled.
+func (r *RayClusterReconciler) reconcilePodMonitor(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
+	info := ray.PodMonitorInfo(rc)
+
+	if !monitoring.Enabled(info.Config) {
+		pm := monitoring.Reference(info.Name, info.Namespace)
+		if err := util.IgnoreMissing(r.deleteIfExists(ctx, pm)); err != nil {
+			return fmt.Errorf("failed to delete pod monitor: %w", err)
+		}
+		return nil
+	}
+	if err := r.createOrUpdateOwnedResource(ctx, rc, monitoring.New(info)); err != nil {
+		return fmt.Errorf("failed to reconcile pod monitor: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileDashboard optionally exposes the ray head dashboard with an
+// Ingress or HTTPRoute and removes the routing objects that are not used.
+func (r *RayClusterReconciler) reconcileDashboard(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
+	info := ray.DashboardInfo(rc)
+
+	for _, obj := range dashboard.Stale(info) {
+		if err := util.IgnoreMissing(r.deleteIfExists(ctx, obj)); err != nil {
+			return fmt.Errorf("failed to delete dashboard route: %w", err)
+		}
+	}
+
+	if !dashboard.Enabled(info.Config) {
+		return nil
+	}
+	obj, err := dashboard.New(info)
+	if err != nil {
+		return fmt.Errorf("failed to build dashboard route: %w", err)
+	}
+	if err = r.createOrUpdateOwnedResource(ctx, rc, obj); err != nil {
+		return fmt.Errorf("failed to reconcile dashboard route: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileDashboardAuth manages the configuration and cluster role binding
+// of the TokenReview dashboard proxy and removes them when they are not used.
+func (r *RayClusterReconciler) reconcileDashboardAuth(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
+	info := ray.DashboardAuthInfo(rc)
+	cm := dashboard.AuthConfigMap(info)
+
+	if !ray.DashboardAuthEnabled(rc) || !dashboard.AuthConfigMapEnabled(rc.Spec.Dashboard) {
+		if err := r.deleteIfExists(ctx, cm); err != nil {
+			return err
+		}
+		return components.DeleteDashboardAuthBinding(ctx, r.Client, info)
+	}
+	if err := r.createOrUpdateOwnedResource(ctx, rc, cm); err != nil {
+		return fmt.Errorf("failed to reconcile dashboard auth config map: %w", err)
+	}
+
+	return components.ReconcileDashboardAuthBinding(ctx, r.Client, r.APIReader, info)
+}
+
+// modifyStatusDashboard publishes the external URL of the dashboard.
+func (r *RayClusterReconciler) modifyStatusDashboard(ctx context.Context, rc *dcv1alpha1.RayCluster) (bool, error) {
+	url, err := dashboard.URL(ray.DashboardInfo(rc))
+	if err != nil || url == rc.Status.DashboardURL {
+		return false, err
+	}
+
+	logr.FromContextOrDiscard(ctx).V(1).Info("modifying status", "path", ".status.dashboardURL", "value", url)
+	rc.Status.DashboardURL = url
+
+	return true, nil
+}
+
+// modifyStatusClientPeers publishes the peers granted ingress access to the
+// head client server port by network policies.
+func (r *RayClusterReconciler) modifyStatusClientPeers(ctx context.Context, rc *dcv1alpha1.RayCluster) bool {
+	clientPeers := clientaccess.StatusPeers(rc.Spec.NetworkPolicy, ray.ClientPeers(rc))
+	if equality.Semantic.DeepEqual(clientPeers, rc.Status.ClientPeers) {
+		return false
+	}
+
+	logr.FromContextOrDiscard(ctx).V(1).Info("modifying status", "path", ".status.clientPeers", "value", clientPeers)
+	rc.Status.ClientPeers = clientPeers
+
+	return true
+}
+
+// reconcileWorkload queues the cluster with an admission controller when it
+// requests a queue. The cluster remains suspended until its workload is admitted.
+func (r *RayClusterReconciler) reconcileWorkload(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
+	info, err := ray.WorkloadInfo(rc, r.IstioMode, r.SyncImage)
+	if err != nil {
+		return err
+	}
+
+	if info.QueueName == "" {
+		rc.Status.Queued = false
+		if err = util.IgnoreMissing(r.deleteIfExists(ctx, workload.Reference(info.Name, info.Namespace))); err != nil {
+			return fmt.Errorf("failed to delete workload: %w", err)
+		}
+		return nil
+	}
+
+	wl, err := workload.New(info)
+	if err != nil {
+		return err
+	}
+
+	err = r.createOrUpdateOwnedResource(ctx, rc, wl)
+	if apierrors.IsInvalid(err) {
+		// the pod sets of admitted workloads are immutable, so the cluster is
+		// suspended and queued again with a new workload.
+		logr.FromContextOrDiscard(ctx).Info("recreating workload after pod set changes")
+		rc.Status.Queued = true
+
+		if err = r.deleteIfExists(ctx, wl); err != nil {
+			return fmt.Errorf("failed to delete workload: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reconcile workload: %w", err)
+	}
+
+	admitted, err := workload.FetchAdmitted(ctx, r, info.Name, info.Namespace)
+	if err != nil {
+		return err
+	}
+	rc.Status.Queued = !admitted
+
+	return nil
+}
+
 func (r *RayClusterReconciler) initializeStatus(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
 	if rc.Status.ClusterStatus == "" {
 		rc.Status.ClusterStatus = dcv1alpha1.PendingStatus
@@ -351,7 +807,7 @@ func (r *RayClusterReconciler) initializeStatus(ctx context.Context, rc *dcv1alp
 // reconcileStatefulSets creates separate Ray head and worker stateful sets
 // that will collectively comprise the execution agents of the cluster.
 func (r *RayClusterReconciler) reconcileStatefulSets(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
-	head, err := ray.NewStatefulSet(rc, ray.ComponentHead, r.IstioEnabled)
+	head, err := ray.NewStatefulSet(rc, ray.ComponentHead, r.IstioMode, r.SyncImage)
 	if err != nil {
 		return err
 	}
@@ -359,7 +815,7 @@ func (r *RayClusterReconciler) reconcileStatefulSets(ctx context.Context, rc *dc
 		return fmt.Errorf("failed to create head stateful set: %w", err)
 	}
 
-	worker, err := ray.NewStatefulSet(rc, ray.ComponentWorker, r.IstioEnabled)
+	worker, err := ray.NewStatefulSet(rc, ray.ComponentWorker, r.IstioMode, r.SyncImage)
 	if err != nil {
 		return err
 	}
@@ -370,14 +826,15 @@ func (r *RayClusterReconciler) reconcileStatefulSets(ctx context.Context, rc *dc
 	updateStatus := false
 	var status dcv1alpha1.ClusterStatusType
 	masterPod, masterPodFound := r.getMasterPod(ctx, rc)
-	if masterPodFound {
-		if dcv1alpha1.IsPodReady(masterPod) {
-			status = dcv1alpha1.RunningStatus
-		} else {
-			status = dcv1alpha1.StartingStatus
-		}
-	} else {
+	switch {
+	case rc.Status.Queued:
+		status = dcv1alpha1.QueuedStatus
+	case !masterPodFound:
 		status = dcv1alpha1.PendingStatus
+	case dcv1alpha1.IsPodReady(masterPod):
+		status = dcv1alpha1.RunningStatus
+	default:
+		status = dcv1alpha1.StartingStatus
 	}
 	if rc.Status.ClusterStatus != status && rc.GetDeletionTimestamp() == nil {
 		updateStatus = true
@@ -506,7 +963,36 @@ func (r *RayClusterReconciler) updateStatus(ctx context.Context, rc *dcv1alpha1.
 		return fmt.Errorf("cannot modify cluster status worker fields: %w", err)
 	}
 
-	if mNodes || mWorkedFields {
+	mSync, err := r.modifyStatusSync(ctx, rc)
+	if err != nil {
+		return fmt.Errorf("cannot modify cluster status sync fields: %w", err)
+	}
+
+	mPodGroup, err := r.modifyStatusPodGroup(ctx, rc)
+	if err != nil {
+		return fmt.Errorf("cannot modify cluster status pod group phase: %w", err)
+	}
+
+	mDashboard, err := r.modifyStatusDashboard(ctx, rc)
+	if err != nil {
+		return fmt.Errorf("cannot modify cluster status dashboard url: %w", err)
+	}
+
+	mClientPeers := r.modifyStatusClientPeers(ctx, rc)
+
+	pods, err := r.listPods(ctx, rc)
+	if err != nil {
+		return err
+	}
+
+	mResources, err := r.modifyStatusResources(ctx, rc, pods)
+	if err != nil {
+		return fmt.Errorf("cannot modify cluster status resources: %w", err)
+	}
+
+	mUsage := r.modifyStatusUsage(ctx, rc, pods)
+
+	if mNodes || mWorkedFields || mSync || mPodGroup || mDashboard || mClientPeers || mResources || mUsage {
 		if err = r.Status().Update(ctx, rc); err != nil {
 			return err
 		}
@@ -546,7 +1032,7 @@ func (r *RayClusterReconciler) modifyStatusNodes(ctx context.Context, rc *dcv1al
 
 // modifyStatusWorkerFields syncs certain worker stateful set fields into the status.
 func (r *RayClusterReconciler) modifyStatusWorkerFields(ctx context.Context, rc *dcv1alpha1.RayCluster) (bool, error) {
-	worker, err := ray.NewStatefulSet(rc, ray.ComponentWorker, r.IstioEnabled)
+	worker, err := ray.NewStatefulSet(rc, ray.ComponentWorker, r.IstioMode, r.SyncImage)
 	if err != nil {
 		return false, err
 	}
@@ -580,6 +1066,106 @@ func (r *RayClusterReconciler) modifyStatusWorkerFields(ctx context.Context, rc
 	return modified, nil
 }
 
+// modifyStatusSync reports the file sync secret and per-worker sidecar readiness.
+func (r *RayClusterReconciler) modifyStatusSync(ctx context.Context, rc *dcv1alpha1.RayCluster) (bool, error) {
+	var secretName string
+	var pods []corev1.Pod
+
+	if ray.SyncEnabled(rc) {
+		podList := &corev1.PodList{}
+		listOpts := []client.ListOption{
+			client.InNamespace(rc.Namespace),
+			client.MatchingLabels(ray.SelectorLabelsWithComponent(rc, ray.ComponentWorker)),
+		}
+		if err := r.List(ctx, podList, listOpts...); err != nil {
+			return false, fmt.Errorf("cannot list ray worker pods: %w", err)
+		}
+
+		secretName = ray.SyncSecretName(rc.Name)
+		pods = podList.Items
+	}
+
+	if !filesync.UpdateStatus(&rc.Status, secretName, pods, ray.SyncContainerName) {
+		return false, nil
+	}
+
+	log := logr.FromContextOrDiscard(ctx)
+	log.V(1).Info("modifying status", "path", ".status.syncSecret", "value", rc.Status.SyncSecret)
+	log.V(1).Info("modifying status", "path", ".status.syncNodes", "value", rc.Status.SyncNodes)
+
+	return true, nil
+}
+
+// modifyStatusPodGroup reports the phase of the cluster PodGroup.
+func (r *RayClusterReconciler) modifyStatusPodGroup(ctx context.Context, rc *dcv1alpha1.RayCluster) (bool, error) {
+	phase, err := podgroup.FetchPhase(ctx, r, rc.Spec.GangScheduling, ray.PodGroupName(rc.Name), rc.Namespace)
+	if err != nil || phase == rc.Status.PodGroupPhase {
+		return false, err
+	}
+
+	logr.FromContextOrDiscard(ctx).V(1).Info("modifying status", "path", ".status.podGroupPhase", "value", phase)
+	rc.Status.PodGroupPhase = phase
+
+	return true, nil
+}
+
+// modifyStatusResources totals the resources of the generated stateful sets
+// and live pods, and exports them as metrics.
+func (r *RayClusterReconciler) modifyStatusResources(ctx context.Context, rc *dcv1alpha1.RayCluster, pods []corev1.Pod) (bool, error) {
+	head, err := ray.NewStatefulSet(rc, ray.ComponentHead, r.IstioMode, r.SyncImage)
+	if err != nil {
+		return false, err
+	}
+	worker, err := ray.NewStatefulSet(rc, ray.ComponentWorker, r.IstioMode, r.SyncImage)
+	if err != nil {
+		return false, err
+	}
+
+	maxWorkers := workload.WorkerCount(rc.Spec.Worker.Replicas, rc.Spec.Autoscaling)
+	resources, err := accounting.Compute(head, worker, maxWorkers, pods)
+	if err != nil {
+		return false, err
+	}
+	accounting.UpdateMetrics("RayCluster", rc.Namespace, rc.Name, resources)
+
+	if equality.Semantic.DeepEqual(resources, rc.Status.Resources) {
+		return false, nil
+	}
+
+	logr.FromContextOrDiscard(ctx).V(1).Info("modifying status", "path", ".status.resources", "value", resources)
+	rc.Status.Resources = resources
+
+	return true, nil
+}
+
+// modifyStatusUsage periodically accumulates the resources requested by the
+// cluster pods.
+func (r *RayClusterReconciler) modifyStatusUsage(ctx context.Context, rc *dcv1alpha1.RayCluster, pods []corev1.Pod) bool {
+	now := time.Now()
+	if len(pods) == 0 || !metering.Due(rc.Status.Usage, now) {
+		return false
+	}
+
+	rc.Status.Usage = metering.Accumulate(rc.Status.Usage, pods, now)
+	logr.FromContextOrDiscard(ctx).V(1).Info("modifying status", "path", ".status.usage", "value", rc.Status.Usage)
+
+	return true
+}
+
+// listPods returns all the pods in the cluster.
+func (r *RayClusterReconciler) listPods(ctx context.Context, rc *dcv1alpha1.RayCluster) ([]corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(rc.Namespace),
+		client.MatchingLabels(ray.MetadataLabels(rc)),
+	}
+	if err := r.List(ctx, podList, listOpts...); err != nil {
+		return nil, fmt.Errorf("cannot list ray pods: %w", err)
+	}
+
+	return podList.Items, nil
+}
+
 // deleteExternalStorage queries for all persistent volume claims belonging to
 // a cluster instance using selector labels. this should find all the claims
 // created by both the head and worker stateful sets.
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/namespaces"
 
 	"github.com/banzaicloud/k8s-objectmatcher/patch"
 	"github.com/go-logr/logr"
@@ -19,12 +20,14 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,8 +35,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/accounting"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/clientaccess"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashboard"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/kerberos"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/metering"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/spark"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/workload"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
 )
 
@@ -50,24 +62,35 @@ var (
 // SparkClusterReconciler reconciles SparkCluster objects.
 type SparkClusterReconciler struct {
 	client.Client
+	// APIReader reads objects that are not cached by Client.
+	APIReader    client.Reader
 	Log          logr.Logger
 	Scheme       *runtime.Scheme
+	Recorder     record.EventRecorder
 	IstioEnabled bool
+	IstioMode    istio.Mode
+	SyncImage    string
+	// UsageSink receives the final usage of deleted clusters when not nil.
+	UsageSink metering.Sink
+	// Namespaces limits reconciliation to selected namespaces when not nil.
+	Namespaces *namespaces.Selector
 }
 
 // SetupWithManager creates and registers this controller with the manager.
 func (r *SparkClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&dcv1alpha1.SparkCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.ServiceAccount{}).
+		Owns(&corev1.Secret{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
 		Owns(&networkingv1.NetworkPolicy{}).
 		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
-		Owns(&corev1.ConfigMap{}).
-		Complete(r)
+		Owns(&corev1.ConfigMap{})
+
+	return r.Namespaces.Watch(b, &dcv1alpha1.SparkClusterList{}).Complete(r)
 }
 
 const SparkFinalizerName = "distributed-compute.dominodatalab.com/dco-finalizer"
@@ -77,11 +100,18 @@ const SparkFinalizerName = "distributed-compute.dominodatalab.com/dco-finalizer"
 //+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=sparkclusters/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",resources=pods,verbs=list;watch
 //+kubebuilder:rbac:groups="",resources=services;serviceaccounts,verbs=create;update;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=create;update;delete;list;watch
 //+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=create;update;list;watch
 //+kubebuilder:rbac:groups=apps,resources=configmaps,verbs=create;update;list;watch
 //+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=create;update;delete;list;watch
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=create;update;delete;list;watch
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=create;update;delete;list;watch
+//+kubebuilder:rbac:groups=scheduling.x-k8s.io,resources=podgroups,verbs=get;create;update;delete
+//+kubebuilder:rbac:groups=scheduling.volcano.sh,resources=podgroups,verbs=get;create;update;delete
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;create;update;delete
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;create;update;delete
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;create;update;delete
 
 // Reconcile implements state reconciliation logic for SparkCluster objects.
 func (r *SparkClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -101,6 +131,19 @@ func (r *SparkClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
+	// clusters outside of selected namespaces are only finalized
+	if rc.DeletionTimestamp.IsZero() {
+		selected, err := r.Namespaces.Selected(ctx, rc.Namespace)
+		if err != nil {
+			log.Error(err, "failed to retrieve namespace")
+			return ctrl.Result{}, err
+		}
+		if !selected {
+			log.V(1).Info("skipping reconciliation outside of selected namespaces")
+			return ctrl.Result{}, nil
+		}
+	}
+
 	updated, err := r.processFinalizers(ctx, rc, log)
 	if err != nil {
 		log.Error(err, "failed to process finalizers")
@@ -124,6 +167,15 @@ func (r *SparkClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
+	// pod groups and workloads are not watched, so they are polled until settled
+	if rc.Status.Queued || (podgroup.Enabled(rc.Spec.GangScheduling) && !podgroup.Settled(rc.Status.PodGroupPhase)) {
+		return ctrl.Result{RequeueAfter: pollPeriod}, nil
+	}
+	// usage is accumulated periodically while pods exist
+	if len(rc.Status.Nodes) > 0 {
+		return ctrl.Result{RequeueAfter: metering.PersistPeriod}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -142,8 +194,18 @@ func (r *SparkClusterReconciler) processFinalizers(ctx context.Context, sc *dcv1
 		// if it has finalizer and has a deletion timestamp then we want to delete some stuff
 	}
 	if containsFinalizer && hasDeletionTimestamp {
+		podList := &corev1.PodList{}
+		listOpts := []client.ListOption{
+			client.InNamespace(sc.Namespace),
+			client.MatchingLabels(spark.MetadataLabels(sc)),
+		}
+		if err := r.List(ctx, podList, listOpts...); err != nil {
+			return false, fmt.Errorf("cannot list spark pods: %w", err)
+		}
+
 		sc.Status.ClusterStatus = dcv1alpha1.StoppingStatus
 		sc.Status.StartTime = nil
+		sc.Status.Usage = metering.Accumulate(sc.Status.Usage, podList.Items, time.Now())
 		err := r.Status().Update(ctx, sc)
 		if err != nil {
 			return false, err
@@ -160,6 +222,11 @@ func (r *SparkClusterReconciler) processFinalizers(ctx context.Context, sc *dcv1
 			return false, err
 		}
 
+		accounting.DeleteMetrics("SparkCluster", sc.Namespace, sc.Name)
+		if err = metering.Report(ctx, r.Recorder, r.UsageSink, "SparkCluster", sc, sc.Status.Usage); err != nil {
+			log.Error(err, "failed to send final usage record")
+		}
+
 		controllerutil.RemoveFinalizer(sc, SparkFinalizerName)
 		err = r.Update(ctx, sc)
 		if err != nil {
@@ -227,6 +294,15 @@ func (r *SparkClusterReconciler) reconcileResources(ctx context.Context, sc *dcv
 	if err := r.reconcileServiceAccount(ctx, sc); err != nil {
 		return err
 	}
+	if err := r.reconcileSyncSecret(ctx, sc); err != nil {
+		return err
+	}
+	if err := r.reconcileAuthSecret(ctx, sc); err != nil {
+		return err
+	}
+	if err := r.reconcileKeytabSecret(ctx, sc); err != nil {
+		return err
+	}
 	if err := r.reconcileHeadService(ctx, sc); err != nil {
 		return err
 	}
@@ -239,12 +315,27 @@ func (r *SparkClusterReconciler) reconcileResources(ctx context.Context, sc *dcv
 	if err := r.reconcileNetworkPolicies(ctx, sc); err != nil {
 		return err
 	}
+	if err := r.reconcileEgressNetworkPolicy(ctx, sc); err != nil {
+		return err
+	}
 	if err := r.reconcilePodSecurityPolicyRBAC(ctx, sc); err != nil {
 		return err
 	}
 	if err := r.reconcileAutoscaler(ctx, sc); err != nil {
 		return err
 	}
+	if err := r.reconcilePodGroup(ctx, sc); err != nil {
+		return err
+	}
+	if err := r.reconcilePodMonitor(ctx, sc); err != nil {
+		return err
+	}
+	if err := r.reconcileDashboard(ctx, sc); err != nil {
+		return err
+	}
+	if err := r.reconcileWorkload(ctx, sc); err != nil {
+		return err
+	}
 	if err := r.reconcileConfigMap(ctx, sc); err != nil {
 		return err
 	}
@@ -262,10 +353,23 @@ func (r *SparkClusterReconciler) reconcileIstio(ctx context.Context, sc *dcv1alp
 
 	envoyFilter := spark.NewEnvoyFilter(sc)
 
-	if err := r.createOrUpdateOwnedResource(ctx, sc, envoyFilter); err != nil {
+	// pods do not run an envoy sidecar in ambient mode
+	if r.IstioMode.Ambient() {
+		if err := r.deleteIfExists(ctx, envoyFilter); err != nil {
+			return err
+		}
+	} else if err := r.createOrUpdateOwnedResource(ctx, sc, envoyFilter); err != nil {
 		return fmt.Errorf("failed to reconcile envoy filter: %w", err)
 	}
 
+	if err := r.reconcileAuthorizationPolicy(ctx, sc); err != nil {
+		return err
+	}
+
+	if err := r.reconcileIstioTraffic(ctx, sc); err != nil {
+		return err
+	}
+
 	peerAuth := istio.NewPeerAuthentication(&istio.PeerAuthInfo{
 		Name:      spark.InstanceObjectName(sc.Name, spark.ComponentNone),
 		Namespace: sc.Namespace,
@@ -285,6 +389,66 @@ func (r *SparkClusterReconciler) reconcileIstio(ctx context.Context, sc *dcv1alp
 	return nil
 }
 
+// reconcileAuthorizationPolicy restricts the workloads allowed to reach
+// cluster pods, and the clients of the cluster services when traffic is
+// forwarded by a waypoint.
+func (r *SparkClusterReconciler) reconcileAuthorizationPolicy(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
+	info := spark.AuthorizationPolicyInfo(sc, r.IstioMode)
+	authzPolicy := istio.NewAuthorizationPolicy(info)
+	waypointRef := istio.WaypointAuthorizationPolicyReference(info.Name, info.Namespace)
+
+	if !istio.AuthorizationEnabled(sc.Spec.IstioConfig) {
+		return r.deleteIfExists(ctx, authzPolicy, waypointRef)
+	}
+
+	if !istio.WaypointPolicyEnabled(info) {
+		if err := r.deleteIfExists(ctx, waypointRef); err != nil {
+			return err
+		}
+	} else {
+		waypointPolicy, err := istio.NewWaypointAuthorizationPolicy(info)
+		if err != nil {
+			return err
+		}
+		if err = r.createOrUpdateOwnedResource(ctx, sc, waypointPolicy); err != nil {
+			return fmt.Errorf("failed to reconcile waypoint authorization policy: %w", err)
+		}
+	}
+
+	if err := r.createOrUpdateOwnedResource(ctx, sc, authzPolicy); err != nil {
+		return fmt.Errorf("failed to reconcile authorization policy: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileIstioTraffic limits the mesh configuration of cluster pods with a
+// Sidecar and requires Istio mutual TLS to the cluster services.
+func (r *SparkClusterReconciler) reconcileIstioTraffic(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
+	info := spark.TrafficInfo(sc, r.IstioMode)
+
+	sidecar := istio.NewSidecar(info)
+	if !istio.SidecarEnabled(sc.Spec.IstioConfig, info.Mode) {
+		if err := r.deleteIfExists(ctx, sidecar); err != nil {
+			return err
+		}
+	} else if err := r.createOrUpdateOwnedResource(ctx, sc, sidecar); err != nil {
+		return fmt.Errorf("failed to reconcile sidecar: %w", err)
+	}
+
+	for _, rule := range istio.NewDestinationRules(info) {
+		if !istio.DestinationRuleEnabled(sc.Spec.IstioConfig) {
+			if err := r.deleteIfExists(ctx, rule); err != nil {
+				return err
+			}
+		} else if err := r.createOrUpdateOwnedResource(ctx, sc, rule); err != nil {
+			return fmt.Errorf("failed to reconcile destination rule: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (r *SparkClusterReconciler) reconcileConfigMap(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
 	frameworkCM := spark.NewFrameworkConfigMap(sc)
 
@@ -294,12 +458,14 @@ func (r *SparkClusterReconciler) reconcileConfigMap(ctx context.Context, sc *dcv
 		}
 	}
 
-	keytabCM := spark.NewKeyTabConfigMap(sc)
+	kerberosCM := kerberos.NewConfigMap(spark.KerberosInfo(sc))
 
-	if keytabCM != nil {
-		if err := r.createOrUpdateOwnedResource(ctx, sc, keytabCM); err != nil {
-			return fmt.Errorf("failed to reconcile keytab configmap: %w", err)
+	if kerberos.ConfigMapEnabled(sc.Spec.Kerberos) {
+		if err := r.createOrUpdateOwnedResource(ctx, sc, kerberosCM); err != nil {
+			return fmt.Errorf("failed to reconcile kerberos configmap: %w", err)
 		}
+	} else if err := r.deleteIfExists(ctx, kerberosCM); err != nil {
+		return fmt.Errorf("failed to delete kerberos configmap: %w", err)
 	}
 
 	return nil
@@ -338,6 +504,84 @@ func (r *SparkClusterReconciler) reconcileServiceAccount(ctx context.Context, sc
 	return nil
 }
 
+// reconcileSyncSecret creates a secret holding the credentials for the worker
+// file sync sidecar when sync is enabled. The generated password is preserved
+// across updates and the secret is deleted once sync is disabled.
+func (r *SparkClusterReconciler) reconcileSyncSecret(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
+	current := &corev1.Secret{}
+	key := types.NamespacedName{Name: spark.SyncSecretName(sc.Name), Namespace: sc.Namespace}
+
+	if !spark.SyncEnabled(sc) {
+		current.Name, current.Namespace = key.Name, key.Namespace
+		return r.deleteIfExists(ctx, current)
+	}
+
+	password, err := filesync.FetchPassword(ctx, r, key)
+	if err != nil {
+		return err
+	}
+
+	if err = r.createOrUpdateOwnedResource(ctx, sc, spark.NewSyncSecret(sc, password)); err != nil {
+		return fmt.Errorf("failed to reconcile sync secret: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileAuthSecret creates a secret holding the shared secret used to
+// authenticate spark RPCs when authentication is enabled. The generated
+// secret is preserved across updates and the secret is deleted once
+// authentication is disabled.
+func (r *SparkClusterReconciler) reconcileAuthSecret(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
+	current := &corev1.Secret{}
+	key := types.NamespacedName{Name: spark.AuthSecretName(sc.Name), Namespace: sc.Namespace}
+
+	if !spark.AuthenticationEnabled(sc) {
+		current.Name, current.Namespace = key.Name, key.Namespace
+		return r.deleteIfExists(ctx, current)
+	}
+
+	if err := r.Get(ctx, key, current); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("cannot fetch auth secret: %w", err)
+	}
+	secret, err := spark.AuthSecret(current)
+	if err != nil {
+		return err
+	}
+
+	if err = r.createOrUpdateOwnedResource(ctx, sc, spark.NewAuthSecret(sc, secret)); err != nil {
+		return fmt.Errorf("failed to reconcile auth secret: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileKeytabSecret copies a keytab embedded in the cluster spec into a
+// secret and deletes it once the keytab is removed or moved to a user secret. A
+// warning event is recorded when the secret is created.
+func (r *SparkClusterReconciler) reconcileKeytabSecret(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
+	info := spark.KeytabInfo(sc)
+	if err := r.deleteIfExists(ctx, kerberos.KeytabConfigMapReference(info)); err != nil {
+		return fmt.Errorf("failed to delete keytab configmap: %w", err)
+	}
+
+	secret := kerberos.NewKeytabSecret(info)
+	if !kerberos.InlineKeytab(sc.Spec.KerberosKeytab) {
+		return r.deleteIfExists(ctx, secret)
+	}
+	err := r.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{})
+	if apierrors.IsNotFound(err) {
+		kerberos.RecordInlineKeytab(r.Recorder, sc)
+	} else if err != nil {
+		return fmt.Errorf("cannot fetch keytab secret: %w", err)
+	}
+	if err = r.createOrUpdateOwnedResource(ctx, sc, secret); err != nil {
+		return fmt.Errorf("failed to reconcile keytab secret: %w", err)
+	}
+
+	return nil
+}
+
 // reconcileHeadService creates a service that points to the head Spark pod and
 // applies updates when the parent CR changes.
 func (r *SparkClusterReconciler) reconcileHeadService(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
@@ -394,6 +638,25 @@ func (r SparkClusterReconciler) reconcileNetworkPolicies(ctx context.Context, sc
 	return nil
 }
 
+// reconcileEgressNetworkPolicy restricts the egress traffic of cluster pods
+// when requested and deletes the policy otherwise.
+func (r *SparkClusterReconciler) reconcileEgressNetworkPolicy(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
+	rules, err := components.ControlPlaneEgressRules(ctx, r.APIReader, sc.Spec.NetworkPolicy, r.IstioEnabled)
+	if err != nil {
+		return err
+	}
+	netpol := components.NewEgressNetworkPolicy(sc, sc.Spec.NetworkPolicy, rules, spark.Meta)
+
+	if !components.EgressEnabled(sc.Spec.NetworkPolicy) {
+		return r.deleteIfExists(ctx, netpol)
+	}
+	if err := r.createOrUpdateOwnedResource(ctx, sc, netpol); err != nil {
+		return fmt.Errorf("failed to reconcile egress network policy: %w", err)
+	}
+
+	return nil
+}
+
 // nolint:dupl
 // reconcilePodSecurityPolicyRBAC optionally creates a role and role binding
 // that allows the Spark pods to "use" the specified pod security policy.
@@ -404,7 +667,16 @@ func (r *SparkClusterReconciler) reconcilePodSecurityPolicyRBAC(ctx context.Cont
 		return r.deleteIfExists(ctx, role, binding)
 	}
 
-	err := r.Get(ctx, types.NamespacedName{Name: sc.Spec.PodSecurityPolicy}, &policyv1beta1.PodSecurityPolicy{})
+	available, err := util.PodSecurityPolicyAvailable(r.RESTMapper())
+	if err != nil {
+		return err
+	}
+	if !available {
+		logr.FromContextOrDiscard(ctx).V(1).Info("skipping pod security policy rbac, api is not available")
+		return nil
+	}
+
+	err = r.Get(ctx, types.NamespacedName{Name: sc.Spec.PodSecurityPolicy}, &policyv1beta1.PodSecurityPolicy{})
 	if err != nil {
 		return fmt.Errorf("cannot verify pod security policy: %w", err)
 	}
@@ -440,6 +712,131 @@ func (r *SparkClusterReconciler) reconcileAutoscaler(ctx context.Context, sc *dc
 	return nil
 }
 
+// reconcilePodGroup optionally creates a PodGroup that gang schedules the
+// spark pods and removes the PodGroups of any other scheduler.
+func (r *SparkClusterReconciler) reconcilePodGroup(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
+	info := spark.PodGroupInfo(sc)
+
+	for _, pg := range podgroup.Stale(info.Config, info.Name, info.Namespace) {
+		if err := util.IgnoreMissing(r.deleteIfExists(ctx, pg)); err != nil {
+			return fmt.Errorf("failed to delete pod group: %w", err)
+		}
+	}
+
+	if !podgroup.Enabled(info.Config) {
+		return nil
+	}
+	if err := r.createOrUpdateOwnedResource(ctx, sc, podgroup.New(info)); err != nil {
+		return fmt.Errorf("failed to reconcile pod group: %w", err)
+	}
+
+	return nil
+}
+
+// reconcilePodMonitor optionally creates a PodMonitor that scrapes the
+// metrics of the spark master and workers and removes it when monitoring is
+// disabled.
+func (r *SparkClusterReconciler) reconcilePodMonitor(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
+	info := spark.PodMonitorInfo(sc)
+
+	if !monitoring.Enabled(info.Config) {
+		pm := monitoring.Reference(info.Name, info.Namespace)
+		if err := util.IgnoreMissing(r.deleteIfExists(ctx, pm)); err != nil {
+			return fmt.Errorf("failed to delete pod monitor: %w", err)
+		}
+		return nil
+	}
+	if err := r.createOrUpdateOwnedResource(ctx, sc, monitoring.New(info)); err != nil {
+		return fmt.Errorf("failed to reconcile pod monitor: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileDashboard optionally exposes the spark master dashboard with an
+// Ingress or HTTPRoute and removes the routing objects that are not used.
+func (r *SparkClusterReconciler) reconcileDashboard(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
+	info := spark.DashboardInfo(sc)
+
+	for _, obj := range dashboard.Stale(info) {
+		if err := util.IgnoreMissing(r.deleteIfExists(ctx, obj)); err != nil {
+			return fmt.Errorf("failed to delete dashboard route: %w", err)
+		}
+	}
+
+	if !dashboard.Enabled(info.Config) {
+		return nil
+	}
+	obj, err := dashboard.New(info)
+	if err != nil {
+		return fmt.Errorf("failed to build dashboard route: %w", err)
+	}
+	if err = r.createOrUpdateOwnedResource(ctx, sc, obj); err != nil {
+		return fmt.Errorf("failed to reconcile dashboard route: %w", err)
+	}
+
+	return nil
+}
+
+// modifyStatusDashboard publishes the external URL of the dashboard.
+func (r *SparkClusterReconciler) modifyStatusDashboard(ctx context.Context, sc *dcv1alpha1.SparkCluster) (bool, error) {
+	url, err := dashboard.URL(spark.DashboardInfo(sc))
+	if err != nil || url == sc.Status.DashboardURL {
+		return false, err
+	}
+
+	logr.FromContextOrDiscard(ctx).V(1).Info("modifying status", "path", ".status.dashboardURL", "value", url)
+	sc.Status.DashboardURL = url
+
+	return true, nil
+}
+
+// reconcileWorkload queues the cluster with an admission controller when it
+// requests a queue. The cluster remains suspended until its workload is admitted.
+func (r *SparkClusterReconciler) reconcileWorkload(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
+	info, err := spark.WorkloadInfo(sc, r.IstioMode, r.SyncImage)
+	if err != nil {
+		return err
+	}
+
+	if info.QueueName == "" {
+		sc.Status.Queued = false
+		if err = util.IgnoreMissing(r.deleteIfExists(ctx, workload.Reference(info.Name, info.Namespace))); err != nil {
+			return fmt.Errorf("failed to delete workload: %w", err)
+		}
+		return nil
+	}
+
+	wl, err := workload.New(info)
+	if err != nil {
+		return err
+	}
+
+	err = r.createOrUpdateOwnedResource(ctx, sc, wl)
+	if apierrors.IsInvalid(err) {
+		// the pod sets of admitted workloads are immutable, so the cluster is
+		// suspended and queued again with a new workload.
+		logr.FromContextOrDiscard(ctx).Info("recreating workload after pod set changes")
+		sc.Status.Queued = true
+
+		if err = r.deleteIfExists(ctx, wl); err != nil {
+			return fmt.Errorf("failed to delete workload: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reconcile workload: %w", err)
+	}
+
+	admitted, err := workload.FetchAdmitted(ctx, r, info.Name, info.Namespace)
+	if err != nil {
+		return err
+	}
+	sc.Status.Queued = !admitted
+
+	return nil
+}
+
 func (r *SparkClusterReconciler) initializeStatus(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
 	if sc.Status.ClusterStatus == "" {
 		sc.Status.ClusterStatus = dcv1alpha1.PendingStatus
@@ -452,7 +849,7 @@ func (r *SparkClusterReconciler) initializeStatus(ctx context.Context, sc *dcv1a
 // reconcileStatefulSets creates separate Spark head and worker statefulsets that
 // will collectively comprise the execution agents of the cluster.
 func (r *SparkClusterReconciler) reconcileStatefulSets(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
-	head, err := spark.NewStatefulSet(sc, spark.ComponentMaster)
+	head, err := spark.NewStatefulSet(sc, spark.ComponentMaster, r.IstioMode, r.SyncImage)
 	if err != nil {
 		return err
 	}
@@ -460,7 +857,7 @@ func (r *SparkClusterReconciler) reconcileStatefulSets(ctx context.Context, sc *
 		return fmt.Errorf("failed to create head deployment: %w", err)
 	}
 
-	worker, err := spark.NewStatefulSet(sc, spark.ComponentWorker)
+	worker, err := spark.NewStatefulSet(sc, spark.ComponentWorker, r.IstioMode, r.SyncImage)
 	if err != nil {
 		return err
 	}
@@ -492,14 +889,15 @@ func (r *SparkClusterReconciler) reconcileStatefulSets(ctx context.Context, sc *
 
 	var status dcv1alpha1.ClusterStatusType
 	masterPod, masterPodFound := r.getMasterPod(ctx, sc)
-	if masterPodFound {
-		if dcv1alpha1.IsPodReady(masterPod) {
-			status = dcv1alpha1.RunningStatus
-		} else {
-			status = dcv1alpha1.StartingStatus
-		}
-	} else {
+	switch {
+	case sc.Status.Queued:
+		status = dcv1alpha1.QueuedStatus
+	case !masterPodFound:
 		status = dcv1alpha1.PendingStatus
+	case dcv1alpha1.IsPodReady(masterPod):
+		status = dcv1alpha1.RunningStatus
+	default:
+		status = dcv1alpha1.StartingStatus
 	}
 	if sc.Status.ClusterStatus != status && !hasDeletionTimestamp(sc) {
 		updateStatus = true
@@ -634,14 +1032,149 @@ func (r *SparkClusterReconciler) updateStatus(ctx context.Context, sc *dcv1alpha
 	}
 	sort.Strings(podNames)
 
-	if reflect.DeepEqual(podNames, sc.Status.Nodes) {
+	modified := r.modifyStatusSync(ctx, sc, podList.Items)
+	modified = r.modifyStatusAuthSecret(ctx, sc) || modified
+	modified = r.modifyStatusClientPeers(ctx, sc) || modified
+
+	mPodGroup, err := r.modifyStatusPodGroup(ctx, sc)
+	if err != nil {
+		return fmt.Errorf("cannot modify spark status pod group phase: %w", err)
+	}
+	modified = modified || mPodGroup
+
+	mDashboard, err := r.modifyStatusDashboard(ctx, sc)
+	if err != nil {
+		return fmt.Errorf("cannot modify spark status dashboard url: %w", err)
+	}
+	modified = modified || mDashboard
+
+	mResources, err := r.modifyStatusResources(ctx, sc, podList.Items)
+	if err != nil {
+		return fmt.Errorf("cannot modify spark status resources: %w", err)
+	}
+	modified = modified || mResources
+	modified = r.modifyStatusUsage(ctx, sc, podList.Items) || modified
+
+	if !reflect.DeepEqual(podNames, sc.Status.Nodes) {
+		sc.Status.Nodes = podNames
+		modified = true
+	}
+	if !modified {
 		return nil
 	}
-	sc.Status.Nodes = podNames
 
 	if err := r.Status().Update(ctx, sc); err != nil {
-		return fmt.Errorf("cannot update spark status nodes: %w", err)
+		return fmt.Errorf("cannot update spark status: %w", err)
 	}
 
 	return nil
 }
+
+// modifyStatusSync reports the file sync secret and per-worker sidecar readiness.
+func (r *SparkClusterReconciler) modifyStatusSync(ctx context.Context, sc *dcv1alpha1.SparkCluster, pods []corev1.Pod) bool {
+	var secretName string
+	if spark.SyncEnabled(sc) {
+		secretName = spark.SyncSecretName(sc.Name)
+	}
+
+	if !filesync.UpdateStatus(&sc.Status, secretName, pods, spark.SyncContainerName) {
+		return false
+	}
+
+	log := logr.FromContextOrDiscard(ctx)
+	log.V(1).Info("modifying status", "path", ".status.syncSecret", "value", sc.Status.SyncSecret)
+	log.V(1).Info("modifying status", "path", ".status.syncNodes", "value", sc.Status.SyncNodes)
+
+	return true
+}
+
+// modifyStatusAuthSecret reports the secret that drivers use to authenticate.
+func (r *SparkClusterReconciler) modifyStatusAuthSecret(ctx context.Context, sc *dcv1alpha1.SparkCluster) bool {
+	var authSecret *corev1.LocalObjectReference
+	if spark.AuthenticationEnabled(sc) {
+		authSecret = &corev1.LocalObjectReference{Name: spark.AuthSecretName(sc.Name)}
+	}
+
+	if reflect.DeepEqual(authSecret, sc.Status.AuthSecret) {
+		return false
+	}
+	sc.Status.AuthSecret = authSecret
+
+	log := logr.FromContextOrDiscard(ctx)
+	log.V(1).Info("modifying status", "path", ".status.authSecret", "value", sc.Status.AuthSecret)
+
+	return true
+}
+
+// modifyStatusClientPeers publishes the peers granted ingress access to the
+// cluster by network policies.
+func (r *SparkClusterReconciler) modifyStatusClientPeers(ctx context.Context, sc *dcv1alpha1.SparkCluster) bool {
+	np := sc.Spec.NetworkPolicy
+	clientPeers := clientaccess.StatusPeers(np, clientaccess.Peers(np))
+
+	if equality.Semantic.DeepEqual(clientPeers, sc.Status.ClientPeers) {
+		return false
+	}
+	sc.Status.ClientPeers = clientPeers
+
+	log := logr.FromContextOrDiscard(ctx)
+	log.V(1).Info("modifying status", "path", ".status.clientPeers", "value", sc.Status.ClientPeers)
+
+	return true
+}
+
+// modifyStatusResources totals the resources of the generated stateful sets
+// and live pods, and exports them as metrics.
+func (r *SparkClusterReconciler) modifyStatusResources(ctx context.Context, sc *dcv1alpha1.SparkCluster, pods []corev1.Pod) (bool, error) {
+	head, err := spark.NewStatefulSet(sc, spark.ComponentMaster, r.IstioMode, r.SyncImage)
+	if err != nil {
+		return false, err
+	}
+	worker, err := spark.NewStatefulSet(sc, spark.ComponentWorker, r.IstioMode, r.SyncImage)
+	if err != nil {
+		return false, err
+	}
+
+	maxWorkers := workload.WorkerCount(sc.Spec.Worker.Replicas, sc.Spec.Autoscaling)
+	resources, err := accounting.Compute(head, worker, maxWorkers, pods)
+	if err != nil {
+		return false, err
+	}
+	accounting.UpdateMetrics("SparkCluster", sc.Namespace, sc.Name, resources)
+
+	if equality.Semantic.DeepEqual(resources, sc.Status.Resources) {
+		return false, nil
+	}
+
+	logr.FromContextOrDiscard(ctx).V(1).Info("modifying status", "path", ".status.resources", "value", resources)
+	sc.Status.Resources = resources
+
+	return true, nil
+}
+
+// modifyStatusUsage periodically accumulates the resources requested by the
+// cluster pods.
+func (r *SparkClusterReconciler) modifyStatusUsage(ctx context.Context, sc *dcv1alpha1.SparkCluster, pods []corev1.Pod) bool {
+	now := time.Now()
+	if len(pods) == 0 || !metering.Due(sc.Status.Usage, now) {
+		return false
+	}
+
+	sc.Status.Usage = metering.Accumulate(sc.Status.Usage, pods, now)
+	logr.FromContextOrDiscard(ctx).V(1).Info("modifying status", "path", ".status.usage", "value", sc.Status.Usage)
+
+	return true
+}
+
+// modifyStatusPodGroup reports the phase of the cluster PodGroup.
+func (r *SparkClusterReconciler) modifyStatusPodGroup(ctx context.Context, sc *dcv1alpha1.SparkCluster) (bool, error) {
+	phase, err := podgroup.FetchPhase(ctx, r, sc.Spec.GangScheduling, spark.PodGroupName(sc.Name), sc.Namespace)
+	if err != nil || phase == sc.Status.PodGroupPhase {
+		return false, err
+	}
+
+	logr.FromContextOrDiscard(ctx).V(1).Info("modifying status", "path", ".status.podGroupPhase", "value", phase)
+	sc.Status.PodGroupPhase = phase
+
+	return true, nil
+}
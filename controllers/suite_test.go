@@ -70,6 +70,7 @@ var _ = BeforeSuite(func() {
 
 	err = (&RayClusterReconciler{
 		Client:       k8sClient,
+		APIReader:    k8sManager.GetAPIReader(),
 		Scheme:       k8sManager.GetScheme(),
 		Log:          ctrl.Log.WithName("controllers").WithName("RayCluster"),
 		IstioEnabled: false,
@@ -78,6 +79,7 @@ var _ = BeforeSuite(func() {
 
 	err = (&SparkClusterReconciler{
 		Client:       k8sClient,
+		APIReader:    k8sManager.GetAPIReader(),
 		Scheme:       k8sManager.GetScheme(),
 		Log:          ctrl.Log.WithName("controllers").WithName("SparkCluster"),
 		IstioEnabled: false,
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"path"
+	"time"
 
 	"github.com/banzaicloud/k8s-objectmatcher/patch"
 
@@ -24,3 +25,7 @@ var (
 		patch.IgnoreVolumeClaimTemplateTypeMetaAndStatus(),
 	}
 )
+
+// pollPeriod is the delay between checks of unsettled PodGroups and queued
+// Workloads, whose changes are not watched.
+const pollPeriod = 10 * time.Second
@@ -7,6 +7,22 @@ Return the proper image name
 {{- include "common.images.image" (dict "imageRoot" $imageRoot "global" $) -}}
 {{- end -}}
 
+{{/*
+Return the name of an MPI helper image, pinned to a digest when one is provided
+*/}}
+{{- define "dco.mpi.image" -}}
+{{- if .imageRoot.digest -}}
+{{- $registry := .imageRoot.registry | default "" -}}
+{{- if $registry -}}
+{{- printf "%s/%s@%s" $registry .imageRoot.repository .imageRoot.digest -}}
+{{- else -}}
+{{- printf "%s@%s" .imageRoot.repository .imageRoot.digest -}}
+{{- end -}}
+{{- else -}}
+{{- include "common.images.image" (dict "imageRoot" .imageRoot "global" .global) -}}
+{{- end -}}
+{{- end -}}
+
 {{/*
 Create the name of the service account to use
 */}}
@@ -3,9 +3,42 @@
 set -o nounset
 set -o errexit
 
+RUNTIME_CONFIG_FILE="$RSYNC_RUN_DIR/$RSYNC_CONFIG_FILE"
+SECRETS_FILE="$RSYNC_RUN_DIR/rsyncd.secrets"
+
+# rsync refuses secrets files readable by other users, so the mounted
+# credentials are copied with restricted permissions.
+umask 077
+
+RSYNC_USER=$(cat "$RSYNC_AUTH_DIR/username")
+printf '%s:%s\n' "$RSYNC_USER" "$(cat "$RSYNC_AUTH_DIR/password")" > "$SECRETS_FILE"
+
+cp "$DOMINO_ETC/$RSYNC_CONFIG_FILE" "$RUNTIME_CONFIG_FILE"
+cat << EOF >> "$RUNTIME_CONFIG_FILE"
+auth users = $RSYNC_USER
+secrets file = $SECRETS_FILE
+EOF
+
+# Each module is described as "name:path:access", where access is "ro" or "rw".
+for module in $RSYNC_MODULES; do
+	IFS=: read -r name path access <<< "$module"
+
+	read_only="false"
+	if [ "$access" = "ro" ]; then
+		read_only="true"
+	fi
+
+	cat << EOF >> "$RUNTIME_CONFIG_FILE"
+
+[$name]
+path = $path
+read only = $read_only
+EOF
+done
+
 /usr/bin/rsync \
 	--daemon \
 	--no-detach \
 	--verbose \
-       	--config="$DOMINO_ETC/$RSYNC_CONFIG_FILE" \
+	--config="$RUNTIME_CONFIG_FILE" \
 	--port=$RSYNC_PORT
@@ -26,7 +26,11 @@ require (
 	sigs.k8s.io/yaml v1.3.0
 )
 
-require google.golang.org/protobuf v1.30.0
+require (
+	github.com/distribution/reference v0.5.0
+	github.com/prometheus/client_golang v1.14.0
+	google.golang.org/protobuf v1.30.0
+)
 
 require (
 	emperror.dev/errors v0.8.1 // indirect
@@ -37,7 +41,6 @@ require (
 	github.com/coreos/go-semver v0.3.1 // indirect
 	github.com/coreos/go-systemd/v22 v22.5.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/distribution/reference v0.5.0 // indirect
 	github.com/emicklei/go-restful/v3 v3.10.2 // indirect
 	github.com/evanphx/json-patch v5.6.0+incompatible // indirect
 	github.com/evanphx/json-patch/v5 v5.6.0 // indirect
@@ -69,7 +72,6 @@ require (
 	github.com/nxadm/tail v1.4.8 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/prometheus/client_golang v1.14.0 // indirect
 	github.com/prometheus/client_model v0.3.0 // indirect
 	github.com/prometheus/common v0.42.0 // indirect
 	github.com/prometheus/procfs v0.9.0 // indirect
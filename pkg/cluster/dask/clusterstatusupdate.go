@@ -2,18 +2,24 @@ package dask
 
 import (
 	appsv1 "k8s.io/api/apps/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/clientaccess"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashboard"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/metering"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/workload"
 )
 
-func ClusterStatusUpdate() core.Component {
+func ClusterStatusUpdate(sink metering.Sink) core.Component {
 	return components.ClusterStatusUpdate(func(obj client.Object) components.ClusterStatusUpdateDataSource {
 		return &clusterStatusUpdateDS{dc: daskCluster(obj)}
-	})
+	}, sink)
 }
 
 type clusterStatusUpdateDS struct {
@@ -36,6 +42,19 @@ func (c *clusterStatusUpdateDS) StatefulSet() *appsv1.StatefulSet {
 	}
 }
 
+func (c *clusterStatusUpdateDS) HeadStatefulSet() *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      meta.InstanceName(c.dc, ComponentScheduler),
+			Namespace: c.dc.Namespace,
+		},
+	}
+}
+
+func (c *clusterStatusUpdateDS) MaxWorkers() int32 {
+	return workload.WorkerCount(c.dc.Spec.Worker.Replicas, c.dc.Spec.Autoscaling)
+}
+
 func (c *clusterStatusUpdateDS) ClusterStatusConfig() *dcv1alpha1.ClusterStatusConfig {
 	return &c.dc.Status.ClusterStatusConfig
 }
@@ -43,3 +62,34 @@ func (c *clusterStatusUpdateDS) ClusterStatusConfig() *dcv1alpha1.ClusterStatusC
 func (c *clusterStatusUpdateDS) Image() *dcv1alpha1.OCIImageDefinition {
 	return c.dc.Spec.Image
 }
+
+func (c *clusterStatusUpdateDS) SyncContainerName() string {
+	if !syncEnabled(c.dc) {
+		return ""
+	}
+	return ApplicationName + filesync.ContainerSuffix
+}
+
+func (c *clusterStatusUpdateDS) SyncSecretName() string {
+	if !syncEnabled(c.dc) {
+		return ""
+	}
+	return syncSecretName(c.dc)
+}
+
+func (c *clusterStatusUpdateDS) GangScheduling() *dcv1alpha1.GangSchedulingConfig {
+	return c.dc.Spec.GangScheduling
+}
+
+func (c *clusterStatusUpdateDS) PodGroupName() string {
+	return podGroupName(c.dc)
+}
+
+func (c *clusterStatusUpdateDS) DashboardURL() (string, error) {
+	return dashboard.URL(dashboardInfo(c.dc))
+}
+
+func (c *clusterStatusUpdateDS) ClientPeers() []networkingv1.NetworkPolicyPeer {
+	np := c.dc.Spec.NetworkPolicy
+	return clientaccess.StatusPeers(np, clientaccess.Peers(np))
+}
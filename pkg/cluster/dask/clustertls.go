@@ -0,0 +1,62 @@
+package dask
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/metadata"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/clustertls"
+)
+
+// tlsMountPath is where the node certificate is mounted in scheduler and
+// worker containers.
+const tlsMountPath = "/etc/dask/tls"
+
+func ClusterTLS() core.Component {
+	return components.ClusterTLS(func(obj client.Object) components.ClusterTLSDataSource {
+		return &clusterTLSDS{dc: daskCluster(obj)}
+	})
+}
+
+type clusterTLSDS struct {
+	dc *dcv1alpha1.DaskCluster
+}
+
+func (s *clusterTLSDS) ClusterTLSInfo() *clustertls.Info {
+	dc := s.dc
+	scheduler := meta.InstanceName(dc, ComponentScheduler)
+	worker := meta.InstanceName(dc, ComponentWorker)
+
+	return &clustertls.Info{
+		Namespace:        dc.Namespace,
+		Labels:           meta.StandardLabels(dc),
+		CASecretName:     tlsCASecretName(dc),
+		SecretName:       tlsSecretName(dc),
+		ClientSecretName: tlsClientSecretName(dc),
+		CommonName:       meta.InstanceName(dc, metadata.ComponentNone),
+		DNSNames: []string{
+			scheduler,
+			fmt.Sprintf("%s.%s", scheduler, dc.Namespace),
+			fmt.Sprintf("%s.%s.svc", scheduler, dc.Namespace),
+			fmt.Sprintf("*.%s.%s.svc", scheduler, dc.Namespace),
+			fmt.Sprintf("*.%s.%s.svc", worker, dc.Namespace),
+		},
+		ClientCommonName: tlsClientSecretName(dc),
+		Config:           dc.Spec.TLS,
+	}
+}
+
+// tlsArgs point dask to the mounted node certificate.
+func tlsArgs() []string {
+	return []string{
+		"--protocol=tls",
+		fmt.Sprintf("--tls-ca-file=%s/%s", tlsMountPath, clustertls.CAKey),
+		fmt.Sprintf("--tls-cert=%s/%s", tlsMountPath, corev1.TLSCertKey),
+		fmt.Sprintf("--tls-key=%s/%s", tlsMountPath, corev1.TLSPrivateKeyKey),
+	}
+}
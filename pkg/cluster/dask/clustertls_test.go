@@ -0,0 +1,39 @@
+package dask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestClusterTLSDS_ClusterTLSInfo(t *testing.T) {
+	dc := testDaskCluster()
+	dc.Spec.TLS = &dcv1alpha1.TLSConfig{Enabled: true}
+
+	info := (&clusterTLSDS{dc: dc}).ClusterTLSInfo()
+	assert.Equal(t, "test-dask-tls-ca", info.CASecretName)
+	assert.Equal(t, "test-dask-tls", info.SecretName)
+	assert.Equal(t, "test-dask-client-tls", info.ClientSecretName)
+	assert.Contains(t, info.DNSNames, "test-dask-scheduler.ns.svc")
+	assert.Contains(t, info.DNSNames, "*.test-dask-worker.ns.svc")
+}
+
+func TestCommandArgsTLS(t *testing.T) {
+	dc := testDaskCluster()
+	dc.Spec.TLS = &dcv1alpha1.TLSConfig{Enabled: true}
+	tlsFlags := []string{
+		"--protocol=tls",
+		"--tls-ca-file=/etc/dask/tls/ca.crt",
+		"--tls-cert=/etc/dask/tls/tls.crt",
+		"--tls-key=/etc/dask/tls/tls.key",
+	}
+
+	scheduler := (&schedulerConfig{dc: dc}).commandArgs()
+	assert.Subset(t, scheduler, tlsFlags)
+
+	worker := (&workerConfig{dc: dc}).commandArgs()
+	assert.Subset(t, worker, tlsFlags)
+	assert.Equal(t, "tls://test-dask-scheduler:8786", worker[len(worker)-1])
+}
@@ -0,0 +1,62 @@
+package dask
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashboard"
+)
+
+func Dashboard() core.Component {
+	return components.Dashboard(func(obj client.Object) components.DashboardDataSource {
+		return &dashboardDS{dc: daskCluster(obj)}
+	})
+}
+
+func DashboardAuthBinding() core.Component {
+	return components.DashboardAuthBinding(func(obj client.Object) components.DashboardAuthDataSource {
+		return &dashboardDS{dc: daskCluster(obj)}
+	})
+}
+
+type dashboardDS struct {
+	dc *dcv1alpha1.DaskCluster
+}
+
+func (s *dashboardDS) DashboardInfo() *dashboard.Info {
+	return dashboardInfo(s.dc)
+}
+
+func (s *dashboardDS) DashboardAuthInfo() *dashboard.AuthInfo {
+	return dashboardAuthInfo(s.dc)
+}
+
+// dashboardInfo routes to the dashboard served by the scheduler.
+func dashboardInfo(dc *dcv1alpha1.DaskCluster) *dashboard.Info {
+	return &dashboard.Info{
+		Name:        dashboardRouteName(dc),
+		Namespace:   dc.Namespace,
+		Labels:      meta.StandardLabelsWithComponent(dc, ComponentScheduler, nil),
+		ClusterName: dc.Name,
+		ServiceName: meta.InstanceName(dc, ComponentScheduler),
+		ServicePort: dashboard.Port(dc.Spec.Dashboard, dc.Spec.DashboardPort),
+		Config:      dc.Spec.Dashboard,
+	}
+}
+
+// dashboardAuthInfo places the dashboard proxy in front of the scheduler
+// dashboard.
+func dashboardAuthInfo(dc *dcv1alpha1.DaskCluster) *dashboard.AuthInfo {
+	return &dashboard.AuthInfo{
+		ClusterName:    dc.Name,
+		Namespace:      dc.Namespace,
+		Resource:       "daskclusters",
+		ConfigMapName:  dashboardAuthConfigMapName(dc),
+		Labels:         meta.StandardLabelsWithComponent(dc, ComponentScheduler, nil),
+		ServiceAccount: serviceAccountName(dc),
+		UpstreamPort:   dc.Spec.DashboardPort,
+		Config:         dc.Spec.Dashboard,
+	}
+}
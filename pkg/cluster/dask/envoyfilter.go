@@ -0,0 +1,41 @@
+package dask
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+)
+
+func EnvoyFilter(istioMode istio.Mode) core.Component {
+	return components.EnvoyFilter(func(obj client.Object) components.EnvoyFilterDataSource {
+		return &envoyFilterDS{dc: daskCluster(obj), istioMode: istioMode}
+	})
+}
+
+type envoyFilterDS struct {
+	dc        *dcv1alpha1.DaskCluster
+	istioMode istio.Mode
+}
+
+func (s *envoyFilterDS) EnvoyFilterInfo() *istio.EnvoyFilterInfo {
+	return &istio.EnvoyFilterInfo{
+		Name:      meta.InstanceName(s.dc, "envoyfilter"),
+		Namespace: s.dc.Namespace,
+		Labels:    meta.StandardLabels(s.dc),
+		Selector:  meta.MatchLabels(s.dc),
+		Config:    istio.EnvoyFilterConfig(s.dc.Spec.IstioConfig),
+	}
+}
+
+func (s *envoyFilterDS) Enabled() bool {
+	return s.istioMode.Enabled()
+}
+
+// Delete removes EnvoyFilters left behind in ambient mode, where pods do not
+// run an Envoy sidecar.
+func (s *envoyFilterDS) Delete() bool {
+	return s.istioMode.Ambient()
+}
@@ -0,0 +1,59 @@
+package dask
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/metadata"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashboard"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
+)
+
+func IstioAuthorizationPolicy(istioMode istio.Mode) core.Component {
+	return components.IstioAuthorizationPolicy(func(obj client.Object) components.IstioAuthorizationPolicyDataSource {
+		return &istioAuthorizationPolicyDS{dc: daskCluster(obj), istioMode: istioMode}
+	})
+}
+
+type istioAuthorizationPolicyDS struct {
+	dc        *dcv1alpha1.DaskCluster
+	istioMode istio.Mode
+}
+
+func (s *istioAuthorizationPolicyDS) AuthorizationPolicyInfo() *istio.AuthorizationPolicyInfo {
+	clientPorts := []int32{s.dc.Spec.SchedulerPort}
+	if syncEnabled(s.dc) {
+		clientPorts = append(clientPorts, s.dc.Spec.Sync.Port)
+	}
+
+	info := &istio.AuthorizationPolicyInfo{
+		Name:           meta.InstanceName(s.dc, metadata.ComponentNone),
+		Namespace:      s.dc.Namespace,
+		Labels:         meta.StandardLabels(s.dc),
+		Selector:       meta.MatchLabels(s.dc),
+		ServiceAccount: serviceAccountName(s.dc),
+		ClientPorts:    clientPorts,
+		DashboardPorts: []int32{dashboard.Port(s.dc.Spec.Dashboard, s.dc.Spec.DashboardPort)},
+		Waypoint:       istio.Waypoint(s.dc.Spec.IstioConfig, s.istioMode),
+		Services:       (&istioTrafficDS{dc: s.dc, istioMode: s.istioMode}).TrafficInfo().Services,
+	}
+	if s.dc.Spec.Istio != nil {
+		info.Config = s.dc.Spec.Istio.Authorization
+	}
+	if monitoring.Enabled(s.dc.Spec.Monitoring) {
+		info.MonitoringPorts = []int32{s.dc.Spec.DashboardPort}
+	}
+
+	return info
+}
+
+func (s *istioAuthorizationPolicyDS) Enabled() bool {
+	return s.istioMode.Enabled()
+}
+
+func (s *istioAuthorizationPolicyDS) Delete() bool {
+	return !istio.AuthorizationEnabled(s.dc.Spec.IstioConfig)
+}
@@ -0,0 +1,42 @@
+package dask
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/metadata"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+)
+
+func IstioTraffic(istioMode istio.Mode) core.Component {
+	return components.IstioTraffic(func(obj client.Object) components.IstioTrafficDataSource {
+		return &istioTrafficDS{dc: daskCluster(obj), istioMode: istioMode}
+	})
+}
+
+type istioTrafficDS struct {
+	dc        *dcv1alpha1.DaskCluster
+	istioMode istio.Mode
+}
+
+func (s *istioTrafficDS) TrafficInfo() *istio.TrafficInfo {
+	return &istio.TrafficInfo{
+		Name:      meta.InstanceName(s.dc, metadata.ComponentNone),
+		Namespace: s.dc.Namespace,
+		Labels:    meta.StandardLabels(s.dc),
+		Selector:  meta.MatchLabels(s.dc),
+		Services: []string{
+			meta.InstanceName(s.dc, ComponentScheduler),
+			meta.InstanceName(s.dc, ComponentWorker),
+			components.ClientPortsServiceName(s.dc, meta),
+		},
+		Mode:   s.istioMode,
+		Config: s.dc.Spec.Istio,
+	}
+}
+
+func (s *istioTrafficDS) Enabled() bool {
+	return s.istioMode.Enabled()
+}
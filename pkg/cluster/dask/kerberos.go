@@ -0,0 +1,64 @@
+package dask
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/metadata"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/kerberos"
+)
+
+// keytabVolumeName is the pod volume that holds the keytab file.
+const keytabVolumeName = "kerberos"
+
+func KeytabSecret() core.OwnedComponent {
+	return components.KeytabSecret(func(obj client.Object) components.KeytabSecretDataSource {
+		return &keytabSecretDS{dc: daskCluster(obj)}
+	})
+}
+
+type keytabSecretDS struct {
+	dc *dcv1alpha1.DaskCluster
+}
+
+func (s *keytabSecretDS) KeytabInfo() *kerberos.KeytabInfo {
+	return &kerberos.KeytabInfo{
+		SecretName:    keytabSecretName(s.dc),
+		ConfigMapName: meta.InstanceName(s.dc, metadata.ComponentNone),
+		Namespace:     s.dc.Namespace,
+		Labels:        meta.StandardLabels(s.dc),
+		Config:        s.dc.Spec.KerberosKeytab,
+	}
+}
+
+func ConfigMapKerberos() core.OwnedComponent {
+	return components.ConfigMap(func(obj client.Object) components.ConfigMapDataSource {
+		return &kerberosConfigMapDS{dc: daskCluster(obj)}
+	})
+}
+
+type kerberosConfigMapDS struct {
+	dc *dcv1alpha1.DaskCluster
+}
+
+func (s *kerberosConfigMapDS) ConfigMap() *corev1.ConfigMap {
+	return kerberos.NewConfigMap(kerberosInfo(s.dc))
+}
+
+func (s *kerberosConfigMapDS) Delete() bool {
+	return !kerberos.ConfigMapEnabled(s.dc.Spec.Kerberos)
+}
+
+func kerberosInfo(dc *dcv1alpha1.DaskCluster) *kerberos.Info {
+	return &kerberos.Info{
+		ConfigMapName: kerberosConfigMapName(dc),
+		Namespace:     dc.Namespace,
+		Labels:        meta.StandardLabels(dc),
+		Image:         dc.Spec.Image,
+		KeytabVolume:  keytabVolumeName,
+		Config:        dc.Spec.Kerberos,
+	}
+}
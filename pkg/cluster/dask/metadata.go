@@ -22,3 +22,59 @@ var meta = metadata.NewProvider(
 func daskCluster(obj client.Object) *dcv1alpha1.DaskCluster {
 	return obj.(*dcv1alpha1.DaskCluster)
 }
+
+func syncEnabled(dc *dcv1alpha1.DaskCluster) bool {
+	return dc.Spec.Sync != nil && dc.Spec.Sync.Enabled
+}
+
+func podGroupName(dc *dcv1alpha1.DaskCluster) string {
+	return meta.InstanceName(dc, metadata.ComponentNone)
+}
+
+func podMonitorName(dc *dcv1alpha1.DaskCluster) string {
+	return meta.InstanceName(dc, metadata.ComponentNone)
+}
+
+func dashboardRouteName(dc *dcv1alpha1.DaskCluster) string {
+	return meta.InstanceName(dc, "dashboard")
+}
+
+func dashboardAuthConfigMapName(dc *dcv1alpha1.DaskCluster) string {
+	return meta.InstanceName(dc, "dashboard-auth")
+}
+
+func keytabSecretName(dc *dcv1alpha1.DaskCluster) string {
+	return meta.InstanceName(dc, "keytab")
+}
+
+func kerberosConfigMapName(dc *dcv1alpha1.DaskCluster) string {
+	return meta.InstanceName(dc, "kerberos")
+}
+
+func tlsSecretName(dc *dcv1alpha1.DaskCluster) string {
+	return meta.InstanceName(dc, "tls")
+}
+
+func tlsCASecretName(dc *dcv1alpha1.DaskCluster) string {
+	return meta.InstanceName(dc, "tls-ca")
+}
+
+func tlsClientSecretName(dc *dcv1alpha1.DaskCluster) string {
+	return meta.InstanceName(dc, "client-tls")
+}
+
+func workloadName(dc *dcv1alpha1.DaskCluster) string {
+	return meta.InstanceName(dc, metadata.ComponentNone)
+}
+
+func syncSecretName(dc *dcv1alpha1.DaskCluster) string {
+	return meta.InstanceName(dc, "sync")
+}
+
+func serviceAccountName(dc *dcv1alpha1.DaskCluster) string {
+	if dc.Spec.ServiceAccount.Name != "" {
+		return dc.Spec.ServiceAccount.Name
+	}
+
+	return meta.InstanceName(dc, metadata.ComponentNone)
+}
@@ -11,6 +11,9 @@ import (
 	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/metadata"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/clientaccess"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashboard"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
 )
 
@@ -26,6 +29,29 @@ func NetworkPolicyWorker() core.OwnedComponent {
 	})
 }
 
+func NetworkPolicyEgress(istioEnabled bool) core.OwnedComponent {
+	return components.EgressNetworkPolicy(func(obj client.Object) components.EgressNetworkPolicyDataSource {
+		return &egressNetworkPolicyDS{dc: daskCluster(obj), istioEnabled: istioEnabled}
+	})
+}
+
+type egressNetworkPolicyDS struct {
+	dc           *dcv1alpha1.DaskCluster
+	istioEnabled bool
+}
+
+func (s *egressNetworkPolicyDS) NetworkPolicyConfig() dcv1alpha1.NetworkPolicyConfig {
+	return s.dc.Spec.NetworkPolicy
+}
+
+func (s *egressNetworkPolicyDS) IstioEnabled() bool {
+	return s.istioEnabled
+}
+
+func (s *egressNetworkPolicyDS) Metadata() *metadata.Provider {
+	return meta
+}
+
 type networkPolicyDS struct {
 	dc   *dcv1alpha1.DaskCluster
 	comp metadata.Component
@@ -60,21 +86,16 @@ func (s *networkPolicyDS) ingressRules() []networkingv1.NetworkPolicyIngressRule
 
 	if s.comp == ComponentScheduler {
 		sPort := intstr.FromInt(int(s.dc.Spec.SchedulerPort))
+		clientPort := dashboard.Port(s.dc.Spec.Dashboard, s.dc.Spec.DashboardPort)
+		clientDashboardPort := intstr.FromInt(int(clientPort))
 
-		return []networkingv1.NetworkPolicyIngressRule{
+		rules := []networkingv1.NetworkPolicyIngressRule{
 			{
-				From: []networkingv1.NetworkPolicyPeer{
-					{
-						PodSelector: &metav1.LabelSelector{
-							MatchLabels: s.dc.Spec.NetworkPolicy.ClientLabels,
-						},
-					},
-					{
-						PodSelector: &metav1.LabelSelector{
-							MatchLabels: meta.MatchLabelsWithComponent(s.dc, ComponentWorker),
-						},
+				From: append(clientaccess.Peers(s.dc.Spec.NetworkPolicy), networkingv1.NetworkPolicyPeer{
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: meta.MatchLabelsWithComponent(s.dc, ComponentWorker),
 					},
-				},
+				}),
 				Ports: []networkingv1.NetworkPolicyPort{
 					{
 						Port:     &sPort,
@@ -95,18 +116,22 @@ func (s *networkPolicyDS) ingressRules() []networkingv1.NetworkPolicyIngressRule
 				},
 				Ports: []networkingv1.NetworkPolicyPort{
 					{
-						Port:     &dashboardPort,
+						Port:     &clientDashboardPort,
 						Protocol: &tcpProto,
 					},
 				},
 			},
 		}
+
+		rules = append(rules, dashboard.IngressRules(s.dc.Spec.Dashboard, clientPort)...)
+
+		return append(rules, monitoring.IngressRules(s.dc.Spec.Monitoring, s.dc.Spec.DashboardPort)...)
 	}
 
 	workerPort := intstr.FromInt(int(s.dc.Spec.WorkerPort))
 	nannyPort := intstr.FromInt(int(s.dc.Spec.NannyPort))
 
-	return []networkingv1.NetworkPolicyIngressRule{
+	rules := []networkingv1.NetworkPolicyIngressRule{
 		{
 			From: []networkingv1.NetworkPolicyPeer{
 				{
@@ -166,4 +191,20 @@ func (s *networkPolicyDS) ingressRules() []networkingv1.NetworkPolicyIngressRule
 			},
 		},
 	}
+
+	if syncEnabled(s.dc) {
+		syncPort := intstr.FromInt(int(s.dc.Spec.Sync.Port))
+
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{
+			From: clientaccess.Peers(s.dc.Spec.NetworkPolicy),
+			Ports: []networkingv1.NetworkPolicyPort{
+				{
+					Port:     &syncPort,
+					Protocol: &tcpProto,
+				},
+			},
+		})
+	}
+
+	return append(rules, monitoring.IngressRules(s.dc.Spec.Monitoring, s.dc.Spec.DashboardPort)...)
 }
@@ -4,11 +4,15 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/metadata"
 )
 
 func TestNetworkPolicyDS_NetworkPolicy(t *testing.T) {
@@ -214,6 +218,79 @@ func TestNetworkPolicyDS_NetworkPolicy(t *testing.T) {
 
 		assert.Equal(t, expected, actual)
 	})
+
+	t.Run("worker_sync", func(t *testing.T) {
+		dc := testDaskCluster()
+		dc.Spec.Sync = &dcv1alpha1.SyncConfig{Enabled: true, Port: 2223, MountPath: "/mnt/sync"}
+		ds := networkPolicyDS{dc: dc, comp: ComponentWorker}
+		syncPort := intstr.FromInt(2223)
+
+		actual := ds.NetworkPolicy()
+		expected := networkingv1.NetworkPolicyIngressRule{
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"test-client": "true",
+						},
+					},
+				},
+			},
+			Ports: []networkingv1.NetworkPolicyPort{
+				{
+					Port:     &syncPort,
+					Protocol: &tcpProto,
+				},
+			},
+		}
+
+		assert.Contains(t, actual.Spec.Ingress, expected)
+	})
+
+	t.Run("monitoring", func(t *testing.T) {
+		dc := testDaskCluster()
+		dc.Spec.Monitoring = &dcv1alpha1.MonitoringConfig{
+			Enabled:                   true,
+			PrometheusLabels:          map[string]string{"app": "prometheus"},
+			PrometheusNamespaceLabels: map[string]string{"name": "monitoring"},
+		}
+		expected := networkingv1.NetworkPolicyIngressRule{
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "prometheus"},
+					},
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"name": "monitoring"},
+					},
+				},
+			},
+			Ports: []networkingv1.NetworkPolicyPort{
+				{
+					Port:     &dashboardPort,
+					Protocol: &tcpProto,
+				},
+			},
+		}
+
+		for _, comp := range []metadata.Component{ComponentScheduler, ComponentWorker} {
+			ds := networkPolicyDS{dc: dc, comp: comp}
+			assert.Contains(t, ds.NetworkPolicy().Spec.Ingress, expected, comp)
+		}
+	})
+}
+
+func TestNetworkPolicyDS_NetworkPolicyDashboardAuth(t *testing.T) {
+	dc := testDaskCluster()
+	dc.Spec.Dashboard = &dcv1alpha1.DashboardConfig{
+		Auth: &dcv1alpha1.DashboardAuthConfig{Enabled: true, Port: 4180},
+	}
+	ds := networkPolicyDS{dc: dc, comp: ComponentScheduler}
+
+	rules := ds.NetworkPolicy().Spec.Ingress
+	require.Len(t, rules, 2)
+	assert.Equal(t, 4180, rules[1].Ports[0].Port.IntValue())
+	assert.Equal(t, map[string]string{"test-ui-client": "true"}, rules[1].From[0].PodSelector.MatchLabels)
 }
 
 func TestNetworkPolicyDS_Delete(t *testing.T) {
@@ -250,3 +327,25 @@ func TestNetworkPolicyDS_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestNetworkPolicyDS_NetworkPolicyClientPeers(t *testing.T) {
+	dc := testDaskCluster()
+	dc.Spec.NetworkPolicy.ClientNamespaceLabels = map[string]string{"team": "data"}
+	dc.Spec.NetworkPolicy.ClientIPBlocks = []networkingv1.IPBlock{{CIDR: "10.0.0.0/8"}}
+	ds := networkPolicyDS{dc: dc, comp: ComponentScheduler}
+
+	from := ds.NetworkPolicy().Spec.Ingress[0].From
+	require.Len(t, from, 3)
+	assert.Equal(t, map[string]string{"team": "data"}, from[0].NamespaceSelector.MatchLabels)
+	assert.Equal(t, &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}, from[1].IPBlock)
+	assert.Equal(t, meta.MatchLabelsWithComponent(dc, ComponentWorker), from[2].PodSelector.MatchLabels)
+}
+
+func TestEgressNetworkPolicyDS(t *testing.T) {
+	dc := testDaskCluster()
+	ds := egressNetworkPolicyDS{dc: dc, istioEnabled: true}
+
+	assert.Equal(t, dc.Spec.NetworkPolicy, ds.NetworkPolicyConfig())
+	assert.True(t, ds.IstioEnabled())
+	assert.Equal(t, meta, ds.Metadata())
+}
@@ -0,0 +1,30 @@
+package dask
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
+)
+
+func PodGroup() core.Component {
+	return components.PodGroup(func(obj client.Object) components.PodGroupDataSource {
+		return &podGroupDS{dc: daskCluster(obj)}
+	})
+}
+
+type podGroupDS struct {
+	dc *dcv1alpha1.DaskCluster
+}
+
+func (s *podGroupDS) PodGroupInfo() *podgroup.Info {
+	return &podgroup.Info{
+		Name:      podGroupName(s.dc),
+		Namespace: s.dc.Namespace,
+		Labels:    meta.StandardLabels(s.dc),
+		MinMember: podgroup.MinMember(1, s.dc.Spec.Worker.Replicas, s.dc.Spec.Autoscaling),
+		Config:    s.dc.Spec.GangScheduling,
+	}
+}
@@ -0,0 +1,35 @@
+package dask
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
+)
+
+func PodMonitor() core.Component {
+	return components.PodMonitor(func(obj client.Object) components.PodMonitorDataSource {
+		return &podMonitorDS{dc: daskCluster(obj)}
+	})
+}
+
+type podMonitorDS struct {
+	dc *dcv1alpha1.DaskCluster
+}
+
+// PodMonitorInfo scrapes the dashboard servers of the scheduler and workers,
+// which publish Prometheus metrics at the same path.
+func (s *podMonitorDS) PodMonitorInfo() *monitoring.Info {
+	return &monitoring.Info{
+		Name:      podMonitorName(s.dc),
+		Namespace: s.dc.Namespace,
+		Labels:    meta.StandardLabels(s.dc),
+		Selector:  meta.MatchLabels(s.dc),
+		Endpoints: []monitoring.Endpoint{
+			{Port: "dashboard", Path: "/metrics"},
+		},
+		Config: s.dc.Spec.Monitoring,
+	}
+}
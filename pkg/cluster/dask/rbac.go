@@ -18,15 +18,15 @@ var (
 )
 
 func RolePodSecurityPolicy() core.OwnedComponent {
-	return components.Role(func(obj client.Object) components.RoleDataSource {
+	return components.PodSecurityPolicyRBAC(components.Role(func(obj client.Object) components.RoleDataSource {
 		return &pspDS{dc: daskCluster(obj)}
-	})
+	}))
 }
 
 func RoleBindingPodSecurityPolicy() core.OwnedComponent {
-	return components.RoleBinding(func(obj client.Object) components.RoleBindingDataSource {
+	return components.PodSecurityPolicyRBAC(components.RoleBinding(func(obj client.Object) components.RoleBindingDataSource {
 		return &pspDS{dc: daskCluster(obj)}
-	})
+	}))
 }
 
 type pspDS struct {
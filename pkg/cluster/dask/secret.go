@@ -0,0 +1,32 @@
+package dask
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
+)
+
+func SyncSecret() core.OwnedComponent {
+	return components.SyncSecret(func(obj client.Object) components.SyncSecretDataSource {
+		return &syncSecretDS{dc: daskCluster(obj)}
+	})
+}
+
+type syncSecretDS struct {
+	dc *dcv1alpha1.DaskCluster
+}
+
+func (s *syncSecretDS) SyncSecretInfo() *filesync.SecretInfo {
+	return &filesync.SecretInfo{
+		Name:      syncSecretName(s.dc),
+		Namespace: s.dc.Namespace,
+		Labels:    meta.StandardLabels(s.dc),
+	}
+}
+
+func (s *syncSecretDS) Delete() bool {
+	return !syncEnabled(s.dc)
+}
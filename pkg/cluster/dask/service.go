@@ -10,6 +10,8 @@ import (
 	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/metadata"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashboard"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
 )
 
 func ServiceScheduler() core.OwnedComponent {
@@ -52,15 +54,11 @@ func (s *serviceDS) ports() []corev1.ServicePort {
 				Port:       s.dc.Spec.SchedulerPort,
 				TargetPort: intstr.FromString("serve"),
 			},
-			{
-				Name:       "tcp-dashboard",
-				Port:       s.dc.Spec.DashboardPort,
-				TargetPort: intstr.FromString("dashboard"),
-			},
+			s.schedulerDashboardPort(),
 		}
 	}
 
-	return []corev1.ServicePort{
+	ports := []corev1.ServicePort{
 		{
 			Name:       "tcp-worker",
 			Port:       s.dc.Spec.WorkerPort,
@@ -77,4 +75,32 @@ func (s *serviceDS) ports() []corev1.ServicePort {
 			TargetPort: intstr.FromString("dashboard"),
 		},
 	}
+
+	if syncEnabled(s.dc) {
+		ports = append(ports, corev1.ServicePort{
+			Name:       filesync.PortName,
+			Port:       s.dc.Spec.Sync.Port,
+			TargetPort: intstr.FromString(filesync.PortName),
+		})
+	}
+
+	return ports
+}
+
+// schedulerDashboardPort routes to the dashboard proxy instead of the
+// dashboard server when authentication is enabled.
+func (s *serviceDS) schedulerDashboardPort() corev1.ServicePort {
+	if s.dc.Spec.Dashboard.AuthEnabled() {
+		return corev1.ServicePort{
+			Name:       "tcp-dashboard",
+			Port:       s.dc.Spec.Dashboard.Auth.Port,
+			TargetPort: intstr.FromString(dashboard.AuthPortName),
+		}
+	}
+
+	return corev1.ServicePort{
+		Name:       "tcp-dashboard",
+		Port:       s.dc.Spec.DashboardPort,
+		TargetPort: intstr.FromString("dashboard"),
+	}
 }
@@ -7,6 +7,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 )
 
 func TestServiceDataSource_Service(t *testing.T) {
@@ -53,6 +55,20 @@ func TestServiceDataSource_Service(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 
+	t.Run("scheduler_dashboard_auth", func(t *testing.T) {
+		dc := testDaskCluster()
+		dc.Spec.Dashboard = &dcv1alpha1.DashboardConfig{
+			Auth: &dcv1alpha1.DashboardAuthConfig{Enabled: true, Port: 4180},
+		}
+		ds := serviceDS{dc: dc, comp: ComponentScheduler}
+
+		assert.Contains(t, ds.Service().Spec.Ports, corev1.ServicePort{
+			Name:       "tcp-dashboard",
+			Port:       4180,
+			TargetPort: intstr.FromString("dashboard-auth"),
+		})
+	})
+
 	t.Run("worker", func(t *testing.T) {
 		ds := serviceDS{dc: dc, comp: ComponentWorker}
 
@@ -98,4 +114,17 @@ func TestServiceDataSource_Service(t *testing.T) {
 
 		assert.Equal(t, expected, actual)
 	})
+
+	t.Run("worker_sync", func(t *testing.T) {
+		dc := testDaskCluster()
+		dc.Spec.Sync = &dcv1alpha1.SyncConfig{Enabled: true, Port: 2223, MountPath: "/mnt/sync"}
+		ds := serviceDS{dc: dc, comp: ComponentWorker}
+
+		actual := ds.Service()
+		assert.Contains(t, actual.Spec.Ports, corev1.ServicePort{
+			Name:       "tcp-rsync",
+			Port:       2223,
+			TargetPort: intstr.FromString("tcp-rsync"),
+		})
+	})
 }
@@ -14,31 +14,41 @@ import (
 	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/metadata"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/clustertls"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashboard"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/kerberos"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podsecurity"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/workload"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
 )
 
-func StatefulSetScheduler() core.OwnedComponent {
+func StatefulSetScheduler(istioMode istio.Mode) core.OwnedComponent {
 	return components.StatefulSet(func(obj client.Object) components.StatefulSetDataSource {
 		dc := daskCluster(obj)
 		tc := &schedulerConfig{dc: dc}
 
-		return &statefulSetDS{tc, dc, ComponentScheduler}
+		return &statefulSetDS{tc, dc, ComponentScheduler, "", istioMode}
 	})
 }
 
-func StatefulSetWorker() core.OwnedComponent {
+func StatefulSetWorker(syncImage string, istioMode istio.Mode) core.OwnedComponent {
 	return components.StatefulSet(func(obj client.Object) components.StatefulSetDataSource {
 		dc := daskCluster(obj)
 		tc := &workerConfig{dc: dc}
 
-		return &statefulSetDS{tc, dc, ComponentWorker}
+		return &statefulSetDS{tc, dc, ComponentWorker, syncImage, istioMode}
 	})
 }
 
 type statefulSetDS struct {
-	tc   typeConfig
-	dc   *dcv1alpha1.DaskCluster
-	comp metadata.Component
+	tc        typeConfig
+	dc        *dcv1alpha1.DaskCluster
+	comp      metadata.Component
+	syncImage string
+	istioMode istio.Mode
 }
 
 func (s *statefulSetDS) StatefulSet() (*appsv1.StatefulSet, error) {
@@ -46,6 +56,9 @@ func (s *statefulSetDS) StatefulSet() (*appsv1.StatefulSet, error) {
 	if err != nil {
 		return nil, fmt.Errorf("cannot parse image: %w", err)
 	}
+	if s.syncSidecarEnabled() && s.syncImage == "" {
+		return nil, fmt.Errorf("sync sidecar image is not provided")
+	}
 
 	sts := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -61,11 +74,11 @@ func (s *statefulSetDS) StatefulSet() (*appsv1.StatefulSet, error) {
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      s.labels(),
+					Labels:      s.podLabels(),
 					Annotations: s.podAnnotations(),
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: s.serviceAccountName(),
+					ServiceAccountName: serviceAccountName(s.dc),
 					NodeSelector:       s.nodeSelector(),
 					Affinity:           s.affinity(),
 					Tolerations:        s.tolerations(),
@@ -98,6 +111,37 @@ func (s *statefulSetDS) StatefulSet() (*appsv1.StatefulSet, error) {
 		},
 	}
 
+	if s.syncSidecarEnabled() {
+		podSpec := &sts.Spec.Template.Spec
+		podSpec.Containers = append(podSpec.Containers, s.syncSidecar())
+	}
+	if kerberos.Enabled(s.dc.Spec.Kerberos) {
+		info := kerberosInfo(s.dc)
+		initContainer, err := kerberos.InitContainer(info)
+		if err != nil {
+			return nil, err
+		}
+		sidecar, err := kerberos.Sidecar(info)
+		if err != nil {
+			return nil, err
+		}
+
+		podSpec := &sts.Spec.Template.Spec
+		podSpec.InitContainers = append(podSpec.InitContainers, initContainer)
+		podSpec.Containers = append(podSpec.Containers, sidecar)
+	}
+	if s.dashboardAuthEnabled() {
+		sidecar, err := dashboard.AuthSidecar(dashboardAuthInfo(s.dc))
+		if err != nil {
+			return nil, err
+		}
+
+		podSpec := &sts.Spec.Template.Spec
+		podSpec.Containers = append(podSpec.Containers, sidecar)
+	}
+	podsecurity.Harden(s.dc.Spec.SecurityProfile, &sts.Spec.Template.Spec)
+	podgroup.ConfigurePodTemplate(s.dc.Spec.GangScheduling, podGroupName(s.dc), &sts.Spec.Template)
+
 	return sts, nil
 }
 
@@ -124,6 +168,10 @@ func (s *statefulSetDS) labels() map[string]string {
 	return meta.StandardLabelsWithComponent(s.dc, s.comp, s.tc.podConfig().Labels)
 }
 
+func (s *statefulSetDS) podLabels() map[string]string {
+	return util.MergeStringMaps(istio.PodLabels(s.istioMode), s.labels())
+}
+
 func (s *statefulSetDS) securityContext() *corev1.SecurityContext {
 	switch s.comp {
 	case ComponentScheduler:
@@ -143,14 +191,6 @@ func (s *statefulSetDS) serviceName() string {
 	return meta.InstanceName(s.dc, s.comp)
 }
 
-func (s *statefulSetDS) serviceAccountName() string {
-	if s.dc.Spec.ServiceAccount.Name != "" {
-		return s.dc.Spec.ServiceAccount.Name
-	}
-
-	return meta.InstanceName(s.dc, metadata.ComponentNone)
-}
-
 func (s *statefulSetDS) image() *dcv1alpha1.OCIImageDefinition {
 	return s.dc.Spec.Image
 }
@@ -166,12 +206,15 @@ func (s *statefulSetDS) podSecurityContext() *corev1.PodSecurityContext {
 func (s *statefulSetDS) env() []corev1.EnvVar {
 	envvars := s.dc.Spec.EnvVars
 	envvars = append(envvars, s.tc.containerEnv()...)
+	if kerberos.Enabled(s.dc.Spec.Kerberos) {
+		envvars = append(envvars, kerberos.Env()...)
+	}
 
 	return envvars
 }
 
 func (s *statefulSetDS) replicas() *int32 {
-	return s.tc.replicas()
+	return workload.Replicas(&s.dc.Status.ClusterStatusConfig, s.tc.replicas())
 }
 
 func (s *statefulSetDS) commandArgs() []string {
@@ -205,16 +248,23 @@ func (s *statefulSetDS) initContainers() []corev1.Container {
 func (s *statefulSetDS) volumes() []corev1.Volume {
 	volumes := s.tc.podConfig().Volumes
 	if s.dc.Spec.KerberosKeytab != nil {
-		volumes = append(volumes, corev1.Volume{
-			Name: "kerberos",
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: meta.InstanceName(s.dc, metadata.ComponentNone),
-					},
-				},
-			},
-		})
+		volumes = append(volumes, kerberos.NewKeytabVolume(
+			keytabVolumeName, s.dc.Spec.KerberosKeytab, keytabSecretName(s.dc),
+		))
+	}
+	if kerberos.Enabled(s.dc.Spec.Kerberos) {
+		volumes = append(volumes, kerberos.Volumes(kerberosInfo(s.dc))...)
+	}
+
+	if s.syncSidecarEnabled() {
+		shared, _ := filesync.NewSharedVolume(s.dc.Spec.Sync.MountPath)
+		volumes = append(volumes, shared, filesync.NewAuthVolume(syncSecretName(s.dc)))
+	}
+	if s.dashboardAuthEnabled() {
+		volumes = append(volumes, dashboard.AuthVolumes(dashboardAuthInfo(s.dc))...)
+	}
+	if clustertls.Enabled(s.dc.Spec.TLS) {
+		volumes = append(volumes, clustertls.NewVolume(tlsSecretName(s.dc)))
 	}
 
 	return volumes
@@ -224,20 +274,65 @@ func (s *statefulSetDS) volumeMounts() []corev1.VolumeMount {
 	mounts := s.tc.podConfig().VolumeMounts
 	if s.dc.Spec.KerberosKeytab != nil {
 		mounts = append(mounts, corev1.VolumeMount{
-			Name:      "kerberos",
+			Name:      keytabVolumeName,
 			ReadOnly:  true,
 			MountPath: s.dc.Spec.KerberosKeytab.MountPath,
 		})
 	}
+	if kerberos.Enabled(s.dc.Spec.Kerberos) {
+		mounts = append(mounts, kerberos.VolumeMounts()...)
+	}
+
+	if s.syncSidecarEnabled() {
+		_, shared := filesync.NewSharedVolume(s.dc.Spec.Sync.MountPath)
+		mounts = append(mounts, shared)
+	}
+	if clustertls.Enabled(s.dc.Spec.TLS) {
+		mounts = append(mounts, clustertls.NewVolumeMount(tlsMountPath))
+	}
 
 	return mounts
 }
 
+func (s *statefulSetDS) syncSidecarEnabled() bool {
+	return s.comp == ComponentWorker && syncEnabled(s.dc)
+}
+
+func (s *statefulSetDS) dashboardAuthEnabled() bool {
+	return s.comp == ComponentScheduler && s.dc.Spec.Dashboard.AuthEnabled()
+}
+
+func (s *statefulSetDS) syncSidecar() corev1.Container {
+	_, shared := filesync.NewSharedVolume(s.dc.Spec.Sync.MountPath)
+	mounts := append([]corev1.VolumeMount{shared}, s.tc.podConfig().VolumeMounts...)
+
+	return filesync.NewSidecar(&filesync.SidecarInfo{
+		Name:         s.applicationName() + filesync.ContainerSuffix,
+		Image:        s.syncImage,
+		PullPolicy:   s.image().PullPolicy,
+		Port:         s.dc.Spec.Sync.Port,
+		SecretName:   syncSecretName(s.dc),
+		Modules:      filesync.Modules(s.dc.Spec.Sync),
+		VolumeMounts: mounts,
+	})
+}
+
 func (s *statefulSetDS) resources() corev1.ResourceRequirements {
 	return s.tc.podConfig().Resources
 }
 
 func (s *statefulSetDS) probe() *corev1.Probe {
+	// dashboards behind the auth proxy cannot be reached by the kubelet
+	if s.dc.Spec.Dashboard.AuthEnabled() {
+		return &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{
+					Port: intstr.FromString(s.tc.containerPorts()[0].Name),
+				},
+			},
+		}
+	}
+
 	return &corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
 			HTTPGet: &corev1.HTTPGetAction{
@@ -266,6 +361,17 @@ func (s *statefulSetDS) pvcTemplates() (tmpls []corev1.PersistentVolumeClaim) {
 	return
 }
 
+// dashboardAddress returns the listen address of the scheduler and worker
+// dashboards. Only the auth proxy can reach them when dashboard auth is
+// enabled.
+func dashboardAddress(dc *dcv1alpha1.DaskCluster) string {
+	if dc.Spec.Dashboard.AuthEnabled() {
+		return fmt.Sprintf("127.0.0.1:%d", dc.Spec.DashboardPort)
+	}
+
+	return fmt.Sprintf(":%d", dc.Spec.DashboardPort)
+}
+
 type typeConfig interface {
 	podConfig() dcv1alpha1.WorkloadConfig
 	replicas() *int32
@@ -287,11 +393,16 @@ func (c *schedulerConfig) replicas() *int32 {
 }
 
 func (c *schedulerConfig) commandArgs() []string {
-	return []string{
+	args := []string{
 		"dask-scheduler",
 		fmt.Sprintf("--port=%d", c.dc.Spec.SchedulerPort),
-		fmt.Sprintf("--dashboard-address=:%d", c.dc.Spec.DashboardPort),
+		"--dashboard-address=" + dashboardAddress(c.dc),
 	}
+	if clustertls.Enabled(c.dc.Spec.TLS) {
+		args = append(args, tlsArgs()...)
+	}
+
+	return args
 }
 
 func (c *schedulerConfig) containerEnv() []corev1.EnvVar {
@@ -324,7 +435,7 @@ func (c *workerConfig) replicas() *int32 {
 }
 
 func (c *workerConfig) commandArgs() []string {
-	return []string{
+	args := []string{
 		"dask-worker",
 		"--name=$(MY_POD_NAME)",
 		"--local-directory=/tmp",
@@ -333,9 +444,16 @@ func (c *workerConfig) commandArgs() []string {
 		// "--memory=$(MY_MEM_LIMIT)",
 		fmt.Sprintf("--worker-port=%d", c.dc.Spec.WorkerPort),
 		fmt.Sprintf("--nanny-port=%d", c.dc.Spec.NannyPort),
-		fmt.Sprintf("--dashboard-address=:%d", c.dc.Spec.DashboardPort),
-		fmt.Sprintf("%s:%d", meta.InstanceName(c.dc, ComponentScheduler), c.dc.Spec.SchedulerPort),
+		"--dashboard-address=" + dashboardAddress(c.dc),
 	}
+
+	schedulerAddress := fmt.Sprintf("%s:%d", meta.InstanceName(c.dc, ComponentScheduler), c.dc.Spec.SchedulerPort)
+	if clustertls.Enabled(c.dc.Spec.TLS) {
+		args = append(args, tlsArgs()...)
+		schedulerAddress = "tls://" + schedulerAddress
+	}
+
+	return append(args, schedulerAddress)
 }
 
 func (c *workerConfig) containerEnv() []corev1.EnvVar {
@@ -0,0 +1,48 @@
+package dask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestCommandArgsDashboardAuth(t *testing.T) {
+	dc := testDaskCluster()
+
+	scheduler := (&schedulerConfig{dc: dc}).commandArgs()
+	assert.Contains(t, scheduler, "--dashboard-address=:8787")
+	worker := (&workerConfig{dc: dc}).commandArgs()
+	assert.Contains(t, worker, "--dashboard-address=:8787")
+
+	dc.Spec.Dashboard = &dcv1alpha1.DashboardConfig{
+		Auth: &dcv1alpha1.DashboardAuthConfig{Enabled: true, Port: 4180},
+	}
+
+	scheduler = (&schedulerConfig{dc: dc}).commandArgs()
+	assert.Contains(t, scheduler, "--dashboard-address=127.0.0.1:8787")
+	worker = (&workerConfig{dc: dc}).commandArgs()
+	assert.Contains(t, worker, "--dashboard-address=127.0.0.1:8787")
+}
+
+func TestStatefulSetDS_ProbeDashboardAuth(t *testing.T) {
+	dc := testDaskCluster()
+	ds := statefulSetDS{dc: dc, tc: &schedulerConfig{dc: dc}, comp: ComponentScheduler}
+
+	probe := ds.probe()
+	require.NotNil(t, probe.HTTPGet)
+	assert.Equal(t, "dashboard", probe.HTTPGet.Port.String())
+
+	dc.Spec.Dashboard = &dcv1alpha1.DashboardConfig{
+		Auth: &dcv1alpha1.DashboardAuthConfig{Enabled: true, Port: 4180},
+	}
+
+	probe = ds.probe()
+	require.NotNil(t, probe.TCPSocket)
+	assert.Equal(t, "serve", probe.TCPSocket.Port.String())
+
+	worker := statefulSetDS{dc: dc, tc: &workerConfig{dc: dc}, comp: ComponentWorker}
+	assert.Equal(t, "worker", worker.probe().TCPSocket.Port.String())
+}
@@ -0,0 +1,59 @@
+package dask
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/workload"
+)
+
+func Workload(syncImage string, istioMode istio.Mode) core.Component {
+	return components.Workload(func(obj client.Object) components.WorkloadDataSource {
+		return &workloadDS{dc: daskCluster(obj), syncImage: syncImage, istioMode: istioMode}
+	})
+}
+
+type workloadDS struct {
+	dc        *dcv1alpha1.DaskCluster
+	syncImage string
+	istioMode istio.Mode
+}
+
+func (s *workloadDS) WorkloadInfo() (*workload.Info, error) {
+	info := &workload.Info{
+		Name:      workloadName(s.dc),
+		Namespace: s.dc.Namespace,
+		Labels:    meta.StandardLabels(s.dc),
+		QueueName: workload.QueueName(s.dc),
+	}
+	if info.QueueName == "" {
+		return info, nil
+	}
+
+	scheduler, err := (&statefulSetDS{&schedulerConfig{dc: s.dc}, s.dc, ComponentScheduler, "", s.istioMode}).StatefulSet()
+	if err != nil {
+		return nil, err
+	}
+	worker, err := (&statefulSetDS{&workerConfig{dc: s.dc}, s.dc, ComponentWorker, s.syncImage, s.istioMode}).StatefulSet()
+	if err != nil {
+		return nil, err
+	}
+
+	info.PodSets = []workload.PodSet{
+		{Name: string(ComponentScheduler), Count: 1, Template: scheduler.Spec.Template},
+		{
+			Name:     string(ComponentWorker),
+			Count:    workload.WorkerCount(s.dc.Spec.Worker.Replicas, s.dc.Spec.Autoscaling),
+			Template: worker.Spec.Template,
+		},
+	}
+
+	return info, nil
+}
+
+func (s *workloadDS) ClusterStatusConfig() *dcv1alpha1.ClusterStatusConfig {
+	return &s.dc.Status.ClusterStatusConfig
+}
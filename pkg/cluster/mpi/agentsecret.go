@@ -0,0 +1,68 @@
+package mpi
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/actions"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/rankagent"
+)
+
+func AgentSecret() core.OwnedComponent {
+	return &agentSecretComponent{}
+}
+
+type agentSecretComponent struct{}
+
+func (c agentSecretComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
+	cr := objToMPICluster(ctx.Object)
+
+	current := &corev1.Secret{}
+	key := client.ObjectKey{Name: agentSecretName(cr), Namespace: cr.Namespace}
+
+	if !agentBootstrap(cr) {
+		current.Name, current.Namespace = key.Name, key.Namespace
+		return ctrl.Result{}, actions.DeleteIfExists(ctx, current)
+	}
+
+	if err := ctx.Client.Get(ctx, key, current); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot fetch agent secret: %w", err)
+	}
+
+	// the token is generated once, otherwise running launchers would lose access
+	token := current.Data[rankagent.TokenKey]
+	if len(token) == 0 {
+		var err error
+		if token, err = rankagent.GenerateToken(); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels:    meta.StandardLabels(cr),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			rankagent.TokenKey: token,
+		},
+	}
+
+	err := actions.CreateOrUpdateOwnedResource(ctx, cr, secret)
+	if err != nil {
+		err = fmt.Errorf("cannot reconcile agent secret: %w", err)
+	}
+
+	return ctrl.Result{}, err
+}
+
+func (c agentSecretComponent) Kind() client.Object {
+	return &corev1.Secret{}
+}
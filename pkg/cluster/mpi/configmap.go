@@ -12,6 +12,7 @@ import (
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/actions"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/kerberos"
 )
 
 func ConfigMap() core.OwnedComponent {
@@ -29,12 +30,14 @@ func (c configMapComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
 		return ctrl.Result{}, fmt.Errorf("cannot reconcile hostfile configmap: %w", err)
 	}
 
-	keytabConfig := createKeytabConfig(cr)
-	if keytabConfig != nil {
-		err := actions.CreateOrUpdateOwnedResource(ctx, cr, keytabConfig)
+	kerberosConfig := kerberos.NewConfigMap(kerberosInfo(cr))
+	if kerberos.ConfigMapEnabled(cr.Spec.Kerberos) {
+		err := actions.CreateOrUpdateOwnedResource(ctx, cr, kerberosConfig)
 		if err != nil {
-			return ctrl.Result{}, fmt.Errorf("cannot reconcile keytab configmap: %w", err)
+			return ctrl.Result{}, fmt.Errorf("cannot reconcile kerberos configmap: %w", err)
 		}
+	} else if err := actions.DeleteIfExists(ctx, kerberosConfig); err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot delete kerberos configmap: %w", err)
 	}
 
 	return ctrl.Result{}, nil
@@ -66,19 +69,3 @@ func createHostFileConfig(cr *dcv1alpha1.MPICluster) *corev1.ConfigMap {
 		},
 	}
 }
-
-func createKeytabConfig(cr *dcv1alpha1.MPICluster) *corev1.ConfigMap {
-	if cr.Spec.KerberosKeytab == nil {
-		return nil
-	}
-	return &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      configMapName(cr) + "-" + keytabName,
-			Namespace: cr.Namespace,
-			Labels:    meta.StandardLabels(cr),
-		},
-		BinaryData: map[string][]byte{
-			keytabName: cr.Spec.KerberosKeytab.Contents,
-		},
-	}
-}
@@ -0,0 +1,41 @@
+package mpi
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+)
+
+func EnvoyFilter(istioMode istio.Mode) core.Component {
+	return components.EnvoyFilter(func(obj client.Object) components.EnvoyFilterDataSource {
+		return &envoyFilterDS{mpi: objToMPICluster(obj), istioMode: istioMode}
+	})
+}
+
+type envoyFilterDS struct {
+	mpi       *dcv1alpha1.MPICluster
+	istioMode istio.Mode
+}
+
+func (s *envoyFilterDS) EnvoyFilterInfo() *istio.EnvoyFilterInfo {
+	return &istio.EnvoyFilterInfo{
+		Name:      meta.InstanceName(s.mpi, "envoyfilter"),
+		Namespace: s.mpi.Namespace,
+		Labels:    meta.StandardLabels(s.mpi),
+		Selector:  meta.MatchLabels(s.mpi),
+		Config:    istio.EnvoyFilterConfig(s.mpi.Spec.IstioConfig),
+	}
+}
+
+func (s *envoyFilterDS) Enabled() bool {
+	return s.istioMode.Enabled()
+}
+
+// Delete removes EnvoyFilters left behind in ambient mode, where pods do not
+// run an Envoy sidecar.
+func (s *envoyFilterDS) Delete() bool {
+	return s.istioMode.Ambient()
+}
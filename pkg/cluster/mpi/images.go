@@ -0,0 +1,62 @@
+package mpi
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+// helperImages are the images of the worker init container and file sync
+// sidecar, and the policies used to pull them.
+type helperImages struct {
+	init           string
+	initPullPolicy corev1.PullPolicy
+	sync           string
+	syncPullPolicy corev1.PullPolicy
+}
+
+// resolveHelperImages selects the helper images of a cluster: per-cluster
+// overrides take precedence over the operator defaults.
+func resolveHelperImages(cr *dcv1alpha1.MPICluster, defaultInit, defaultSync string) (*helperImages, error) {
+	var err error
+	images := &helperImages{}
+
+	images.init, images.initPullPolicy, err = resolveHelperImage(cr, cr.Spec.InitImage, defaultInit)
+	if err != nil {
+		return nil, fmt.Errorf("init container image for MPI worker: %w", err)
+	}
+	images.sync, images.syncPullPolicy, err = resolveHelperImage(cr, cr.Spec.SyncImage, defaultSync)
+	if err != nil {
+		return nil, fmt.Errorf("sidecar container image for MPI worker: %w", err)
+	}
+
+	return images, nil
+}
+
+func resolveHelperImage(
+	cr *dcv1alpha1.MPICluster,
+	override *dcv1alpha1.OCIImageDefinition,
+	defaultImage string,
+) (string, corev1.PullPolicy, error) {
+	pullPolicy := cr.Spec.Image.PullPolicy // Same as in the main image by default!
+
+	if override == nil {
+		if defaultImage == "" {
+			return "", "", fmt.Errorf("image is not provided")
+		}
+		return defaultImage, pullPolicy, nil
+	}
+
+	image, err := util.ParseImageDefinition(override)
+	if err != nil {
+		return "", "", err
+	}
+	if override.PullPolicy != "" {
+		pullPolicy = override.PullPolicy
+	}
+
+	return image, pullPolicy, nil
+}
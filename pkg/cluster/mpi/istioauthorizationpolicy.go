@@ -0,0 +1,49 @@
+package mpi
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/metadata"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+)
+
+func IstioAuthorizationPolicy(istioMode istio.Mode) core.Component {
+	return components.IstioAuthorizationPolicy(func(obj client.Object) components.IstioAuthorizationPolicyDataSource {
+		return &istioAuthorizationPolicyDS{mpi: objToMPICluster(obj), istioMode: istioMode}
+	})
+}
+
+type istioAuthorizationPolicyDS struct {
+	mpi       *dcv1alpha1.MPICluster
+	istioMode istio.Mode
+}
+
+// AuthorizationPolicyInfo grants clients access to every worker port, which
+// mirrors the worker network policy.
+func (s *istioAuthorizationPolicyDS) AuthorizationPolicyInfo() *istio.AuthorizationPolicyInfo {
+	info := &istio.AuthorizationPolicyInfo{
+		Name:           meta.InstanceName(s.mpi, metadata.ComponentNone),
+		Namespace:      s.mpi.Namespace,
+		Labels:         meta.StandardLabels(s.mpi),
+		Selector:       meta.MatchLabels(s.mpi),
+		ServiceAccount: selectServiceAccount(s.mpi),
+		Waypoint:       istio.Waypoint(s.mpi.Spec.IstioConfig, s.istioMode),
+		Services:       (&istioTrafficDS{mpi: s.mpi, istioMode: s.istioMode}).TrafficInfo().Services,
+	}
+	if s.mpi.Spec.Istio != nil {
+		info.Config = s.mpi.Spec.Istio.Authorization
+	}
+
+	return info
+}
+
+func (s *istioAuthorizationPolicyDS) Enabled() bool {
+	return s.istioMode.Enabled()
+}
+
+func (s *istioAuthorizationPolicyDS) Delete() bool {
+	return !istio.AuthorizationEnabled(s.mpi.Spec.IstioConfig)
+}
@@ -0,0 +1,42 @@
+package mpi
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/metadata"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+)
+
+func IstioTraffic(istioMode istio.Mode) core.Component {
+	return components.IstioTraffic(func(obj client.Object) components.IstioTrafficDataSource {
+		return &istioTrafficDS{mpi: objToMPICluster(obj), istioMode: istioMode}
+	})
+}
+
+type istioTrafficDS struct {
+	mpi       *dcv1alpha1.MPICluster
+	istioMode istio.Mode
+}
+
+func (s *istioTrafficDS) TrafficInfo() *istio.TrafficInfo {
+	return &istio.TrafficInfo{
+		Name:      meta.InstanceName(s.mpi, metadata.ComponentNone),
+		Namespace: s.mpi.Namespace,
+		Labels:    meta.StandardLabels(s.mpi),
+		Selector:  meta.MatchLabels(s.mpi),
+		Services: []string{
+			serviceName(s.mpi, ComponentWorker),
+			serviceName(s.mpi, ComponentClient),
+			components.ClientPortsServiceName(s.mpi, meta),
+		},
+		Mode:   s.istioMode,
+		Config: s.mpi.Spec.Istio,
+	}
+}
+
+func (s *istioTrafficDS) Enabled() bool {
+	return s.istioMode.Enabled()
+}
@@ -7,6 +7,8 @@ import (
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/metadata"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/rankagent"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/kerberos"
 )
 
 const (
@@ -29,28 +31,60 @@ const (
 	defaultHomeDir   = "/mnt"
 
 	// SSH ports used by rsync sidecar
-	rsyncPort     = 2223
-	rsyncPortName = "tcp-rsync"
+	rsyncPort = 2223
 
-	// User and group for running the sidecar container;
-	// they should match a user provisioned in the sidecar image.
-	rsyncUserID  = 12574
-	rsyncGroupID = 12574
+	// Port of the rank agent used by the "agent" bootstrap mode
+	agentPort     = rankagent.DefaultPort
+	agentPortName = "tcp-agent"
+
+	// Location of the mounted rank agent token and its mode
+	agentTokenPath = "/etc/mpi/agent"
+	agentTokenMode = 0444 // octal!
+
+	// Rank agent binary unpacked by the init container
+	agentBinary = customUtilPath + "/bin/rank-agent"
 
 	// Name of an MPI hostfile; also a key in the config map and its prefix
 	hostFileName = "hostfile"
 
-	// Name of a Kerberos keytab file; also a key in the config map and its prefix
+	// Suffix of the config map that held inline Kerberos keytabs
 	keytabName = "keytab"
 
+	// Name of the worker volume holding the Kerberos keytab
+	kerberosKeytabVolume = "kerberos-keytab-volume"
+
 	// Period of rerunning resource finalizers
 	finalizerRetryPeriod = 1 * time.Second
 )
 
+func agentBootstrap(cr *dcv1alpha1.MPICluster) bool {
+	return cr.Spec.Bootstrap == dcv1alpha1.MPIBootstrapAgent
+}
+
+func agentSecretName(cr client.Object) string {
+	return meta.InstanceName(cr, "agent")
+}
+
 func configMapName(cr client.Object) string {
 	return meta.InstanceName(cr, "config")
 }
 
+// kerberosInfo describes the kerberos sidecar of worker pods.
+func kerberosInfo(cr *dcv1alpha1.MPICluster) *kerberos.Info {
+	return &kerberos.Info{
+		ConfigMapName: configMapName(cr) + "-kerberos",
+		Namespace:     cr.Namespace,
+		Labels:        meta.StandardLabels(cr),
+		Image:         cr.Spec.Image,
+		KeytabVolume:  kerberosKeytabVolume,
+		Config:        cr.Spec.Kerberos,
+	}
+}
+
+func keytabSecretName(cr client.Object) string {
+	return meta.InstanceName(cr, "keytab")
+}
+
 func selectServiceAccount(cr *dcv1alpha1.MPICluster) string {
 	if cr.Spec.ServiceAccount.Name != "" {
 		return cr.Spec.ServiceAccount.Name
@@ -59,6 +93,14 @@ func selectServiceAccount(cr *dcv1alpha1.MPICluster) string {
 	return serviceAccountName(cr)
 }
 
+func podGroupName(cr client.Object) string {
+	return meta.InstanceName(cr, metadata.ComponentNone)
+}
+
+func workloadName(cr client.Object) string {
+	return meta.InstanceName(cr, metadata.ComponentNone)
+}
+
 func serviceAccountName(cr client.Object) string {
 	return meta.InstanceName(cr, metadata.ComponentNone)
 }
@@ -72,6 +114,10 @@ func sshSecretName(cr *dcv1alpha1.MPICluster) string {
 	return worker.SharedSSHSecret
 }
 
+func syncSecretName(cr client.Object) string {
+	return meta.InstanceName(cr, "sync")
+}
+
 func workerStatefulSetName(cr client.Object) string {
 	return meta.InstanceName(cr, ComponentWorker)
 }
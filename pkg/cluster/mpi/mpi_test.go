@@ -0,0 +1,35 @@
+package mpi
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func testMPICluster() *dcv1alpha1.MPICluster {
+	return &dcv1alpha1.MPICluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "MPICluster",
+			APIVersion: "distributed-compute.dominodatalab.com/v1test1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "ns",
+		},
+		Spec: dcv1alpha1.MPIClusterSpec{
+			ClusterConfig: dcv1alpha1.ClusterConfig{
+				Image: &dcv1alpha1.OCIImageDefinition{
+					Repository: "mpi",
+					Tag:        "test-tag",
+				},
+			},
+		},
+	}
+}
+
+func testHelperImages() *helperImages {
+	return &helperImages{
+		init: "init:test",
+		sync: "sync:test",
+	}
+}
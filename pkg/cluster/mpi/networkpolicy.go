@@ -9,9 +9,12 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/metadata"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/actions"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/clientaccess"
 )
 
 func NetworkPolicyWorker() core.OwnedComponent {
@@ -26,6 +29,29 @@ func NetworkPolicyClient() core.OwnedComponent {
 	}
 }
 
+func NetworkPolicyEgress(istioEnabled bool) core.OwnedComponent {
+	return components.EgressNetworkPolicy(func(obj client.Object) components.EgressNetworkPolicyDataSource {
+		return &egressNetworkPolicyDS{cr: objToMPICluster(obj), istioEnabled: istioEnabled}
+	})
+}
+
+type egressNetworkPolicyDS struct {
+	cr           *dcv1alpha1.MPICluster
+	istioEnabled bool
+}
+
+func (s *egressNetworkPolicyDS) NetworkPolicyConfig() dcv1alpha1.NetworkPolicyConfig {
+	return s.cr.Spec.NetworkPolicy
+}
+
+func (s *egressNetworkPolicyDS) IstioEnabled() bool {
+	return s.istioEnabled
+}
+
+func (s *egressNetworkPolicyDS) Metadata() *metadata.Provider {
+	return meta
+}
+
 type networkPolicyComponent struct {
 	comp metadata.Component
 }
@@ -41,18 +67,13 @@ func (c networkPolicyComponent) Reconcile(ctx *core.Context) (ctrl.Result, error
 	switch c.comp {
 	case ComponentWorker:
 		podSelectorMatchLabels = matchLabels
-		ingressRules = []networkingv1.NetworkPolicyPeer{
+		ingressRules = append([]networkingv1.NetworkPolicyPeer{
 			{
 				PodSelector: &metav1.LabelSelector{
 					MatchLabels: matchLabels,
 				},
 			},
-			{
-				PodSelector: &metav1.LabelSelector{
-					MatchLabels: cr.Spec.NetworkPolicy.ClientLabels,
-				},
-			},
-		}
+		}, clientaccess.Peers(cr.Spec.NetworkPolicy)...)
 	case ComponentClient:
 		podSelectorMatchLabels = cr.Spec.NetworkPolicy.ClientLabels
 		ingressRules = []networkingv1.NetworkPolicyPeer{
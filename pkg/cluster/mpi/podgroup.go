@@ -0,0 +1,32 @@
+package mpi
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
+)
+
+func PodGroup() core.Component {
+	return components.PodGroup(func(obj client.Object) components.PodGroupDataSource {
+		return &podGroupDS{cr: objToMPICluster(obj)}
+	})
+}
+
+type podGroupDS struct {
+	cr *dcv1alpha1.MPICluster
+}
+
+// PodGroupInfo requires all workers to be schedulable together; the MPI
+// launcher runs outside the cluster.
+func (s *podGroupDS) PodGroupInfo() *podgroup.Info {
+	return &podgroup.Info{
+		Name:      podGroupName(s.cr),
+		Namespace: s.cr.Namespace,
+		Labels:    meta.StandardLabels(s.cr),
+		MinMember: podgroup.MinMember(0, s.cr.Spec.Worker.Replicas, nil),
+		Config:    s.cr.Spec.GangScheduling,
+	}
+}
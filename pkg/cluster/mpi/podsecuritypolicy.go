@@ -11,6 +11,7 @@ import (
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/metadata"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/actions"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
 )
 
@@ -21,11 +22,11 @@ var (
 )
 
 func RolePodSecurityPolicy() core.OwnedComponent {
-	return &podSecurityPolicyComponent{kind: &rbacv1.Role{}}
+	return components.PodSecurityPolicyRBAC(&podSecurityPolicyComponent{kind: &rbacv1.Role{}})
 }
 
 func RoleBindingPodSecurityPolicy() core.OwnedComponent {
-	return &podSecurityPolicyComponent{kind: &rbacv1.RoleBinding{}}
+	return components.PodSecurityPolicyRBAC(&podSecurityPolicyComponent{kind: &rbacv1.RoleBinding{}})
 }
 
 type podSecurityPolicyComponent struct {
@@ -0,0 +1,53 @@
+package mpi
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/kerberos"
+)
+
+func SyncSecret() core.OwnedComponent {
+	return components.SyncSecret(func(obj client.Object) components.SyncSecretDataSource {
+		return &syncSecretDS{cr: objToMPICluster(obj)}
+	})
+}
+
+type syncSecretDS struct {
+	cr *dcv1alpha1.MPICluster
+}
+
+func (s *syncSecretDS) SyncSecretInfo() *filesync.SecretInfo {
+	return &filesync.SecretInfo{
+		Name:      syncSecretName(s.cr),
+		Namespace: s.cr.Namespace,
+		Labels:    meta.StandardLabels(s.cr),
+	}
+}
+
+func (s *syncSecretDS) Delete() bool {
+	return false
+}
+
+func KeytabSecret() core.OwnedComponent {
+	return components.KeytabSecret(func(obj client.Object) components.KeytabSecretDataSource {
+		return &keytabSecretDS{cr: objToMPICluster(obj)}
+	})
+}
+
+type keytabSecretDS struct {
+	cr *dcv1alpha1.MPICluster
+}
+
+func (s *keytabSecretDS) KeytabInfo() *kerberos.KeytabInfo {
+	return &kerberos.KeytabInfo{
+		SecretName:    keytabSecretName(s.cr),
+		ConfigMapName: configMapName(s.cr) + "-" + keytabName,
+		Namespace:     s.cr.Namespace,
+		Labels:        meta.StandardLabels(s.cr),
+		Config:        s.cr.Spec.KerberosKeytab,
+	}
+}
@@ -0,0 +1,26 @@
+package mpi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
+)
+
+func TestSyncSecretDS_SyncSecretInfo(t *testing.T) {
+	ds := syncSecretDS{cr: testMPICluster()}
+
+	expected := &filesync.SecretInfo{
+		Name:      "test-mpi-sync",
+		Namespace: "ns",
+		Labels: map[string]string{
+			"app.kubernetes.io/instance":   "test",
+			"app.kubernetes.io/managed-by": "distributed-compute-operator",
+			"app.kubernetes.io/name":       "mpi",
+			"app.kubernetes.io/version":    "test-tag",
+		},
+	}
+	assert.Equal(t, expected, ds.SyncSecretInfo())
+	assert.False(t, ds.Delete())
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/metadata"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/actions"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
 )
 
 func ServiceWorker() core.OwnedComponent {
@@ -43,17 +44,27 @@ func (c serviceComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
 		selector = cr.Spec.NetworkPolicy.ClientLabels
 		extraLabels = map[string]string{}
 	case ComponentWorker:
-		ports = append(ports,
-			corev1.ServicePort{
-				Name:       sshdPortName,
-				Port:       sshdPort,
-				TargetPort: intstr.FromString(sshdPortName),
+		launchPort := corev1.ServicePort{
+			Name:       sshdPortName,
+			Port:       sshdPort,
+			TargetPort: intstr.FromString(sshdPortName),
+			Protocol:   corev1.ProtocolTCP,
+		}
+		if agentBootstrap(cr) {
+			launchPort = corev1.ServicePort{
+				Name:       agentPortName,
+				Port:       agentPort,
+				TargetPort: intstr.FromString(agentPortName),
 				Protocol:   corev1.ProtocolTCP,
-			},
+			}
+		}
+
+		ports = append(ports,
+			launchPort,
 			corev1.ServicePort{
-				Name:       rsyncPortName,
+				Name:       filesync.PortName,
 				Port:       rsyncPort,
-				TargetPort: intstr.FromString(rsyncPortName),
+				TargetPort: intstr.FromString(filesync.PortName),
 				Protocol:   corev1.ProtocolTCP,
 			})
 
@@ -15,6 +15,13 @@ import (
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/actions"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/rankagent"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/kerberos"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podsecurity"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/workload"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
 )
 
@@ -22,27 +29,33 @@ var (
 	workerCommand = []string{
 		"/bin/bash", "-l", "-c", "/opt/domino/mpi-cluster/bin/mpi-worker-start.sh",
 	}
-	sidecarCommand = []string{
-		"/opt/domino/rsync/bin/rsync-start.sh",
-	}
 	customizerCommand = []string{
 		"tar", "-C", "/", "-xf", "/root/worker-utils.tgz",
 	}
+
+	// Modules exported by the rsync sidecar when none are configured
+	defaultSyncModules = []dcv1alpha1.SyncModule{
+		{Name: "mnt", Path: "/mnt"},
+		{Name: "repos", Path: "/repos"},
+		{Name: "imported", Path: "/mnt/imported"},
+	}
 )
 
 // Key of the shared Secret object that contains client-side SSH public key
 const publicKeyField = "ssh-publickey"
 
-func StatefulSet(initImage, syncImage string) core.OwnedComponent {
+func StatefulSet(initImage, syncImage string, istioMode istio.Mode) core.OwnedComponent {
 	return &statefulSetComponent{
 		InitImage: initImage,
 		SyncImage: syncImage,
+		IstioMode: istioMode,
 	}
 }
 
 type statefulSetComponent struct {
 	InitImage string
 	SyncImage string
+	IstioMode istio.Mode
 }
 
 func (c statefulSetComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
@@ -53,21 +66,41 @@ func (c statefulSetComponent) Reconcile(ctx *core.Context) (ctrl.Result, error)
 		return ctrl.Result{}, fmt.Errorf("cannot parse workerImage: %w", err)
 	}
 
-	if c.InitImage == "" {
-		return ctrl.Result{}, fmt.Errorf("init container image for MPI worker is not provided")
+	images, err := resolveHelperImages(cr, c.InitImage, c.SyncImage)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
-	if c.SyncImage == "" {
-		return ctrl.Result{}, fmt.Errorf("sidecar container image for MPI worker is not provided")
+	if !agentBootstrap(cr) {
+		err = assureSharedKey(ctx, cr)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("invalid shared key: %w", err)
+		}
 	}
 
-	err = assureSharedKey(ctx, cr)
+	sts, err := newWorkerStatefulSet(cr, workerImage, images, c.IstioMode)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("invalid shared key: %w", err)
+		return ctrl.Result{}, err
 	}
+	err = actions.CreateOrUpdateOwnedResource(ctx, cr, sts)
+	if err != nil {
+		err = fmt.Errorf("cannot reconcile statefulset: %w", err)
+	}
+
+	return ctrl.Result{}, err
+}
 
+// newWorkerStatefulSet builds the stateful set running the MPI worker pods.
+func newWorkerStatefulSet(
+	cr *dcv1alpha1.MPICluster,
+	workerImage string,
+	images *helperImages,
+	istioMode istio.Mode,
+) (*appsv1.StatefulSet, error) {
 	worker := cr.Spec.Worker
 	labels := meta.StandardLabelsWithComponent(cr, ComponentWorker, worker.Labels)
+	podLabels := util.MergeStringMaps(istio.PodLabels(istioMode),
+		meta.StandardLabelsWithComponent(cr, ComponentWorker, worker.Labels))
 	serviceAccount := selectServiceAccount(cr)
 
 	initVolumes, initMounts := initVolumes()
@@ -77,6 +110,7 @@ func (c statefulSetComponent) Reconcile(ctx *core.Context) (ctrl.Result, error)
 	allVolumes = append(allVolumes, worker.Volumes...)
 	allVolumes = append(allVolumes, secretVolumes...)
 	allVolumes = append(allVolumes, initVolumes...)
+	allVolumes = append(allVolumes, filesync.NewAuthVolume(syncSecretName(cr)))
 
 	workerMounts := make([]corev1.VolumeMount, 0)
 	workerMounts = append(workerMounts, worker.VolumeMounts...)
@@ -88,7 +122,30 @@ func (c statefulSetComponent) Reconcile(ctx *core.Context) (ctrl.Result, error)
 
 	initContainers := make([]corev1.Container, 0)
 	initContainers = append(initContainers, worker.InitContainers...)
-	initContainers = append(initContainers, createInitContainer(cr, c.InitImage, initMounts))
+	initContainers = append(initContainers, createInitContainer(images, initMounts))
+
+	containers := []corev1.Container{
+		createWorkerContainer(cr, workerImage, workerMounts),
+		createSidecarContainer(cr, images, sidecarMounts),
+	}
+
+	if kerberos.Enabled(cr.Spec.Kerberos) {
+		info := kerberosInfo(cr)
+		initContainer, err := kerberos.InitContainer(info)
+		if err != nil {
+			return nil, err
+		}
+		sidecar, err := kerberos.Sidecar(info)
+		if err != nil {
+			return nil, err
+		}
+
+		allVolumes = append(allVolumes, kerberos.Volumes(info)...)
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, kerberos.VolumeMounts()...)
+		containers[0].Env = append(containers[0].Env, kerberos.Env()...)
+		initContainers = append(initContainers, initContainer)
+		containers = append(containers, sidecar)
+	}
 
 	sts := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -97,14 +154,14 @@ func (c statefulSetComponent) Reconcile(ctx *core.Context) (ctrl.Result, error)
 			Labels:    labels,
 		},
 		Spec: appsv1.StatefulSetSpec{
-			Replicas:    worker.Replicas,
+			Replicas:    workload.Replicas(&cr.Status.ClusterStatusConfig, worker.Replicas),
 			ServiceName: serviceName(cr, ComponentWorker),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: meta.MatchLabelsWithComponent(cr, ComponentWorker),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      labels,
+					Labels:      podLabels,
 					Annotations: worker.Annotations,
 				},
 				Spec: corev1.PodSpec{
@@ -116,23 +173,21 @@ func (c statefulSetComponent) Reconcile(ctx *core.Context) (ctrl.Result, error)
 					ImagePullSecrets:   cr.Spec.ImagePullSecrets,
 					SecurityContext:    cr.Spec.PodSecurityContext,
 					Volumes:            allVolumes,
-					Containers: []corev1.Container{
-						createWorkerContainer(cr, workerImage, workerMounts),
-						createSidecarContainer(cr, c.SyncImage, sidecarMounts),
-					},
+					Containers:         containers,
 				},
 			},
 			VolumeClaimTemplates: persistentVolumeClaims(worker.VolumeClaimTemplates),
 			PodManagementPolicy:  appsv1.ParallelPodManagement,
 		},
 	}
-
-	err = actions.CreateOrUpdateOwnedResource(ctx, cr, sts)
-	if err != nil {
-		err = fmt.Errorf("cannot reconcile statefulset: %w", err)
+	if cr.Spec.SecurityProfile == dcv1alpha1.SecurityProfileRestricted {
+		runHelpersAsWorkerUser(sts.Spec.Template.Spec.InitContainers[len(worker.InitContainers):])
+		runHelpersAsWorkerUser(sts.Spec.Template.Spec.Containers[1:])
 	}
+	podsecurity.Harden(cr.Spec.SecurityProfile, &sts.Spec.Template.Spec)
+	podgroup.ConfigurePodTemplate(cr.Spec.GangScheduling, podGroupName(cr), &sts.Spec.Template)
 
-	return ctrl.Result{}, err
+	return sts, nil
 }
 
 func (c statefulSetComponent) Finalize(ctx *core.Context) (ctrl.Result, bool, error) {
@@ -190,13 +245,33 @@ func initVolumes() ([]corev1.Volume, []corev1.VolumeMount) {
 
 func secretVolumes(cr *dcv1alpha1.MPICluster) ([]corev1.Volume, []corev1.VolumeMount) {
 	const authorizedKeysVolume = "authorized-keys-volume"
-	const kerberosKeytabVolume = "kerberos-keytab-volume"
+	const agentTokenVolume = "agent-token-volume"
 
-	authorizedKeysModeCopy := int32(authorizedKeysMode)
-	authorizedKeysName := filepath.Base(authorizedKeysPath)
+	var volumes []corev1.Volume
+	var mounts []corev1.VolumeMount
 
-	volumes := []corev1.Volume{
-		{
+	if agentBootstrap(cr) {
+		agentTokenModeCopy := int32(agentTokenMode)
+
+		volumes = append(volumes, corev1.Volume{
+			Name: agentTokenVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  agentSecretName(cr),
+					DefaultMode: &agentTokenModeCopy,
+				},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      agentTokenVolume,
+			ReadOnly:  true,
+			MountPath: agentTokenPath,
+		})
+	} else {
+		authorizedKeysModeCopy := int32(authorizedKeysMode)
+		authorizedKeysName := filepath.Base(authorizedKeysPath)
+
+		volumes = append(volumes, corev1.Volume{
 			Name: authorizedKeysVolume,
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
@@ -210,28 +285,19 @@ func secretVolumes(cr *dcv1alpha1.MPICluster) ([]corev1.Volume, []corev1.VolumeM
 					DefaultMode: &authorizedKeysModeCopy,
 				},
 			},
-		},
-	}
-	mounts := []corev1.VolumeMount{
-		{
+		})
+		mounts = append(mounts, corev1.VolumeMount{
 			Name:      authorizedKeysVolume,
 			ReadOnly:  true,
 			MountPath: authorizedKeysPath,
 			SubPath:   authorizedKeysName,
-		},
+		})
 	}
 
 	if cr.Spec.KerberosKeytab != nil {
-		volumes = append(volumes, corev1.Volume{
-			Name: kerberosKeytabVolume,
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: configMapName(cr) + "-" + keytabName,
-					},
-				},
-			},
-		})
+		volumes = append(volumes, kerberos.NewKeytabVolume(
+			kerberosKeytabVolume, cr.Spec.KerberosKeytab, keytabSecretName(cr),
+		))
 		mounts = append(mounts, corev1.VolumeMount{
 			Name:      kerberosKeytabVolume,
 			ReadOnly:  true,
@@ -295,10 +361,6 @@ func workerEnvironmentExtras(cr *dcv1alpha1.MPICluster) []corev1.EnvVar {
 	if cr.Spec.Worker.GroupName != "" {
 		groupName = cr.Spec.Worker.GroupName
 	}
-	homeDir := defaultHomeDir
-	if cr.Spec.Worker.HomeDir != "" {
-		homeDir = cr.Spec.Worker.HomeDir
-	}
 	return []corev1.EnvVar{
 		{
 			Name:  "DOMINO_SSH_PORT",
@@ -326,35 +388,62 @@ func workerEnvironmentExtras(cr *dcv1alpha1.MPICluster) []corev1.EnvVar {
 		},
 		{
 			Name:  "DOMINO_HOME_DIR",
-			Value: homeDir,
+			Value: workerHomeDir(cr),
 		},
 	}
 }
 
+func workerHomeDir(cr *dcv1alpha1.MPICluster) string {
+	if cr.Spec.Worker.HomeDir != "" {
+		return cr.Spec.Worker.HomeDir
+	}
+	return defaultHomeDir
+}
+
+// agentCommand runs the rank agent directly, without a login shell, so that
+// it works in images without bash and under any non-root user.
+func agentCommand(cr *dcv1alpha1.MPICluster) []string {
+	return []string{
+		agentBinary, "serve",
+		"--port", strconv.Itoa(agentPort),
+		"--token-file", filepath.Join(agentTokenPath, rankagent.TokenKey),
+		"--dir", workerHomeDir(cr),
+	}
+}
+
 func createWorkerContainer(cr *dcv1alpha1.MPICluster, image string, mounts []corev1.VolumeMount) corev1.Container {
 	environment := make([]corev1.EnvVar, 0)
 	environment = append(environment, cr.Spec.EnvVars...)
-	environment = append(environment, workerEnvironmentExtras(cr)...)
+
+	command := workerCommand
+	port := corev1.ContainerPort{
+		Name:          sshdPortName,
+		ContainerPort: sshdPort,
+	}
+	if agentBootstrap(cr) {
+		command = agentCommand(cr)
+		port = corev1.ContainerPort{
+			Name:          agentPortName,
+			ContainerPort: agentPort,
+		}
+	} else {
+		environment = append(environment, workerEnvironmentExtras(cr)...)
+	}
 
 	probe := &corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
 			TCPSocket: &corev1.TCPSocketAction{
-				Port: intstr.FromInt(sshdPort),
+				Port: intstr.FromInt(int(port.ContainerPort)),
 			},
 		},
 	}
 
 	return corev1.Container{
 		Name:            ApplicationName,
-		Command:         workerCommand,
+		Command:         command,
 		Image:           image,
 		ImagePullPolicy: cr.Spec.Image.PullPolicy,
-		Ports: []corev1.ContainerPort{
-			{
-				Name:          sshdPortName,
-				ContainerPort: sshdPort,
-			},
-		},
+		Ports:           []corev1.ContainerPort{port},
 		Env:             environment,
 		VolumeMounts:    mounts,
 		Resources:       cr.Spec.Worker.Resources,
@@ -364,51 +453,51 @@ func createWorkerContainer(cr *dcv1alpha1.MPICluster, image string, mounts []cor
 	}
 }
 
-func createSidecarContainer(cr *dcv1alpha1.MPICluster, image string, mounts []corev1.VolumeMount) corev1.Container {
-	probe := &corev1.Probe{
-		ProbeHandler: corev1.ProbeHandler{
-			TCPSocket: &corev1.TCPSocketAction{
-				Port: intstr.FromInt(rsyncPort),
-			},
-		},
+func createSidecarContainer(cr *dcv1alpha1.MPICluster, images *helperImages, mounts []corev1.VolumeMount) corev1.Container {
+	modules := cr.Spec.Worker.SyncModules
+	if len(modules) == 0 {
+		modules = defaultSyncModules
 	}
 
-	user := int64(rsyncUserID)
-	group := int64(rsyncGroupID)
-
-	return corev1.Container{
-		Name:            ApplicationName + "-sync",
-		Command:         sidecarCommand,
-		Image:           image,
-		ImagePullPolicy: cr.Spec.Image.PullPolicy, // Same as in the main image!
-		Ports: []corev1.ContainerPort{
-			{
-				Name:          rsyncPortName,
-				ContainerPort: rsyncPort,
-			},
-		},
-		Env: []corev1.EnvVar{
-			{
-				Name:  "RSYNC_PORT",
-				Value: strconv.FormatInt(rsyncPort, 10),
-			},
-		},
+	return filesync.NewSidecar(&filesync.SidecarInfo{
+		Name:         ApplicationName + filesync.ContainerSuffix,
+		Image:        images.sync,
+		PullPolicy:   images.syncPullPolicy,
+		Port:         rsyncPort,
+		SecretName:   syncSecretName(cr),
+		Modules:      modules,
 		VolumeMounts: mounts,
-		SecurityContext: &corev1.SecurityContext{
-			RunAsUser:  &user,
-			RunAsGroup: &group,
-		},
-		LivenessProbe:  probe,
-		ReadinessProbe: probe,
+	})
+}
+
+// runHelpersAsWorkerUser runs the operator helper containers as the default
+// worker user unless a user is already set, because the restricted profile
+// rejects containers that would run as root.
+func runHelpersAsWorkerUser(containers []corev1.Container) {
+	userID := int64(defaultUserID)
+	groupID := int64(defaultGroupID)
+
+	for idx := range containers {
+		sc := containers[idx].SecurityContext.DeepCopy()
+		if sc == nil {
+			sc = &corev1.SecurityContext{}
+		}
+		if sc.RunAsUser == nil {
+			sc.RunAsUser = &userID
+		}
+		if sc.RunAsGroup == nil {
+			sc.RunAsGroup = &groupID
+		}
+		containers[idx].SecurityContext = sc
 	}
 }
 
-func createInitContainer(cr *dcv1alpha1.MPICluster, image string, mounts []corev1.VolumeMount) corev1.Container {
+func createInitContainer(images *helperImages, mounts []corev1.VolumeMount) corev1.Container {
 	return corev1.Container{
 		Name:            ApplicationName + "-init",
 		Command:         customizerCommand,
-		Image:           image,
-		ImagePullPolicy: cr.Spec.Image.PullPolicy, // Same as in the main image!
+		Image:           images.init,
+		ImagePullPolicy: images.initPullPolicy,
 		VolumeMounts:    mounts,
 	}
 }
@@ -0,0 +1,123 @@
+package mpi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+)
+
+func findContainer(t *testing.T, containers []corev1.Container, name string) corev1.Container {
+	t.Helper()
+
+	for _, c := range containers {
+		if c.Name == name {
+			return c
+		}
+	}
+	require.Failf(t, "container not found", "name: %s", name)
+
+	return corev1.Container{}
+}
+
+func findEnv(t *testing.T, container corev1.Container, name string) string {
+	t.Helper()
+
+	for _, env := range container.Env {
+		if env.Name == name {
+			return env.Value
+		}
+	}
+	require.Failf(t, "env var not found", "container: %s, name: %s", container.Name, name)
+
+	return ""
+}
+
+func TestNewWorkerStatefulSet_SyncSidecar(t *testing.T) {
+	t.Run("default_modules", func(t *testing.T) {
+		sts, err := newWorkerStatefulSet(testMPICluster(), "mpi:test-tag", testHelperImages(), istio.ModeSidecar)
+		require.NoError(t, err)
+
+		sidecar := findContainer(t, sts.Spec.Template.Spec.Containers, "mpi-sync")
+		assert.Equal(t, "sync:test", sidecar.Image)
+		assert.Equal(t, "2223", findEnv(t, sidecar, "RSYNC_PORT"))
+		assert.Equal(t, "/etc/rsync", findEnv(t, sidecar, "RSYNC_AUTH_DIR"))
+		assert.Equal(t, "mnt:/mnt:rw repos:/repos:rw imported:/mnt/imported:rw", findEnv(t, sidecar, "RSYNC_MODULES"))
+		assert.Contains(t, sidecar.VolumeMounts, corev1.VolumeMount{
+			Name:      "sync-auth",
+			ReadOnly:  true,
+			MountPath: "/etc/rsync",
+		})
+	})
+
+	t.Run("custom_modules", func(t *testing.T) {
+		cr := testMPICluster()
+		cr.Spec.Worker.SyncModules = []dcv1alpha1.SyncModule{
+			{Name: "data", Path: "/data", ReadOnly: true},
+			{Name: "out", Path: "/out"},
+		}
+
+		sts, err := newWorkerStatefulSet(cr, "mpi:test-tag", testHelperImages(), istio.ModeSidecar)
+		require.NoError(t, err)
+
+		sidecar := findContainer(t, sts.Spec.Template.Spec.Containers, "mpi-sync")
+		assert.Equal(t, "data:/data:ro out:/out:rw", findEnv(t, sidecar, "RSYNC_MODULES"))
+	})
+
+	t.Run("auth_volume", func(t *testing.T) {
+		sts, err := newWorkerStatefulSet(testMPICluster(), "mpi:test-tag", testHelperImages(), istio.ModeSidecar)
+		require.NoError(t, err)
+
+		var volume *corev1.Volume
+		for i := range sts.Spec.Template.Spec.Volumes {
+			if sts.Spec.Template.Spec.Volumes[i].Name == "sync-auth" {
+				volume = &sts.Spec.Template.Spec.Volumes[i]
+			}
+		}
+		require.NotNil(t, volume)
+		require.NotNil(t, volume.Secret)
+		assert.Equal(t, "test-mpi-sync", volume.Secret.SecretName)
+	})
+}
+
+func TestNewWorkerStatefulSet_Restricted(t *testing.T) {
+	cr := testMPICluster()
+	cr.Spec.SecurityProfile = dcv1alpha1.SecurityProfileRestricted
+	cr.Spec.Bootstrap = dcv1alpha1.MPIBootstrapAgent
+	cr.Spec.Worker.InitContainers = []corev1.Container{{Name: "user-init"}}
+
+	sts, err := newWorkerStatefulSet(cr, "mpi:test-tag", testHelperImages(), istio.ModeSidecar)
+	require.NoError(t, err)
+
+	spec := sts.Spec.Template.Spec
+	require.NotNil(t, spec.SecurityContext)
+	assert.Equal(t, pointer.Bool(true), spec.SecurityContext.RunAsNonRoot)
+
+	initSC := findContainer(t, spec.InitContainers, "mpi-init").SecurityContext
+	require.NotNil(t, initSC)
+	assert.Equal(t, pointer.Int64(12574), initSC.RunAsUser)
+	assert.Equal(t, pointer.Int64(12574), initSC.RunAsGroup)
+	assert.Equal(t, pointer.Bool(false), initSC.AllowPrivilegeEscalation)
+
+	syncSC := findContainer(t, spec.Containers, "mpi-sync").SecurityContext
+	require.NotNil(t, syncSC)
+	assert.Equal(t, pointer.Int64(12574), syncSC.RunAsUser)
+
+	userInit := findContainer(t, spec.InitContainers, "user-init").SecurityContext
+	require.NotNil(t, userInit)
+	assert.Nil(t, userInit.RunAsUser)
+	assert.Nil(t, findContainer(t, spec.Containers, "mpi").SecurityContext.RunAsUser)
+
+	t.Run("baseline", func(t *testing.T) {
+		cr := testMPICluster()
+
+		sts, err := newWorkerStatefulSet(cr, "mpi:test-tag", testHelperImages(), istio.ModeSidecar)
+		require.NoError(t, err)
+		assert.Nil(t, findContainer(t, sts.Spec.Template.Spec.InitContainers, "mpi-init").SecurityContext)
+	})
+}
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"time"
 
 	"k8s.io/apimachinery/pkg/types"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -16,6 +19,11 @@ import (
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/accounting"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/clientaccess"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/metering"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
 )
 
@@ -27,11 +35,20 @@ const (
 // This map is used as a set: value are irrelevant.
 var runningPods = map[types.UID]interface{}{}
 
-func StatusUpdate() core.Component {
-	return &statusUpdateComponent{}
+func StatusUpdate(initImage, syncImage string, sink metering.Sink) core.Component {
+	return &statusUpdateComponent{
+		InitImage: initImage,
+		SyncImage: syncImage,
+		UsageSink: sink,
+	}
 }
 
-type statusUpdateComponent struct{}
+type statusUpdateComponent struct {
+	InitImage string
+	SyncImage string
+	// UsageSink receives the final usage of deleted clusters when not nil.
+	UsageSink metering.Sink
+}
 
 func (c statusUpdateComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
 	cr := objToMPICluster(ctx.Object)
@@ -47,6 +64,44 @@ func (c statusUpdateComponent) Reconcile(ctx *core.Context) (ctrl.Result, error)
 		modified = true
 	}
 
+	images, err := resolveHelperImages(cr, c.InitImage, c.SyncImage)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if cr.Status.InitImage != images.init {
+		cr.Status.InitImage = images.init
+		modified = true
+	}
+	if cr.Status.SyncImage != images.sync {
+		cr.Status.SyncImage = images.sync
+		modified = true
+	}
+
+	var agentSecret *corev1.LocalObjectReference
+	if agentBootstrap(cr) {
+		agentSecret = &corev1.LocalObjectReference{Name: agentSecretName(cr)}
+	}
+	if !reflect.DeepEqual(agentSecret, cr.Status.AgentSecret) {
+		cr.Status.AgentSecret = agentSecret
+		modified = true
+	}
+
+	np := cr.Spec.NetworkPolicy
+	clientPeers := clientaccess.StatusPeers(np, clientaccess.Peers(np))
+	if !equality.Semantic.DeepEqual(clientPeers, cr.Status.ClientPeers) {
+		cr.Status.ClientPeers = clientPeers
+		modified = true
+	}
+
+	podGroupPhase, err := podgroup.FetchPhase(ctx, ctx.Client, cr.Spec.GangScheduling, podGroupName(cr), cr.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if cr.Status.PodGroupPhase != podGroupPhase {
+		cr.Status.PodGroupPhase = podGroupPhase
+		modified = true
+	}
+
 	pods, err := getPods(ctx, cr)
 	if err != nil && !apierrors.IsNotFound(err) {
 		return ctrl.Result{}, fmt.Errorf("cannot list cluster pods: %w", err)
@@ -77,10 +132,40 @@ func (c statusUpdateComponent) Reconcile(ctx *core.Context) (ctrl.Result, error)
 		modified = true
 	}
 
+	if filesync.UpdateStatus(&cr.Status.ClusterStatusConfig, syncSecretName(cr), pods, ApplicationName+filesync.ContainerSuffix) {
+		modified = true
+	}
+
+	worker := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workerStatefulSetName(cr),
+			Namespace: cr.Namespace,
+		},
+	}
+	if err = ctx.Client.Get(ctx, client.ObjectKeyFromObject(worker), worker); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, err
+	}
+	resources, err := accounting.Compute(nil, worker, *cr.Spec.Worker.Replicas, pods)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot compute cluster resources: %w", err)
+	}
+	if !equality.Semantic.DeepEqual(resources, cr.Status.Resources) {
+		cr.Status.Resources = resources
+		modified = true
+	}
+	accounting.UpdateMetrics("MPICluster", cr.Namespace, cr.Name, resources)
+
+	if now := time.Now(); len(pods) > 0 && metering.Due(cr.Status.Usage, now) {
+		cr.Status.Usage = metering.Accumulate(cr.Status.Usage, pods, now)
+		modified = true
+	}
+
 	expectedPodCnt := int(*cr.Spec.Worker.Replicas)
 
 	var status dcv1alpha1.ClusterStatusType
 	switch {
+	case cr.Status.Queued:
+		status = dcv1alpha1.QueuedStatus
 	case failureReason != "":
 		status = dcv1alpha1.FailedStatus
 	case runningPodCnt >= expectedPodCnt:
@@ -111,27 +196,34 @@ func (c statusUpdateComponent) Reconcile(ctx *core.Context) (ctrl.Result, error)
 	}
 
 	requeue := status != dcv1alpha1.RunningStatus && status != dcv1alpha1.FailedStatus
+	if !requeue && len(pods) > 0 {
+		// usage is accumulated periodically while pods exist
+		return ctrl.Result{RequeueAfter: metering.PersistPeriod}, nil
+	}
 	return ctrl.Result{Requeue: requeue}, nil
 }
 
 func (c statusUpdateComponent) Finalize(ctx *core.Context) (ctrl.Result, bool, error) {
 	cr := objToMPICluster(ctx.Object)
 
-	if cr.Status.ClusterStatus != dcv1alpha1.StoppingStatus {
+	pods, err := getPods(ctx, cr)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{RequeueAfter: finalizerRetryPeriod}, false,
+			fmt.Errorf("cannot list cluster pods: %w", err)
+	}
+	usage := metering.Accumulate(cr.Status.Usage, pods, time.Now())
+
+	if cr.Status.ClusterStatus != dcv1alpha1.StoppingStatus || metering.Due(cr.Status.Usage, time.Now()) {
 		cr.Status.ClusterStatus = dcv1alpha1.StoppingStatus
 		cr.Status.StartTime = nil
-		err := ctx.Client.Status().Update(ctx, cr)
+		cr.Status.Usage = usage
+		err = ctx.Client.Status().Update(ctx, cr)
 		if err != nil {
 			return ctrl.Result{RequeueAfter: finalizerRetryPeriod}, false,
 				fmt.Errorf("cannot update cluster status: %w", err)
 		}
 	}
 
-	pods, err := getPods(ctx, cr)
-	if err != nil && !apierrors.IsNotFound(err) {
-		return ctrl.Result{RequeueAfter: finalizerRetryPeriod}, false,
-			fmt.Errorf("cannot list cluster pods: %w", err)
-	}
 	podCnt := len(pods)
 	if podCnt != 0 {
 		return ctrl.Result{RequeueAfter: finalizerRetryPeriod}, false, nil
@@ -139,6 +231,11 @@ func (c statusUpdateComponent) Finalize(ctx *core.Context) (ctrl.Result, bool, e
 	for uid := range runningPods {
 		delete(runningPods, uid)
 	}
+	accounting.DeleteMetrics("MPICluster", cr.Namespace, cr.Name)
+
+	if err = metering.Report(ctx, ctx.Recorder, c.UsageSink, "MPICluster", cr, usage); err != nil {
+		ctx.Log.Error(err, "Cannot send final usage record")
+	}
 	return ctrl.Result{}, true, nil
 }
 
@@ -0,0 +1,68 @@
+package mpi
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/workload"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+func Workload(initImage, syncImage string, istioMode istio.Mode) core.Component {
+	return components.Workload(func(obj client.Object) components.WorkloadDataSource {
+		return &workloadDS{cr: objToMPICluster(obj), initImage: initImage, syncImage: syncImage, istioMode: istioMode}
+	})
+}
+
+type workloadDS struct {
+	cr        *dcv1alpha1.MPICluster
+	initImage string
+	syncImage string
+	istioMode istio.Mode
+}
+
+// WorkloadInfo only describes the workers; the MPI launcher runs outside the
+// cluster.
+func (s *workloadDS) WorkloadInfo() (*workload.Info, error) {
+	info := &workload.Info{
+		Name:      workloadName(s.cr),
+		Namespace: s.cr.Namespace,
+		Labels:    meta.StandardLabels(s.cr),
+		QueueName: workload.QueueName(s.cr),
+	}
+	if info.QueueName == "" {
+		return info, nil
+	}
+
+	workerImage, err := util.ParseImageDefinition(s.cr.Spec.Image)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse workerImage: %w", err)
+	}
+	images, err := resolveHelperImages(s.cr, s.initImage, s.syncImage)
+	if err != nil {
+		return nil, err
+	}
+
+	worker, err := newWorkerStatefulSet(s.cr, workerImage, images, s.istioMode)
+	if err != nil {
+		return nil, err
+	}
+	info.PodSets = []workload.PodSet{
+		{
+			Name:     string(ComponentWorker),
+			Count:    workload.WorkerCount(s.cr.Spec.Worker.Replicas, nil),
+			Template: worker.Spec.Template,
+		},
+	}
+
+	return info, nil
+}
+
+func (s *workloadDS) ClusterStatusConfig() *dcv1alpha1.ClusterStatusConfig {
+	return &s.cr.Status.ClusterStatusConfig
+}
@@ -24,12 +24,18 @@ const (
 
 func newResourceMeta(obj *client.Object, componentMeta *metadata.Provider) metav1.ObjectMeta {
 	return metav1.ObjectMeta{
-		Name:      componentMeta.InstanceName(*obj, component),
+		Name:      ClientPortsServiceName(*obj, componentMeta),
 		Namespace: (*obj).GetNamespace(),
 		Labels:    componentMeta.StandardLabelsWithComponent(*obj, component, nil),
 	}
 }
 
+// ClientPortsServiceName returns the name of the service that exposes the
+// additional client ports.
+func ClientPortsServiceName(obj client.Object, componentMeta *metadata.Provider) string {
+	return componentMeta.InstanceName(obj, component)
+}
+
 func NewClientPortsServiceComponent(
 	obj *client.Object,
 	ports []corev1.ServicePort,
@@ -9,33 +9,60 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/accounting"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/metering"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
 )
 
 type ClusterStatusUpdateDataSource interface {
 	ListOpts() []client.ListOption
 	StatefulSet() *appsv1.StatefulSet
+	// HeadStatefulSet is nil when the cluster has no head node.
+	HeadStatefulSet() *appsv1.StatefulSet
+	// MaxWorkers is the number of workers when the cluster is fully scaled.
+	MaxWorkers() int32
 	ClusterStatusConfig() *dcv1alpha1.ClusterStatusConfig
 	Image() *dcv1alpha1.OCIImageDefinition
+	// SyncContainerName and SyncSecretName are blank when file sync is disabled.
+	SyncContainerName() string
+	SyncSecretName() string
+	// GangScheduling and PodGroupName locate the PodGroup whose phase is reported.
+	GangScheduling() *dcv1alpha1.GangSchedulingConfig
+	PodGroupName() string
+	// DashboardURL is blank when the dashboard is not exposed.
+	DashboardURL() (string, error)
+	// ClientPeers is nil when network policies are disabled.
+	ClientPeers() []networkingv1.NetworkPolicyPeer
 }
 
-const finalizerRetryPeriod = 1 * time.Second
+const (
+	finalizerRetryPeriod = 1 * time.Second
+	podGroupPollPeriod   = 10 * time.Second
+)
 
 type ClusterStatusUpdateDataSourceFactory func(client.Object) ClusterStatusUpdateDataSource
 
-func ClusterStatusUpdate(f ClusterStatusUpdateDataSourceFactory) core.Component {
-	return &clusterStatusUpdateComponent{factory: f}
+// ClusterStatusUpdate reports the observed state of a cluster. The final usage
+// of deleted clusters is sent to sink when it is not nil.
+func ClusterStatusUpdate(f ClusterStatusUpdateDataSourceFactory, sink metering.Sink) core.Component {
+	return &clusterStatusUpdateComponent{factory: f, sink: sink}
 }
 
 type clusterStatusUpdateComponent struct {
 	factory func(client.Object) ClusterStatusUpdateDataSource
+	sink    metering.Sink
 }
 
 func (c *clusterStatusUpdateComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
@@ -68,6 +95,22 @@ func (c *clusterStatusUpdateComponent) Reconcile(ctx *core.Context) (ctrl.Result
 		modified = true
 	}
 
+	// report file sync sidecar state
+	if filesync.UpdateStatus(csc, ds.SyncSecretName(), podList.Items, ds.SyncContainerName()) {
+		modified = true
+	}
+
+	// report gang scheduling state
+	gs := ds.GangScheduling()
+	phase, err := podgroup.FetchPhase(ctx, ctx.Client, gs, ds.PodGroupName(), ctx.Object.GetNamespace())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if csc.PodGroupPhase != phase {
+		csc.PodGroupPhase = phase
+		modified = true
+	}
+
 	// modify scale subresource fields
 	sts := ds.StatefulSet()
 	if err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(sts), sts); client.IgnoreNotFound(err) != nil {
@@ -88,6 +131,51 @@ func (c *clusterStatusUpdateComponent) Reconcile(ctx *core.Context) (ctrl.Result
 		modified = true
 	}
 
+	// total requested and limited resources
+	head := ds.HeadStatefulSet()
+	if head != nil {
+		if err = ctx.Client.Get(ctx, client.ObjectKeyFromObject(head), head); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	resources, err := accounting.Compute(head, sts, ds.MaxWorkers(), podList.Items)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot compute cluster resources: %w", err)
+	}
+	if !equality.Semantic.DeepEqual(resources, csc.Resources) {
+		csc.Resources = resources
+		modified = true
+	}
+
+	gvk, err := apiutil.GVKForObject(ctx.Object, ctx.Scheme)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	accounting.UpdateMetrics(gvk.Kind, ctx.Object.GetNamespace(), ctx.Object.GetName(), resources)
+
+	// accumulate usage periodically while pods exist
+	if now := time.Now(); len(podList.Items) > 0 && metering.Due(csc.Usage, now) {
+		csc.Usage = metering.Accumulate(csc.Usage, podList.Items, now)
+		modified = true
+	}
+
+	// publish the external dashboard url
+	dashboardURL, err := ds.DashboardURL()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot build dashboard url: %w", err)
+	}
+	if csc.DashboardURL != dashboardURL {
+		csc.DashboardURL = dashboardURL
+		modified = true
+	}
+
+	// publish the peers granted access to client ports
+	clientPeers := ds.ClientPeers()
+	if !equality.Semantic.DeepEqual(clientPeers, csc.ClientPeers) {
+		csc.ClientPeers = clientPeers
+		modified = true
+	}
+
 	// store canonical image reference
 	image, err := util.ParseImageDefinition(ds.Image())
 	if err != nil {
@@ -99,14 +187,15 @@ func (c *clusterStatusUpdateComponent) Reconcile(ctx *core.Context) (ctrl.Result
 	}
 
 	var status dcv1alpha1.ClusterStatusType
-	if masterPodFound {
-		if dcv1alpha1.IsPodReady(masterPod) {
-			status = dcv1alpha1.RunningStatus
-		} else {
-			status = dcv1alpha1.StartingStatus
-		}
-	} else {
+	switch {
+	case csc.Queued:
+		status = dcv1alpha1.QueuedStatus
+	case !masterPodFound:
 		status = dcv1alpha1.PendingStatus
+	case dcv1alpha1.IsPodReady(masterPod):
+		status = dcv1alpha1.RunningStatus
+	default:
+		status = dcv1alpha1.StartingStatus
 	}
 	if csc.ClusterStatus != status && ctx.Object.GetDeletionTimestamp() == nil {
 		modified = true
@@ -124,16 +213,32 @@ func (c *clusterStatusUpdateComponent) Reconcile(ctx *core.Context) (ctrl.Result
 		err = ctx.Client.Status().Update(ctx, ctx.Object)
 	}
 
-	return ctrl.Result{}, err
+	// pod group changes are not watched and usage must be accumulated
+	var result ctrl.Result
+	if podgroup.Enabled(gs) && !podgroup.Settled(phase) {
+		result.RequeueAfter = podGroupPollPeriod
+	} else if len(podList.Items) > 0 {
+		result.RequeueAfter = metering.PersistPeriod
+	}
+
+	return result, err
 }
 
 func (c clusterStatusUpdateComponent) Finalize(ctx *core.Context) (ctrl.Result, bool, error) {
 	ds := c.factory(ctx.Object)
 	csc := ds.ClusterStatusConfig()
 
+	podList := &corev1.PodList{}
+	if err := ctx.Client.List(ctx, podList, ds.ListOpts()...); err != nil {
+		return ctrl.Result{RequeueAfter: finalizerRetryPeriod}, false,
+			fmt.Errorf("cannot list cluster pods: %w", err)
+	}
+	usage := metering.Accumulate(csc.Usage, podList.Items, time.Now())
+
 	if csc.ClusterStatus != dcv1alpha1.StoppingStatus {
 		csc.ClusterStatus = dcv1alpha1.StoppingStatus
 		csc.StartTime = nil
+		csc.Usage = usage
 		err := ctx.Client.Status().Update(ctx, ctx.Object)
 		if err != nil {
 			return ctrl.Result{RequeueAfter: finalizerRetryPeriod}, false,
@@ -141,5 +246,15 @@ func (c clusterStatusUpdateComponent) Finalize(ctx *core.Context) (ctrl.Result,
 		}
 	}
 
+	gvk, err := apiutil.GVKForObject(ctx.Object, ctx.Scheme)
+	if err != nil {
+		return ctrl.Result{}, false, err
+	}
+	accounting.DeleteMetrics(gvk.Kind, ctx.Object.GetNamespace(), ctx.Object.GetName())
+
+	if err = metering.Report(ctx, ctx.Recorder, c.sink, gvk.Kind, ctx.Object, usage); err != nil {
+		ctx.Log.Error(err, "Cannot send final usage record")
+	}
+
 	return ctrl.Result{}, true, nil
 }
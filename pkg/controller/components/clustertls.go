@@ -0,0 +1,173 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/actions"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/clustertls"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;create;update;delete
+
+type ClusterTLSDataSource interface {
+	// ClusterTLSInfo describes the cluster certificates; its config may disable TLS.
+	ClusterTLSInfo() *clustertls.Info
+}
+
+type ClusterTLSDataSourceFactory func(client.Object) ClusterTLSDataSource
+
+// ClusterTLS manages the certificates of a cluster. It is not an owned
+// component because cert-manager APIs are optional and cannot always be watched.
+func ClusterTLS(f ClusterTLSDataSourceFactory) core.Component {
+	return &clusterTLSComponent{factory: f}
+}
+
+type clusterTLSComponent struct {
+	factory ClusterTLSDataSourceFactory
+}
+
+func (c *clusterTLSComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
+	info := c.factory(ctx.Object).ClusterTLSInfo()
+
+	return ctrl.Result{}, ReconcileClusterTLS(ctx, ctx.Client, info, ClusterTLSActions{
+		Apply: func(_ context.Context, obj client.Object) error {
+			return actions.CreateOrUpdateOwnedResource(ctx, ctx.Object, obj)
+		},
+		Delete: func(_ context.Context, objs ...client.Object) error {
+			return actions.DeleteIfExists(ctx, objs...)
+		},
+	})
+}
+
+// ClusterTLSActions apply and delete the objects of cluster certificates.
+// Applied objects must be owned by the cluster.
+type ClusterTLSActions struct {
+	Apply  func(context.Context, client.Object) error
+	Delete func(context.Context, ...client.Object) error
+}
+
+// ReconcileClusterTLS manages the certificates of a cluster. Certificates are
+// requested from cert-manager when an issuer is referenced, otherwise they are
+// signed by a CA that is generated for the cluster and preserved across
+// updates. Every certificate is removed once TLS is disabled. Secrets are
+// read with r.
+func ReconcileClusterTLS(ctx context.Context, r client.Reader, info *clustertls.Info, act ClusterTLSActions) error {
+	if !clustertls.CertManagerEnabled(info.Config) {
+		if err := util.IgnoreMissing(act.Delete(ctx, clustertls.CertificateReferences(info)...)); err != nil {
+			return fmt.Errorf("cannot delete certificates: %w", err)
+		}
+	}
+	if !clustertls.Enabled(info.Config) {
+		return act.Delete(ctx, clustertls.SecretReferences(info)...)
+	}
+
+	caSecret, err := getTLSSecret(ctx, r, info.Namespace, info.CASecretName)
+	if err != nil {
+		return err
+	}
+
+	if clustertls.CertManagerEnabled(info.Config) {
+		for _, cert := range clustertls.NewCertificates(info) {
+			if err = act.Apply(ctx, cert); err != nil {
+				return fmt.Errorf("cannot reconcile certificate: %w", err)
+			}
+		}
+		// the intermediate CA of pod certificates is stored by cert-manager
+		if caSecret != nil && (!info.PodCertificates || operatorCA(caSecret)) {
+			return act.Delete(ctx, caSecret)
+		}
+		return nil
+	}
+
+	// replace an intermediate CA left behind by cert-manager
+	if caSecret != nil && !operatorCA(caSecret) {
+		if err = act.Delete(ctx, caSecret); err != nil {
+			return err
+		}
+		caSecret = nil
+	}
+
+	return issueCertificates(ctx, r, info, caSecret, act)
+}
+
+// issueCertificates signs the node and client certificates with the CA of
+// the cluster, reusing the CA and certificates stored in existing Secrets.
+// Pods sign their own node certificates when info.PodCertificates is set.
+func issueCertificates(
+	ctx context.Context,
+	r client.Reader,
+	info *clustertls.Info,
+	caSecret *corev1.Secret,
+	act ClusterTLSActions,
+) error {
+	ca, err := clustertls.LoadCA(caSecret, clustertls.CACommonName(info), time.Now())
+	if err != nil {
+		return err
+	}
+	if err = act.Apply(ctx, clustertls.NewCASecret(info, ca)); err != nil {
+		return fmt.Errorf("cannot reconcile ca secret: %w", err)
+	}
+
+	if info.PodCertificates {
+		if err = act.Delete(ctx, clustertls.NodeSecretReference(info)); err != nil {
+			return err
+		}
+	} else if err = reconcileLeaf(ctx, r, info, ca, info.SecretName, clustertls.NewNodeSecret, act); err != nil {
+		return err
+	}
+
+	return reconcileLeaf(ctx, r, info, ca, info.ClientSecretName, clustertls.NewClientSecret, act)
+}
+
+type leafSecretFunc func(*clustertls.Info, *clustertls.CA, *corev1.Secret, time.Time) (*corev1.Secret, error)
+
+func reconcileLeaf(
+	ctx context.Context,
+	r client.Reader,
+	info *clustertls.Info,
+	ca *clustertls.CA,
+	name string,
+	newSecret leafSecretFunc,
+	act ClusterTLSActions,
+) error {
+	current, err := getTLSSecret(ctx, r, info.Namespace, name)
+	if err != nil {
+		return err
+	}
+	secret, err := newSecret(info, ca, current, time.Now())
+	if err != nil {
+		return err
+	}
+	if err = act.Apply(ctx, secret); err != nil {
+		return fmt.Errorf("cannot reconcile tls secret: %w", err)
+	}
+
+	return nil
+}
+
+// operatorCA returns true when the CA Secret was generated by the operator
+// rather than issued by cert-manager.
+func operatorCA(secret *corev1.Secret) bool {
+	return secret.Type != corev1.SecretTypeTLS
+}
+
+// getTLSSecret returns nil when the secret does not exist yet.
+func getTLSSecret(ctx context.Context, r client.Reader, namespace, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, secret); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return nil, fmt.Errorf("cannot fetch tls secret: %w", err)
+		}
+		return nil, nil
+	}
+
+	return secret, nil
+}
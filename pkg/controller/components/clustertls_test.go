@@ -0,0 +1,103 @@
+package components
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/clustertls"
+)
+
+func testClusterTLSActions(c client.Client) ClusterTLSActions {
+	return ClusterTLSActions{
+		Apply: func(ctx context.Context, obj client.Object) error {
+			if err := c.Create(ctx, obj); !apierrors.IsAlreadyExists(err) {
+				return err
+			}
+			return c.Update(ctx, obj)
+		},
+		Delete: func(ctx context.Context, objs ...client.Object) error {
+			for _, obj := range objs {
+				if err := client.IgnoreNotFound(c.Delete(ctx, obj)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func TestReconcileClusterTLS(t *testing.T) {
+	ctx := context.Background()
+	info := &clustertls.Info{
+		Namespace:        "ns",
+		CASecretName:     "rc-ray-tls-ca",
+		SecretName:       "rc-ray-tls",
+		ClientSecretName: "rc-ray-client-tls",
+		CommonName:       "rc-ray",
+		DNSNames:         []string{"rc-ray-head"},
+		IPAddresses:      []string{"127.0.0.1"},
+		ClientCommonName: "rc-ray-client-tls",
+		Config:           &dcv1alpha1.TLSConfig{Enabled: true},
+	}
+
+	get := func(c client.Client, name string) error {
+		return c.Get(ctx, client.ObjectKey{Name: name, Namespace: "ns"}, &corev1.Secret{})
+	}
+
+	t.Run("node_certificate", func(t *testing.T) {
+		c := fake.NewClientBuilder().Build()
+		require.NoError(t, ReconcileClusterTLS(ctx, c, info, testClusterTLSActions(c)))
+
+		for _, name := range []string{"rc-ray-tls-ca", "rc-ray-tls", "rc-ray-client-tls"} {
+			assert.NoError(t, get(c, name))
+		}
+	})
+
+	t.Run("pod_certificates", func(t *testing.T) {
+		c := fake.NewClientBuilder().Build()
+		info := *info
+		info.PodCertificates = true
+
+		require.NoError(t, ReconcileClusterTLS(ctx, c, &info, testClusterTLSActions(c)))
+		assert.NoError(t, get(c, "rc-ray-tls-ca"))
+		assert.NoError(t, get(c, "rc-ray-client-tls"))
+		assert.True(t, apierrors.IsNotFound(get(c, "rc-ray-tls")))
+	})
+
+	t.Run("cert_manager_ca", func(t *testing.T) {
+		issued := &corev1.Secret{}
+		issued.Name = "rc-ray-tls-ca"
+		issued.Namespace = "ns"
+		issued.Type = corev1.SecretTypeTLS
+		c := fake.NewClientBuilder().WithObjects(issued).Build()
+		info := *info
+		info.PodCertificates = true
+
+		require.NoError(t, ReconcileClusterTLS(ctx, c, &info, testClusterTLSActions(c)))
+
+		ca := &corev1.Secret{}
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "rc-ray-tls-ca", Namespace: "ns"}, ca))
+		assert.Equal(t, corev1.SecretTypeOpaque, ca.Type)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		c := fake.NewClientBuilder().Build()
+		require.NoError(t, ReconcileClusterTLS(ctx, c, info, testClusterTLSActions(c)))
+
+		disabled := *info
+		disabled.Config = &dcv1alpha1.TLSConfig{}
+		require.NoError(t, ReconcileClusterTLS(ctx, c, &disabled, testClusterTLSActions(c)))
+
+		for _, name := range []string{"rc-ray-tls-ca", "rc-ray-tls", "rc-ray-client-tls"} {
+			assert.True(t, apierrors.IsNotFound(get(c, name)))
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package components
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/actions"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashboard"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+type DashboardDataSource interface {
+	// DashboardInfo describes the routing object of the cluster dashboard; its
+	// config may disable exposure.
+	DashboardInfo() *dashboard.Info
+}
+
+type DashboardDataSourceFactory func(client.Object) DashboardDataSource
+
+// Dashboard manages the Ingress or HTTPRoute exposing a cluster dashboard. It
+// is not an owned component because Gateway APIs are optional and cannot
+// always be watched.
+func Dashboard(f DashboardDataSourceFactory) core.Component {
+	return &dashboardComponent{factory: f}
+}
+
+type dashboardComponent struct {
+	factory DashboardDataSourceFactory
+}
+
+func (c *dashboardComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
+	info := c.factory(ctx.Object).DashboardInfo()
+
+	for _, obj := range dashboard.Stale(info) {
+		if err := util.IgnoreMissing(actions.DeleteIfExists(ctx, obj)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("cannot delete dashboard route: %w", err)
+		}
+	}
+
+	if !dashboard.Enabled(info.Config) {
+		return ctrl.Result{}, nil
+	}
+
+	obj, err := dashboard.New(info)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot build dashboard route: %w", err)
+	}
+	if err = actions.CreateOrUpdateOwnedResource(ctx, ctx.Object, obj); err != nil {
+		err = fmt.Errorf("cannot reconcile dashboard route: %w", err)
+	}
+
+	return ctrl.Result{}, err
+}
+
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;create;update;delete
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind,resourceNames="system:auth-delegator"
+
+type DashboardAuthDataSource interface {
+	// DashboardAuthInfo describes the dashboard proxy; its config may disable
+	// authentication.
+	DashboardAuthInfo() *dashboard.AuthInfo
+}
+
+type DashboardAuthDataSourceFactory func(client.Object) DashboardAuthDataSource
+
+// DashboardAuthBinding manages the cluster role binding of the TokenReview
+// dashboard proxy. Bindings are cluster scoped and cannot be owned by the
+// cluster, so they are removed by a finalizer.
+func DashboardAuthBinding(f DashboardAuthDataSourceFactory) core.Component {
+	return &dashboardAuthBindingComponent{factory: f}
+}
+
+type dashboardAuthBindingComponent struct {
+	factory DashboardAuthDataSourceFactory
+}
+
+func (c *dashboardAuthBindingComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
+	info := c.factory(ctx.Object).DashboardAuthInfo()
+
+	if !dashboard.AuthConfigMapEnabled(info.Config) {
+		return ctrl.Result{}, DeleteDashboardAuthBinding(ctx, ctx.Client, info)
+	}
+	return ctrl.Result{}, ReconcileDashboardAuthBinding(ctx, ctx.Client, ctx.APIReader, info)
+}
+
+func (c *dashboardAuthBindingComponent) Finalize(ctx *core.Context) (ctrl.Result, bool, error) {
+	err := DeleteDashboardAuthBinding(ctx, ctx.Client, c.factory(ctx.Object).DashboardAuthInfo())
+	return ctrl.Result{}, err == nil, err
+}
+
+// ReconcileDashboardAuthBinding creates or updates the cluster role binding
+// of the TokenReview dashboard proxy. The binding is read with r so that
+// cluster role bindings are not cached.
+func ReconcileDashboardAuthBinding(ctx context.Context, c client.Client, r client.Reader, info *dashboard.AuthInfo) error {
+	crb := dashboard.AuthClusterRoleBinding(info)
+
+	found := &rbacv1.ClusterRoleBinding{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(crb), found); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("cannot get dashboard auth binding: %w", err)
+		}
+		if err = c.Create(ctx, crb); err != nil {
+			return fmt.Errorf("cannot create dashboard auth binding: %w", err)
+		}
+		return nil
+	}
+
+	if equality.Semantic.DeepEqual(found.Labels, crb.Labels) && equality.Semantic.DeepEqual(found.Subjects, crb.Subjects) {
+		return nil
+	}
+	found.Labels = crb.Labels
+	found.Subjects = crb.Subjects
+	if err := c.Update(ctx, found); err != nil {
+		return fmt.Errorf("cannot update dashboard auth binding: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteDashboardAuthBinding removes the cluster role binding of the
+// TokenReview dashboard proxy when it exists.
+func DeleteDashboardAuthBinding(ctx context.Context, c client.Client, info *dashboard.AuthInfo) error {
+	if err := c.Delete(ctx, dashboard.AuthClusterRoleBinding(info)); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("cannot delete dashboard auth binding: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package components
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashboard"
+)
+
+func TestDashboardAuthBinding(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+	info := &dashboard.AuthInfo{
+		ClusterName:    "dc",
+		Namespace:      "ns",
+		Resource:       "daskclusters",
+		ConfigMapName:  "dc-dask-dashboard-auth",
+		Labels:         map[string]string{"app": "dask"},
+		ServiceAccount: "dc-dask",
+		Config: &dcv1alpha1.DashboardConfig{
+			Auth: &dcv1alpha1.DashboardAuthConfig{Enabled: true, Type: dcv1alpha1.DashboardAuthTokenReview},
+		},
+	}
+	key := client.ObjectKey{Name: "ns:dc-dask-dashboard-auth"}
+
+	require.NoError(t, ReconcileDashboardAuthBinding(ctx, c, c, info))
+
+	crb := &rbacv1.ClusterRoleBinding{}
+	require.NoError(t, c.Get(ctx, key, crb))
+	assert.Equal(t, "system:auth-delegator", crb.RoleRef.Name)
+	assert.Equal(t, "dc-dask", crb.Subjects[0].Name)
+
+	t.Run("update", func(t *testing.T) {
+		info.ServiceAccount = "custom"
+		require.NoError(t, ReconcileDashboardAuthBinding(ctx, c, c, info))
+
+		require.NoError(t, c.Get(ctx, key, crb))
+		assert.Equal(t, "custom", crb.Subjects[0].Name)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, DeleteDashboardAuthBinding(ctx, c, info))
+		assert.True(t, apierrors.IsNotFound(c.Get(ctx, key, crb)))
+
+		require.NoError(t, DeleteDashboardAuthBinding(ctx, c, info))
+	})
+}
@@ -0,0 +1,50 @@
+package components
+
+import (
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/actions"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+)
+
+type EnvoyFilterDataSource interface {
+	EnvoyFilterInfo() *istio.EnvoyFilterInfo
+	Enabled() bool
+	Delete() bool
+}
+
+type EnvoyFilterDataSourceFactory func(client.Object) EnvoyFilterDataSource
+
+// EnvoyFilter reconciles the EnvoyFilter that sets the idle timeout of TCP
+// connections proxied for cluster pods.
+func EnvoyFilter(f EnvoyFilterDataSourceFactory) core.Component {
+	return &envoyFilterComponent{factory: f}
+}
+
+type envoyFilterComponent struct {
+	factory EnvoyFilterDataSourceFactory
+}
+
+func (c *envoyFilterComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
+	ds := c.factory(ctx.Object)
+
+	if !ds.Enabled() {
+		return ctrl.Result{}, nil
+	}
+
+	envoyFilter := istio.NewEnvoyFilter(ds.EnvoyFilterInfo())
+	if ds.Delete() {
+		return ctrl.Result{}, actions.DeleteIfExists(ctx, envoyFilter)
+	}
+
+	err := actions.CreateOrUpdateOwnedResource(ctx, ctx.Object, envoyFilter)
+	if err != nil {
+		err = fmt.Errorf("cannot reconcile envoy filter: %w", err)
+	}
+
+	return ctrl.Result{}, err
+}
@@ -0,0 +1,64 @@
+package components
+
+import (
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/actions"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+)
+
+type IstioAuthorizationPolicyDataSource interface {
+	AuthorizationPolicyInfo() *istio.AuthorizationPolicyInfo
+	Enabled() bool
+	Delete() bool
+}
+
+type IstioAuthorizationPolicyDataSourceFactory func(client.Object) IstioAuthorizationPolicyDataSource
+
+func IstioAuthorizationPolicy(f IstioAuthorizationPolicyDataSourceFactory) core.Component {
+	return &istioAuthorizationPolicyComponent{factory: f}
+}
+
+type istioAuthorizationPolicyComponent struct {
+	factory IstioAuthorizationPolicyDataSourceFactory
+}
+
+func (c *istioAuthorizationPolicyComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
+	ds := c.factory(ctx.Object)
+
+	if !ds.Enabled() {
+		return ctrl.Result{}, nil
+	}
+
+	info := ds.AuthorizationPolicyInfo()
+	authzPolicy := istio.NewAuthorizationPolicy(info)
+	waypointRef := istio.WaypointAuthorizationPolicyReference(info.Name, info.Namespace)
+	if ds.Delete() {
+		return ctrl.Result{}, actions.DeleteIfExists(ctx, authzPolicy, waypointRef)
+	}
+
+	if !istio.WaypointPolicyEnabled(info) {
+		if err := actions.DeleteIfExists(ctx, waypointRef); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else {
+		waypointPolicy, err := istio.NewWaypointAuthorizationPolicy(info)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if err = actions.CreateOrUpdateOwnedResource(ctx, ctx.Object, waypointPolicy); err != nil {
+			return ctrl.Result{}, fmt.Errorf("cannot reconcile istio waypoint authorization policy: %w", err)
+		}
+	}
+
+	err := actions.CreateOrUpdateOwnedResource(ctx, ctx.Object, authzPolicy)
+	if err != nil {
+		err = fmt.Errorf("cannot reconcile istio authorization policy: %w", err)
+	}
+
+	return ctrl.Result{}, err
+}
@@ -0,0 +1,61 @@
+package components
+
+import (
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/actions"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+)
+
+type IstioTrafficDataSource interface {
+	TrafficInfo() *istio.TrafficInfo
+	Enabled() bool
+}
+
+type IstioTrafficDataSourceFactory func(client.Object) IstioTrafficDataSource
+
+// IstioTraffic reconciles the Istio Sidecar and DestinationRules of a cluster.
+func IstioTraffic(f IstioTrafficDataSourceFactory) core.Component {
+	return &istioTrafficComponent{factory: f}
+}
+
+type istioTrafficComponent struct {
+	factory IstioTrafficDataSourceFactory
+}
+
+func (c *istioTrafficComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
+	ds := c.factory(ctx.Object)
+
+	if !ds.Enabled() {
+		return ctrl.Result{}, nil
+	}
+
+	info := ds.TrafficInfo()
+	ic := dcv1alpha1.IstioConfig{Istio: info.Config}
+
+	sidecar := istio.NewSidecar(info)
+	if !istio.SidecarEnabled(ic, info.Mode) {
+		if err := actions.DeleteIfExists(ctx, sidecar); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if err := actions.CreateOrUpdateOwnedResource(ctx, ctx.Object, sidecar); err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot reconcile istio sidecar: %w", err)
+	}
+
+	for _, rule := range istio.NewDestinationRules(info) {
+		if !istio.DestinationRuleEnabled(ic) {
+			if err := actions.DeleteIfExists(ctx, rule); err != nil {
+				return ctrl.Result{}, err
+			}
+		} else if err := actions.CreateOrUpdateOwnedResource(ctx, ctx.Object, rule); err != nil {
+			return ctrl.Result{}, fmt.Errorf("cannot reconcile istio destination rule: %w", err)
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
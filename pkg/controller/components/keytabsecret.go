@@ -0,0 +1,63 @@
+package components
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/actions"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/kerberos"
+)
+
+type KeytabSecretDataSource interface {
+	// KeytabInfo describes the keytab Secret; it is deleted unless the keytab is inline.
+	KeytabInfo() *kerberos.KeytabInfo
+}
+
+type KeytabSecretDataSourceFactory func(client.Object) KeytabSecretDataSource
+
+// KeytabSecret copies keytab contents embedded in the cluster spec into a
+// Secret, and removes the ConfigMap that held them in earlier versions. A
+// warning event is recorded on the cluster when the Secret is created.
+func KeytabSecret(f KeytabSecretDataSourceFactory) core.OwnedComponent {
+	return &keytabSecretComponent{factory: f}
+}
+
+type keytabSecretComponent struct {
+	factory KeytabSecretDataSourceFactory
+}
+
+func (c *keytabSecretComponent) Kind() client.Object {
+	return &corev1.Secret{}
+}
+
+func (c *keytabSecretComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
+	info := c.factory(ctx.Object).KeytabInfo()
+
+	if err := actions.DeleteIfExists(ctx, kerberos.KeytabConfigMapReference(info)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot delete keytab config map: %w", err)
+	}
+
+	secret := kerberos.NewKeytabSecret(info)
+	if !kerberos.InlineKeytab(info.Config) {
+		return ctrl.Result{}, actions.DeleteIfExists(ctx, secret)
+	}
+
+	err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{})
+	if apierrors.IsNotFound(err) {
+		kerberos.RecordInlineKeytab(ctx.Recorder, ctx.Object)
+	} else if err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot fetch keytab secret: %w", err)
+	}
+
+	err = actions.CreateOrUpdateOwnedResource(ctx, ctx.Object, secret)
+	if err != nil {
+		err = fmt.Errorf("cannot reconcile keytab secret: %w", err)
+	}
+
+	return ctrl.Result{}, err
+}
@@ -2,14 +2,40 @@
 package components
 
 import (
+	"context"
 	"fmt"
+	"net"
 
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/metadata"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/actions"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/clientaccess"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+const (
+	egressComponent = "egress"
+	dnsPort         = 53
+)
+
+// kubernetesAPIEndpoints locates the addresses of the kubernetes API servers.
+var kubernetesAPIEndpoints = client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "kubernetes"}
+
+// Location and ports of the istio control plane: 15012 serves xDS and
+// certificates, 15017 serves the sidecar injection and validation webhooks.
+const istiodNamespace = "istio-system"
+
+var (
+	istiodLabels = map[string]string{"app": "istiod"}
+	istiodPorts  = []int{15012, 15017}
 )
 
 type NetworkPolicyDataSource interface {
@@ -46,3 +72,213 @@ func (c *networkPolicyComponent) Reconcile(ctx *core.Context) (ctrl.Result, erro
 
 	return ctrl.Result{}, err
 }
+
+//+kubebuilder:rbac:groups="",resources=endpoints,verbs=get
+
+// EgressEnabled returns true when the egress traffic of cluster pods must be
+// restricted.
+func EgressEnabled(np dcv1alpha1.NetworkPolicyConfig) bool {
+	return util.BoolPtrIsTrue(np.Enabled) && np.Egress != nil && np.Egress.Enabled
+}
+
+// ControlPlaneEgressRules returns the rules that grant cluster pods access to
+// the kubernetes API servers when requested, and to istiod when istio is
+// enabled.
+func ControlPlaneEgressRules(
+	ctx context.Context,
+	r client.Reader,
+	np dcv1alpha1.NetworkPolicyConfig,
+	istioEnabled bool) ([]networkingv1.NetworkPolicyEgressRule, error) {
+	if !EgressEnabled(np) {
+		return nil, nil
+	}
+
+	var rules []networkingv1.NetworkPolicyEgressRule
+	apiRule, err := KubernetesAPIEgressRule(ctx, r, np)
+	if err != nil {
+		return nil, err
+	}
+	if apiRule != nil {
+		rules = append(rules, *apiRule)
+	}
+	if istioEnabled {
+		rules = append(rules, IstiodEgressRule())
+	}
+
+	return rules, nil
+}
+
+// KubernetesAPIEgressRule returns a rule that grants access to the kubernetes
+// API servers. Network policies apply to translated service addresses, so the
+// rule is built from the endpoints of the "kubernetes" service instead of its
+// cluster IP. The endpoints are read with an uncached reader because they
+// live outside of the namespaces watched by the operator. Nil is returned
+// when API access is not requested.
+func KubernetesAPIEgressRule(ctx context.Context, r client.Reader, np dcv1alpha1.NetworkPolicyConfig) (*networkingv1.NetworkPolicyEgressRule, error) {
+	if !EgressEnabled(np) || !np.Egress.AllowKubernetesAPI {
+		return nil, nil
+	}
+
+	endpoints := &corev1.Endpoints{}
+	if err := r.Get(ctx, kubernetesAPIEndpoints, endpoints); err != nil {
+		return nil, fmt.Errorf("cannot get kubernetes api endpoints: %w", err)
+	}
+
+	rule := &networkingv1.NetworkPolicyEgressRule{}
+	for _, subset := range endpoints.Subsets {
+		for _, addr := range subset.Addresses {
+			rule.To = append(rule.To, networkingv1.NetworkPolicyPeer{
+				IPBlock: &networkingv1.IPBlock{CIDR: hostCIDR(addr.IP)},
+			})
+		}
+		for idx := range subset.Ports {
+			port := intstr.FromInt(int(subset.Ports[idx].Port))
+			rule.Ports = append(rule.Ports, networkingv1.NetworkPolicyPort{
+				Protocol: &subset.Ports[idx].Protocol,
+				Port:     &port,
+			})
+		}
+	}
+
+	return rule, nil
+}
+
+// IstiodEgressRule returns a rule that grants sidecars and ztunnels access to
+// the xDS, CA and webhook ports of istiod.
+func IstiodEgressRule() networkingv1.NetworkPolicyEgressRule {
+	tcp := corev1.ProtocolTCP
+	ports := make([]networkingv1.NetworkPolicyPort, 0, len(istiodPorts))
+	for _, p := range istiodPorts {
+		port := intstr.FromInt(p)
+		ports = append(ports, networkingv1.NetworkPolicyPort{
+			Protocol: &tcp,
+			Port:     &port,
+		})
+	}
+
+	return networkingv1.NetworkPolicyEgressRule{
+		To: []networkingv1.NetworkPolicyPeer{
+			{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{corev1.LabelMetadataName: istiodNamespace},
+				},
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: istiodLabels,
+				},
+			},
+		},
+		Ports: ports,
+	}
+}
+
+// NewEgressNetworkPolicy generates a network policy that denies all egress
+// traffic of cluster pods except traffic to other cluster pods and clients,
+// DNS servers, the control planes granted by controlPlaneRules, and the
+// destinations of the configured egress rules.
+func NewEgressNetworkPolicy(
+	obj client.Object,
+	np dcv1alpha1.NetworkPolicyConfig,
+	controlPlaneRules []networkingv1.NetworkPolicyEgressRule,
+	meta *metadata.Provider) *networkingv1.NetworkPolicy {
+	udp := corev1.ProtocolUDP
+	tcp := corev1.ProtocolTCP
+	port := intstr.FromInt(dnsPort)
+
+	rules := []networkingv1.NetworkPolicyEgressRule{
+		{
+			To: append([]networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: meta.MatchLabels(obj),
+					},
+				},
+			}, clientaccess.Peers(np)...),
+		},
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{
+					Protocol: &udp,
+					Port:     &port,
+				},
+				{
+					Protocol: &tcp,
+					Port:     &port,
+				},
+			},
+		},
+	}
+	rules = append(rules, controlPlaneRules...)
+	if np.Egress != nil {
+		rules = append(rules, np.Egress.Rules...)
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      meta.InstanceName(obj, egressComponent),
+			Namespace: obj.GetNamespace(),
+			Labels:    meta.StandardLabels(obj),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: meta.MatchLabels(obj),
+			},
+			Egress: rules,
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeEgress,
+			},
+		},
+	}
+}
+
+// EgressNetworkPolicyDataSource describes the egress network policy of
+// clusters reconciled with core controllers.
+type EgressNetworkPolicyDataSource interface {
+	NetworkPolicyConfig() dcv1alpha1.NetworkPolicyConfig
+	IstioEnabled() bool
+	Metadata() *metadata.Provider
+}
+
+type EgressNetworkPolicyDataSourceFactory func(client.Object) EgressNetworkPolicyDataSource
+
+func EgressNetworkPolicy(f EgressNetworkPolicyDataSourceFactory) core.OwnedComponent {
+	return &egressNetworkPolicyComponent{factory: f}
+}
+
+type egressNetworkPolicyComponent struct {
+	factory EgressNetworkPolicyDataSourceFactory
+}
+
+func (c *egressNetworkPolicyComponent) Kind() client.Object {
+	return &networkingv1.NetworkPolicy{}
+}
+
+func (c *egressNetworkPolicyComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
+	ds := c.factory(ctx.Object)
+	np := ds.NetworkPolicyConfig()
+
+	rules, err := ControlPlaneEgressRules(ctx, ctx.APIReader, np, ds.IstioEnabled())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	netpol := NewEgressNetworkPolicy(ctx.Object, np, rules, ds.Metadata())
+
+	if !EgressEnabled(np) {
+		return ctrl.Result{}, actions.DeleteIfExists(ctx, netpol)
+	}
+
+	err = actions.CreateOrUpdateOwnedResource(ctx, ctx.Object, netpol)
+	if err != nil {
+		err = fmt.Errorf("cannot reconcile egress network policy: %w", err)
+	}
+
+	return ctrl.Result{}, err
+}
+
+// hostCIDR returns the single address CIDR of an IPv4 or IPv6 address.
+func hostCIDR(ip string) string {
+	if net.ParseIP(ip).To4() != nil {
+		return ip + "/32"
+	}
+
+	return ip + "/128"
+}
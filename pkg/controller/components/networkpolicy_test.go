@@ -0,0 +1,154 @@
+package components
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/cluster/metadata"
+)
+
+var testMeta = metadata.NewProvider(
+	"test",
+	func(obj client.Object) string { return "latest" },
+	func(obj client.Object) map[string]string { return nil },
+)
+
+func testNetworkPolicyConfig() dcv1alpha1.NetworkPolicyConfig {
+	return dcv1alpha1.NetworkPolicyConfig{
+		Enabled:      pointer.Bool(true),
+		ClientLabels: map[string]string{"client": "true"},
+		Egress: &dcv1alpha1.NetworkPolicyEgressConfig{
+			Enabled:            true,
+			AllowKubernetesAPI: true,
+		},
+	}
+}
+
+func TestNewEgressNetworkPolicy(t *testing.T) {
+	obj := &dcv1alpha1.DaskCluster{ObjectMeta: metav1.ObjectMeta{Name: "dc", Namespace: "ns"}}
+	np := testNetworkPolicyConfig()
+	np.Egress.Rules = []networkingv1.NetworkPolicyEgressRule{
+		{To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}}}},
+	}
+	apiRule := networkingv1.NetworkPolicyEgressRule{
+		To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "172.16.0.1/32"}}},
+	}
+
+	netpol := NewEgressNetworkPolicy(obj, np, []networkingv1.NetworkPolicyEgressRule{apiRule}, testMeta)
+	assert.Equal(t, "dc-test-egress", netpol.Name)
+	assert.Equal(t, "ns", netpol.Namespace)
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}, netpol.Spec.PolicyTypes)
+	assert.Equal(t, testMeta.MatchLabels(obj), netpol.Spec.PodSelector.MatchLabels)
+
+	rules := netpol.Spec.Egress
+	require.Len(t, rules, 4)
+	assert.Equal(t, testMeta.MatchLabels(obj), rules[0].To[0].PodSelector.MatchLabels)
+	assert.Equal(t, map[string]string{"client": "true"}, rules[0].To[1].PodSelector.MatchLabels)
+	assert.Empty(t, rules[1].To)
+	assert.Equal(t, 53, rules[1].Ports[0].Port.IntValue())
+	assert.Equal(t, apiRule, rules[2])
+	assert.Equal(t, np.Egress.Rules[0], rules[3])
+}
+
+func TestKubernetesAPIEgressRule(t *testing.T) {
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "kubernetes", Namespace: "default"},
+		Subsets: []corev1.EndpointSubset{
+			{
+				Addresses: []corev1.EndpointAddress{{IP: "172.16.0.1"}, {IP: "fd00::1"}},
+				Ports:     []corev1.EndpointPort{{Name: "https", Port: 6443, Protocol: corev1.ProtocolTCP}},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithObjects(endpoints).Build()
+	np := testNetworkPolicyConfig()
+
+	rule, err := KubernetesAPIEgressRule(context.Background(), c, np)
+	require.NoError(t, err)
+	require.NotNil(t, rule)
+	assert.Equal(t, "172.16.0.1/32", rule.To[0].IPBlock.CIDR)
+	assert.Equal(t, "fd00::1/128", rule.To[1].IPBlock.CIDR)
+	assert.Equal(t, 6443, rule.Ports[0].Port.IntValue())
+
+	t.Run("not_allowed", func(t *testing.T) {
+		np.Egress.AllowKubernetesAPI = false
+
+		rule, err := KubernetesAPIEgressRule(context.Background(), c, np)
+		require.NoError(t, err)
+		assert.Nil(t, rule)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		np := testNetworkPolicyConfig()
+		np.Enabled = pointer.Bool(false)
+		assert.False(t, EgressEnabled(np))
+	})
+}
+
+func TestIstiodEgressRule(t *testing.T) {
+	rule := IstiodEgressRule()
+
+	require.Len(t, rule.To, 1)
+	assert.Equal(t, map[string]string{"kubernetes.io/metadata.name": "istio-system"}, rule.To[0].NamespaceSelector.MatchLabels)
+	assert.Equal(t, map[string]string{"app": "istiod"}, rule.To[0].PodSelector.MatchLabels)
+
+	require.Len(t, rule.Ports, 2)
+	assert.Equal(t, corev1.ProtocolTCP, *rule.Ports[0].Protocol)
+	assert.Equal(t, 15012, rule.Ports[0].Port.IntValue())
+	assert.Equal(t, 15017, rule.Ports[1].Port.IntValue())
+}
+
+func TestControlPlaneEgressRules(t *testing.T) {
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "kubernetes", Namespace: "default"},
+		Subsets: []corev1.EndpointSubset{
+			{
+				Addresses: []corev1.EndpointAddress{{IP: "172.16.0.1"}},
+				Ports:     []corev1.EndpointPort{{Name: "https", Port: 6443, Protocol: corev1.ProtocolTCP}},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithObjects(endpoints).Build()
+	ctx := context.Background()
+
+	t.Run("istio_enabled", func(t *testing.T) {
+		rules, err := ControlPlaneEgressRules(ctx, c, testNetworkPolicyConfig(), true)
+		require.NoError(t, err)
+		require.Len(t, rules, 2)
+		assert.Equal(t, "172.16.0.1/32", rules[0].To[0].IPBlock.CIDR)
+		assert.Equal(t, IstiodEgressRule(), rules[1])
+	})
+
+	t.Run("istio_disabled", func(t *testing.T) {
+		np := testNetworkPolicyConfig()
+		np.Egress.AllowKubernetesAPI = false
+
+		rules, err := ControlPlaneEgressRules(ctx, c, np, false)
+		require.NoError(t, err)
+		assert.Empty(t, rules)
+	})
+
+	t.Run("egress_disabled", func(t *testing.T) {
+		np := testNetworkPolicyConfig()
+		np.Egress.Enabled = false
+
+		rules, err := ControlPlaneEgressRules(ctx, c, np, true)
+		require.NoError(t, err)
+		assert.Empty(t, rules)
+	})
+
+	t.Run("missing_endpoints", func(t *testing.T) {
+		_, err := ControlPlaneEgressRules(ctx, fake.NewClientBuilder().Build(), testNetworkPolicyConfig(), false)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,51 @@
+package components
+
+import (
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/actions"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+type PodGroupDataSource interface {
+	// PodGroupInfo describes the cluster PodGroup; its config may disable gang scheduling.
+	PodGroupInfo() *podgroup.Info
+}
+
+type PodGroupDataSourceFactory func(client.Object) PodGroupDataSource
+
+// PodGroup manages the PodGroup of a cluster. It is not an owned component
+// because PodGroup APIs are optional and cannot always be watched.
+func PodGroup(f PodGroupDataSourceFactory) core.Component {
+	return &podGroupComponent{factory: f}
+}
+
+type podGroupComponent struct {
+	factory PodGroupDataSourceFactory
+}
+
+func (c *podGroupComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
+	info := c.factory(ctx.Object).PodGroupInfo()
+
+	for _, pg := range podgroup.Stale(info.Config, info.Name, info.Namespace) {
+		if err := util.IgnoreMissing(actions.DeleteIfExists(ctx, pg)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("cannot delete pod group: %w", err)
+		}
+	}
+
+	if !podgroup.Enabled(info.Config) {
+		return ctrl.Result{}, nil
+	}
+
+	err := actions.CreateOrUpdateOwnedResource(ctx, ctx.Object, podgroup.New(info))
+	if err != nil {
+		err = fmt.Errorf("cannot reconcile pod group: %w", err)
+	}
+
+	return ctrl.Result{}, err
+}
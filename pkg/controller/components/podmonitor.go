@@ -0,0 +1,50 @@
+package components
+
+import (
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/actions"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+type PodMonitorDataSource interface {
+	// PodMonitorInfo describes the cluster PodMonitor; its config may disable monitoring.
+	PodMonitorInfo() *monitoring.Info
+}
+
+type PodMonitorDataSourceFactory func(client.Object) PodMonitorDataSource
+
+// PodMonitor manages the PodMonitor of a cluster. It is not an owned
+// component because PodMonitor APIs are optional and cannot always be watched.
+func PodMonitor(f PodMonitorDataSourceFactory) core.Component {
+	return &podMonitorComponent{factory: f}
+}
+
+type podMonitorComponent struct {
+	factory PodMonitorDataSourceFactory
+}
+
+func (c *podMonitorComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
+	info := c.factory(ctx.Object).PodMonitorInfo()
+
+	if !monitoring.Enabled(info.Config) {
+		pm := monitoring.Reference(info.Name, info.Namespace)
+		if err := util.IgnoreMissing(actions.DeleteIfExists(ctx, pm)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("cannot delete pod monitor: %w", err)
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	err := actions.CreateOrUpdateOwnedResource(ctx, ctx.Object, monitoring.New(info))
+	if err != nil {
+		err = fmt.Errorf("cannot reconcile pod monitor: %w", err)
+	}
+
+	return ctrl.Result{}, err
+}
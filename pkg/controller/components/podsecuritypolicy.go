@@ -0,0 +1,31 @@
+package components
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+// PodSecurityPolicyRBAC wraps a component that manages pod security policy
+// RBAC so that it is skipped on clusters without the PodSecurityPolicy API.
+func PodSecurityPolicyRBAC(comp core.OwnedComponent) core.OwnedComponent {
+	return &podSecurityPolicyRBACComponent{OwnedComponent: comp}
+}
+
+type podSecurityPolicyRBACComponent struct {
+	core.OwnedComponent
+}
+
+func (c *podSecurityPolicyRBACComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
+	available, err := util.PodSecurityPolicyAvailable(ctx.Client.RESTMapper())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !available {
+		ctx.Log.V(1).Info("Skipping pod security policy RBAC, API is not available")
+		return ctrl.Result{}, nil
+	}
+
+	return c.OwnedComponent.Reconcile(ctx)
+}
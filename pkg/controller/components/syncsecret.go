@@ -0,0 +1,59 @@
+package components
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/actions"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
+)
+
+type SyncSecretDataSource interface {
+	// SyncSecretInfo provides secret metadata; the password field is ignored.
+	SyncSecretInfo() *filesync.SecretInfo
+	Delete() bool
+}
+
+type SyncSecretDataSourceFactory func(client.Object) SyncSecretDataSource
+
+func SyncSecret(f SyncSecretDataSourceFactory) core.OwnedComponent {
+	return &syncSecretComponent{factory: f}
+}
+
+type syncSecretComponent struct {
+	factory SyncSecretDataSourceFactory
+}
+
+func (c *syncSecretComponent) Kind() client.Object {
+	return &corev1.Secret{}
+}
+
+func (c *syncSecretComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
+	ds := c.factory(ctx.Object)
+	info := ds.SyncSecretInfo()
+
+	current := &corev1.Secret{}
+	key := client.ObjectKey{Name: info.Name, Namespace: info.Namespace}
+
+	if ds.Delete() {
+		current.Name, current.Namespace = key.Name, key.Namespace
+		return ctrl.Result{}, actions.DeleteIfExists(ctx, current)
+	}
+
+	password, err := filesync.FetchPassword(ctx, ctx.Client, key)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	info.Password = password
+
+	err = actions.CreateOrUpdateOwnedResource(ctx, ctx.Object, filesync.NewSecret(info))
+	if err != nil {
+		err = fmt.Errorf("cannot reconcile sync secret: %w", err)
+	}
+
+	return ctrl.Result{}, err
+}
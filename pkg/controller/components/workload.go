@@ -0,0 +1,94 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/actions"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/workload"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+const workloadPollPeriod = 10 * time.Second
+
+type WorkloadDataSource interface {
+	// WorkloadInfo describes the cluster workload; a blank queue name disables queueing.
+	WorkloadInfo() (*workload.Info, error)
+	// ClusterStatusConfig receives the queued state, which must be set before
+	// any stateful set is built.
+	ClusterStatusConfig() *dcv1alpha1.ClusterStatusConfig
+}
+
+type WorkloadDataSourceFactory func(client.Object) WorkloadDataSource
+
+// Workload queues a cluster with an admission controller when it requests a
+// queue. It is not an owned component because the Workload API is optional,
+// so admission is polled instead.
+func Workload(f WorkloadDataSourceFactory) core.Component {
+	return &workloadComponent{factory: f}
+}
+
+type workloadComponent struct {
+	factory WorkloadDataSourceFactory
+}
+
+func (c *workloadComponent) Reconcile(ctx *core.Context) (ctrl.Result, error) {
+	ds := c.factory(ctx.Object)
+	csc := ds.ClusterStatusConfig()
+
+	info, err := ds.WorkloadInfo()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot build workload: %w", err)
+	}
+
+	if info.QueueName == "" {
+		csc.Queued = false
+
+		err = util.IgnoreMissing(actions.DeleteIfExists(ctx, workload.Reference(info.Name, info.Namespace)))
+		if err != nil {
+			err = fmt.Errorf("cannot delete workload: %w", err)
+		}
+		return ctrl.Result{}, err
+	}
+
+	wl, err := workload.New(info)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = actions.CreateOrUpdateOwnedResource(ctx, ctx.Object, wl)
+	if apierrors.IsInvalid(err) {
+		// the pod sets of admitted workloads are immutable, so the cluster is
+		// suspended and queued again with a new workload.
+		ctx.Log.Info("Recreating workload after pod set changes")
+		csc.Queued = true
+
+		if err = actions.DeleteIfExists(ctx, wl); err != nil {
+			return ctrl.Result{}, fmt.Errorf("cannot delete workload: %w", err)
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot reconcile workload: %w", err)
+	}
+
+	admitted, err := workload.FetchAdmitted(ctx, ctx.Client, info.Name, info.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	csc.Queued = !admitted
+
+	// workload changes are not watched
+	var result ctrl.Result
+	if csc.Queued {
+		result.RequeueAfter = workloadPollPeriod
+	}
+
+	return result, nil
+}
@@ -12,10 +12,13 @@ import (
 type Context struct {
 	context.Context
 
-	Log      logr.Logger
-	Object   client.Object
-	Client   client.Client
-	Scheme   *runtime.Scheme
-	Recorder record.EventRecorder
-	Patch    *Patch
+	Log    logr.Logger
+	Object client.Object
+	Client client.Client
+	// APIReader reads objects directly from the API server, e.g. objects
+	// outside of the watched namespaces that must not be cached.
+	APIReader client.Reader
+	Scheme    *runtime.Scheme
+	Recorder  record.EventRecorder
+	Patch     *Patch
 }
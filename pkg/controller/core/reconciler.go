@@ -16,6 +16,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/namespaces"
 )
 
 var getGvk = apiutil.GVKForObject
@@ -39,6 +41,7 @@ type Reconciler struct {
 	log               logr.Logger
 	webhooksEnabled   bool
 	finalizerBaseName string
+	namespaces        *namespaces.Selector
 
 	patcher    *Patch
 	recorder   record.EventRecorder
@@ -81,6 +84,12 @@ func (r *Reconciler) WithWebhooks() *Reconciler {
 	return r
 }
 
+// WithNamespaces limits reconciliation to the namespaces selected by s.
+func (r *Reconciler) WithNamespaces(s *namespaces.Selector) *Reconciler {
+	r.namespaces = s
+	return r
+}
+
 func (r *Reconciler) Build() (controller.Controller, error) {
 	name, err := r.getControllerName()
 	if err != nil {
@@ -100,6 +109,13 @@ func (r *Reconciler) Build() (controller.Controller, error) {
 	if r.patcher == nil {
 		r.patcher = NewPatch(gvk)
 	}
+	if r.namespaces != nil {
+		list, err := r.mgr.GetScheme().New(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err != nil {
+			return nil, fmt.Errorf("cannot get list type for GVK %s: %w", gvk, err)
+		}
+		r.controllerBuilder = r.namespaces.Watch(r.controllerBuilder, list.(client.ObjectList))
+	}
 
 	components := map[string]Component{}
 	for _, rc := range r.components {
@@ -151,6 +167,19 @@ func (r *Reconciler) Reconcile(rootCtx context.Context, req ctrl.Request) (ctrl.
 	}
 	cleanObj := obj.DeepCopyObject().(client.Object)
 
+	// skip reconcile outside of selected namespaces, finalizers still run
+	if obj.GetDeletionTimestamp().IsZero() {
+		selected, err := r.namespaces.Selected(rootCtx, req.Namespace)
+		if err != nil {
+			log.Error(err, "Failed to fetch reconcile namespace")
+			return ctrl.Result{}, err
+		}
+		if !selected {
+			log.V(1).Info("Skipping reconcile outside of selected namespaces")
+			return ctrl.Result{}, nil
+		}
+	}
+
 	// skip reconcile when annotated
 	skip, ok := obj.GetAnnotations()[skipReconcileAnnotation]
 	if ok && skip == "true" {
@@ -161,12 +190,13 @@ func (r *Reconciler) Reconcile(rootCtx context.Context, req ctrl.Request) (ctrl.
 	// build context for components
 	compLog := log.WithName("components")
 	ctx := &Context{
-		Context:  rootCtx,
-		Object:   obj,
-		Client:   r.client,
-		Patch:    r.patcher,
-		Scheme:   r.mgr.GetScheme(),
-		Recorder: r.recorder,
+		Context:   rootCtx,
+		Object:    obj,
+		Client:    r.client,
+		APIReader: r.mgr.GetAPIReader(),
+		Patch:     r.patcher,
+		Scheme:    r.mgr.GetScheme(),
+		Recorder:  r.recorder,
 	}
 
 	// reconcile components
@@ -0,0 +1,161 @@
+// Package namespaces limits reconciliation to the namespaces whose labels
+// match a selector. Namespaces are selected and deselected at runtime as their
+// labels change, so the operator does not need to be restarted.
+package namespaces
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+var selectedNamespaces = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "distributed_compute_selected_namespaces",
+		Help: "Namespaces whose clusters are reconciled, set to 1 while a namespace is selected.",
+	},
+	[]string{"namespace"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(selectedNamespaces)
+}
+
+// Selector decides whether the clusters of a namespace are reconciled. A nil
+// Selector selects every namespace.
+type Selector struct {
+	client   client.Reader
+	selector labels.Selector
+}
+
+// NewSelector returns a Selector that matches namespace labels against s. It
+// returns nil when s is nil.
+func NewSelector(c client.Reader, s labels.Selector) *Selector {
+	if s == nil {
+		return nil
+	}
+
+	return &Selector{client: c, selector: s}
+}
+
+// Selected returns true when the clusters of the named namespace should be
+// reconciled. Missing namespaces are never selected.
+func (s *Selector) Selected(ctx context.Context, name string) (bool, error) {
+	if s == nil {
+		return true, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := s.client.Get(ctx, client.ObjectKey{Name: name}, ns); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	return s.selector.Matches(labels.Set(ns.Labels)), nil
+}
+
+// Watch registers a watch of Namespace objects with a controller builder. Every
+// object of the list type in a namespace is enqueued when its labels change,
+// so clusters are reconciled as soon as their namespace is selected.
+func (s *Selector) Watch(b *builder.Builder, list client.ObjectList) *builder.Builder {
+	if s == nil {
+		return b
+	}
+
+	return b.Watches(
+		&source.Kind{Type: &corev1.Namespace{}},
+		handler.EnqueueRequestsFromMapFunc(s.enqueueObjects(list)),
+		builder.WithPredicates(predicate.LabelChangedPredicate{}),
+	)
+}
+
+func (s *Selector) enqueueObjects(list client.ObjectList) handler.MapFunc {
+	log := ctrl.Log.WithName("namespaces")
+
+	return func(obj client.Object) []reconcile.Request {
+		objs := list.DeepCopyObject().(client.ObjectList)
+		if err := s.client.List(context.Background(), objs, client.InNamespace(obj.GetName())); err != nil {
+			log.Error(err, "Cannot list namespace objects", "namespace", obj.GetName())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		err := meta.EachListItem(objs, func(item runtime.Object) error {
+			o := item.(client.Object)
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(o)})
+			return nil
+		})
+		if err != nil {
+			log.Error(err, "Cannot enqueue namespace objects", "namespace", obj.GetName())
+		}
+
+		return requests
+	}
+}
+
+// Tracker maintains the set of selected namespaces and exports it as metrics.
+type Tracker struct {
+	selector *Selector
+	log      logr.Logger
+
+	mu     sync.Mutex
+	active map[string]struct{}
+}
+
+// NewTracker returns a Tracker of the namespaces selected by s.
+func NewTracker(s *Selector) *Tracker {
+	return &Tracker{
+		selector: s,
+		log:      ctrl.Log.WithName("controllers").WithName("Namespace"),
+		active:   map[string]struct{}{},
+	}
+}
+
+// SetupWithManager creates and registers this controller with the manager.
+func (t *Tracker) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("namespace-selector").
+		For(&corev1.Namespace{}, builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		Complete(t)
+}
+
+// Reconcile adds the namespace to the selected set when its labels match and
+// removes it otherwise.
+func (t *Tracker) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	selected, err := t.selector.Selected(ctx, req.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, active := t.active[req.Name]
+	switch {
+	case selected && !active:
+		t.log.Info("Namespace selected", "namespace", req.Name)
+		t.active[req.Name] = struct{}{}
+		selectedNamespaces.WithLabelValues(req.Name).Set(1)
+	case !selected && active:
+		t.log.Info("Namespace deselected", "namespace", req.Name)
+		delete(t.active, req.Name)
+		selectedNamespaces.DeleteLabelValues(req.Name)
+	}
+
+	return ctrl.Result{}, nil
+}
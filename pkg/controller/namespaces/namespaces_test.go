@@ -0,0 +1,102 @@
+package namespaces
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func testSelector(t *testing.T, objs ...client.Object) (*Selector, client.Client) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, dcv1alpha1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	selector, err := labels.Parse("team=compute")
+	require.NoError(t, err)
+
+	return NewSelector(c, selector), c
+}
+
+func testNamespace(name string, lbls map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: lbls}}
+}
+
+func TestSelectorSelected(t *testing.T) {
+	ctx := context.Background()
+	s, _ := testSelector(t,
+		testNamespace("selected", map[string]string{"team": "compute"}),
+		testNamespace("other", map[string]string{"team": "web"}),
+	)
+
+	selected, err := s.Selected(ctx, "selected")
+	require.NoError(t, err)
+	assert.True(t, selected)
+
+	selected, err = s.Selected(ctx, "other")
+	require.NoError(t, err)
+	assert.False(t, selected)
+
+	selected, err = s.Selected(ctx, "missing")
+	require.NoError(t, err)
+	assert.False(t, selected)
+
+	t.Run("nil", func(t *testing.T) {
+		var s *Selector
+		assert.Nil(t, NewSelector(nil, nil))
+
+		selected, err := s.Selected(ctx, "other")
+		require.NoError(t, err)
+		assert.True(t, selected)
+	})
+}
+
+func TestSelectorEnqueueObjects(t *testing.T) {
+	s, _ := testSelector(t,
+		&dcv1alpha1.DaskCluster{ObjectMeta: metav1.ObjectMeta{Name: "one", Namespace: "selected"}},
+		&dcv1alpha1.DaskCluster{ObjectMeta: metav1.ObjectMeta{Name: "two", Namespace: "selected"}},
+		&dcv1alpha1.DaskCluster{ObjectMeta: metav1.ObjectMeta{Name: "three", Namespace: "other"}},
+	)
+
+	requests := s.enqueueObjects(&dcv1alpha1.DaskClusterList{})(testNamespace("selected", nil))
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "one", Namespace: "selected"}},
+		{NamespacedName: types.NamespacedName{Name: "two", Namespace: "selected"}},
+	}, requests)
+}
+
+func TestTracker(t *testing.T) {
+	ctx := context.Background()
+	ns := testNamespace("tracked", map[string]string{"team": "compute"})
+	s, c := testSelector(t, ns)
+	tracker := NewTracker(s)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "tracked"}}
+
+	_, err := tracker.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Contains(t, tracker.active, "tracked")
+	assert.Equal(t, float64(1), testutil.ToFloat64(selectedNamespaces.WithLabelValues("tracked")))
+
+	ns.Labels = nil
+	require.NoError(t, c.Update(ctx, ns))
+
+	_, err = tracker.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, tracker.active)
+	assert.Equal(t, 0, testutil.CollectAndCount(selectedNamespaces))
+}
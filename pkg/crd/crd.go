@@ -53,17 +53,18 @@ var (
 
 // Apply will create or update all project CRDs inside a Kubernetes cluster.
 // The latest available version of the CRD will be used to perform this operation.
-func Apply(ctx context.Context, istioEnabled bool) error {
-	return operate(ctx, istioEnabled, applyFn)
+// The Istio sidecar of the pod is awaited first when istioSidecar is set.
+func Apply(ctx context.Context, istioSidecar bool) error {
+	return operate(ctx, istioSidecar, applyFn)
 }
 
 // Delete will remove all project CRDs from a Kubernetes cluster.
-func Delete(ctx context.Context, istioEnabled bool) error {
-	return operate(ctx, istioEnabled, deleteFn)
+func Delete(ctx context.Context, istioSidecar bool) error {
+	return operate(ctx, istioSidecar, deleteFn)
 }
 
-func operate(ctx context.Context, istio bool, p crdProcessor) error {
-	if istio {
+func operate(ctx context.Context, istioSidecar bool, p crdProcessor) error {
+	if istioSidecar {
 		quit, err := waitForIstioSidecar()
 		if err != nil {
 			return err
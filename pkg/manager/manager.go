@@ -18,6 +18,7 @@ import (
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 	"github.com/dominodatalab/distributed-compute-operator/controllers"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/namespaces"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -33,6 +34,11 @@ var (
 func Start(cfg *controllers.Config) error {
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&cfg.ZapOptions)))
 
+	if err := cfg.Validate(); err != nil {
+		setupLog.Error(err, "invalid configuration")
+		return err
+	}
+
 	mgrOpts := ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     cfg.MetricsAddr,
@@ -44,6 +50,8 @@ func Start(cfg *controllers.Config) error {
 	if len(cfg.Namespaces) > 0 {
 		setupLog.Info("Limiting reconciliation watch", "namespaces", cfg.Namespaces)
 		mgrOpts.NewCache = cache.MultiNamespacedCacheBuilder(cfg.Namespaces)
+	} else if cfg.NamespaceSelector != nil {
+		setupLog.Info("Limiting reconciliation to selected namespaces", "selector", cfg.NamespaceSelector.String())
 	} else {
 		setupLog.Info("Watching all namespaces")
 	}
@@ -63,7 +71,18 @@ func Start(cfg *controllers.Config) error {
 		return err
 	}
 
+	selector := namespaces.NewSelector(mgr.GetClient(), cfg.NamespaceSelector)
+	if selector != nil {
+		if err = namespaces.NewTracker(selector).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Namespace")
+			return err
+		}
+	}
+
 	enableWebHooks := os.Getenv("ENABLE_WEBHOOKS") != "false" // TODO: add to config
+	if enableWebHooks {
+		dcv1alpha1.SetAPIReader(mgr.GetAPIReader())
+	}
 
 	for _, builder := range controllers.BuilderFuncs {
 		if err = builder(mgr, enableWebHooks, cfg); err != nil {
@@ -76,9 +95,15 @@ func Start(cfg *controllers.Config) error {
 
 	if err = (&controllers.RayClusterReconciler{
 		Client:       mgr.GetClient(),
+		APIReader:    mgr.GetAPIReader(),
 		Log:          ctrl.Log.WithName("controllers").WithName("RayCluster"),
 		Scheme:       mgr.GetScheme(),
+		Recorder:     mgr.GetEventRecorderFor("raycluster-controller"),
 		IstioEnabled: cfg.IstioEnabled,
+		IstioMode:    cfg.IstioMode,
+		SyncImage:    cfg.MPISyncImage,
+		UsageSink:    cfg.UsageSink(),
+		Namespaces:   selector,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RayCluster")
 		return err
@@ -86,9 +111,15 @@ func Start(cfg *controllers.Config) error {
 
 	if err = (&controllers.SparkClusterReconciler{
 		Client:       mgr.GetClient(),
+		APIReader:    mgr.GetAPIReader(),
 		Log:          ctrl.Log.WithName("controllers").WithName("SparkCluster"),
 		Scheme:       mgr.GetScheme(),
+		Recorder:     mgr.GetEventRecorderFor("sparkcluster-controller"),
 		IstioEnabled: cfg.IstioEnabled,
+		IstioMode:    cfg.IstioMode,
+		SyncImage:    cfg.MPISyncImage,
+		UsageSink:    cfg.UsageSink(),
+		Namespaces:   selector,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SparkCluster")
 		return err
@@ -0,0 +1,86 @@
+package rankagent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Client sends launch requests to a rank agent.
+type Client struct {
+	// Address is the "host:port" of the agent.
+	Address string
+	// Token is presented to the agent as a bearer token.
+	Token []byte
+	// HTTPClient is used to send requests; defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Launch starts a process described by req on the agent, copies its output
+// into stdout and stderr, and returns its exit code once it terminates.
+func (c *Client) Launch(ctx context.Context, req *LaunchRequest, stdout, stderr io.Writer) (int, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return 0, fmt.Errorf("cannot encode launch request: %w", err)
+	}
+
+	url := fmt.Sprintf("http://%s%s", c.Address, LaunchPath)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+string(c.Token))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient().Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("cannot reach rank agent %s: %w", c.Address, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return 0, fmt.Errorf("rank agent %s rejected launch: %s: %s",
+			c.Address, resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var frame Frame
+		if err = dec.Decode(&frame); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, fmt.Errorf("cannot read rank agent response: %w", err)
+		}
+
+		switch frame.Stream {
+		case StreamStdout:
+			_, err = stdout.Write(frame.Data)
+		case StreamStderr:
+			_, err = stderr.Write(frame.Data)
+		case StreamExit:
+			if frame.Error != "" {
+				return frame.ExitCode, fmt.Errorf("rank agent %s: %s", c.Address, frame.Error)
+			}
+			return frame.ExitCode, nil
+		default:
+			err = fmt.Errorf("unknown stream %q", frame.Stream)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("cannot relay rank agent output: %w", err)
+		}
+	}
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
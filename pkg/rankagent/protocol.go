@@ -0,0 +1,55 @@
+// Package rankagent implements a small launcher used to start MPI rank
+// processes on cluster workers without sshd.
+//
+// A worker runs the agent Server, which accepts authenticated launch requests
+// over HTTP and executes the requested command. The response is a stream of
+// JSON frames carrying the process output followed by a final frame with the
+// exit code. The Client is meant to be used as a remote shell agent by MPI
+// launchers (e.g. mpirun's "plm_rsh_agent"), so it mirrors the semantics of
+// ssh: the command runs through a shell by default, output is relayed and the
+// exit code of the remote process becomes the exit code of the client.
+package rankagent
+
+const (
+	// DefaultPort is the port the agent listens on when none is provided.
+	DefaultPort = 2224
+
+	// LaunchPath is the URL path that accepts launch requests.
+	LaunchPath = "/v1/launch"
+
+	// TokenKey is the key of the Secret holding the agent token.
+	TokenKey = "token"
+)
+
+// Stream identifies the content of a response frame.
+type Stream string
+
+const (
+	// StreamStdout frames carry data written to the standard output of a process.
+	StreamStdout Stream = "stdout"
+	// StreamStderr frames carry data written to the standard error of a process.
+	StreamStderr Stream = "stderr"
+	// StreamExit is the final frame of every response and carries the exit code.
+	StreamExit Stream = "exit"
+)
+
+// LaunchRequest describes a rank process that should be started by the agent.
+type LaunchRequest struct {
+	// Args is the command and its arguments.
+	Args []string `json:"args"`
+	// Env contains extra "KEY=value" entries added to the agent environment.
+	Env []string `json:"env,omitempty"`
+	// Dir overrides the working directory of the process.
+	Dir string `json:"dir,omitempty"`
+	// Shell joins Args with spaces and runs the result with "sh -c", the same
+	// way sshd interprets remote commands.
+	Shell bool `json:"shell,omitempty"`
+}
+
+// Frame is a single message of a launch response stream.
+type Frame struct {
+	Stream   Stream `json:"stream"`
+	Data     []byte `json:"data,omitempty"`
+	ExitCode int    `json:"exitCode,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
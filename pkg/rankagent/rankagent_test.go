@@ -0,0 +1,208 @@
+package rankagent
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testToken = []byte("test-token")
+
+func startAgent(t *testing.T, server *Server) *Client {
+	t.Helper()
+
+	if server.Token == nil {
+		server.Token = testToken
+	}
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	return &Client{
+		Address: strings.TrimPrefix(ts.URL, "http://"),
+		Token:   testToken,
+	}
+}
+
+func TestLaunch(t *testing.T) {
+	client := startAgent(t, &Server{})
+
+	t.Run("output_and_exit_code", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		req := &LaunchRequest{
+			Args:  []string{"echo out; echo err >&2; exit 3"},
+			Shell: true,
+		}
+
+		exitCode, err := client.Launch(context.Background(), req, &stdout, &stderr)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, exitCode)
+		assert.Equal(t, "out\n", stdout.String())
+		assert.Equal(t, "err\n", stderr.String())
+	})
+
+	t.Run("shell_joins_args", func(t *testing.T) {
+		var stdout bytes.Buffer
+		req := &LaunchRequest{
+			Args:  []string{"echo", "$((1 + 2))"},
+			Shell: true,
+		}
+
+		exitCode, err := client.Launch(context.Background(), req, &stdout, &bytes.Buffer{})
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, exitCode)
+		assert.Equal(t, "3\n", stdout.String())
+	})
+
+	t.Run("exec_without_shell", func(t *testing.T) {
+		var stdout bytes.Buffer
+		req := &LaunchRequest{
+			Args: []string{"echo", "$((1 + 2))"},
+		}
+
+		exitCode, err := client.Launch(context.Background(), req, &stdout, &bytes.Buffer{})
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, exitCode)
+		assert.Equal(t, "$((1 + 2))\n", stdout.String())
+	})
+
+	t.Run("env_and_dir", func(t *testing.T) {
+		dir := t.TempDir()
+
+		var stdout bytes.Buffer
+		req := &LaunchRequest{
+			Args:  []string{`echo "$RANK_TEST" && pwd`},
+			Env:   []string{"RANK_TEST=value"},
+			Dir:   dir,
+			Shell: true,
+		}
+
+		_, err := client.Launch(context.Background(), req, &stdout, &bytes.Buffer{})
+		require.NoError(t, err)
+
+		resolved, err := filepath.EvalSymlinks(dir)
+		require.NoError(t, err)
+		assert.Equal(t, "value\n"+resolved+"\n", stdout.String())
+	})
+
+	t.Run("missing_command", func(t *testing.T) {
+		req := &LaunchRequest{
+			Args: []string{filepath.Join(t.TempDir(), "missing")},
+		}
+
+		exitCode, err := client.Launch(context.Background(), req, &bytes.Buffer{}, &bytes.Buffer{})
+		assert.Error(t, err)
+		assert.Equal(t, 127, exitCode)
+	})
+
+	t.Run("empty_command", func(t *testing.T) {
+		_, err := client.Launch(context.Background(), &LaunchRequest{}, &bytes.Buffer{}, &bytes.Buffer{})
+		assert.ErrorContains(t, err, "400 Bad Request")
+	})
+}
+
+func TestLaunchDefaultDir(t *testing.T) {
+	dir := t.TempDir()
+	client := startAgent(t, &Server{Dir: dir})
+
+	var stdout bytes.Buffer
+	req := &LaunchRequest{Args: []string{"pwd"}}
+
+	_, err := client.Launch(context.Background(), req, &stdout, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	resolved, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	assert.Equal(t, resolved+"\n", stdout.String())
+}
+
+func TestLaunchCancel(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "terminated")
+	client := startAgent(t, &Server{GracePeriod: 5 * time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	req := &LaunchRequest{
+		Args:  []string{"trap 'touch " + marker + "; exit 1' TERM; while true; do sleep 0.1; done"},
+		Shell: true,
+	}
+	_, err := client.Launch(ctx, req, &bytes.Buffer{}, &bytes.Buffer{})
+	assert.Error(t, err)
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(marker)
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond, "process was not terminated")
+}
+
+func TestServerAuthorization(t *testing.T) {
+	testcases := []struct {
+		name   string
+		server []byte
+		client []byte
+	}{
+		{"wrong_token", testToken, []byte("wrong")},
+		{"missing_token", testToken, nil},
+		{"server_without_token", []byte{}, []byte{}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := httptest.NewServer(&Server{Token: tc.server})
+			defer ts.Close()
+
+			client := &Client{Address: strings.TrimPrefix(ts.URL, "http://"), Token: tc.client}
+			req := &LaunchRequest{Args: []string{"true"}}
+
+			_, err := client.Launch(context.Background(), req, &bytes.Buffer{}, &bytes.Buffer{})
+			assert.ErrorContains(t, err, "401 Unauthorized")
+		})
+	}
+}
+
+func TestServerRouting(t *testing.T) {
+	ts := httptest.NewServer(&Server{Token: testToken})
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + LaunchPath)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	resp, err = http.Post(ts.URL+"/other", "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestToken(t *testing.T) {
+	token, err := GenerateToken()
+	require.NoError(t, err)
+	assert.Len(t, token, 64)
+
+	other, err := GenerateToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, append(token, '\n'), 0600))
+
+	actual, err := ReadToken(path)
+	require.NoError(t, err)
+	assert.Equal(t, token, actual)
+
+	require.NoError(t, os.WriteFile(path, []byte(" \n"), 0600))
+	_, err = ReadToken(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,207 @@
+package rankagent
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// Exit code reported when the requested command cannot be started;
+	// matches the code returned by shells for a missing command.
+	startFailedExitCode = 127
+
+	defaultShell       = "/bin/sh"
+	defaultGracePeriod = 10 * time.Second
+)
+
+// Server executes rank processes on behalf of authenticated clients.
+type Server struct {
+	// Token is the shared secret clients must present as a bearer token.
+	Token []byte
+	// Dir is the default working directory of launched processes.
+	Dir string
+	// Shell is used to run commands of shell requests; defaults to /bin/sh.
+	Shell string
+	// GracePeriod is how long a process may run after it has been asked to
+	// terminate because its client went away, before it is killed.
+	GracePeriod time.Duration
+	// Logger records launched processes; nothing is logged when nil.
+	Logger *log.Logger
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != LaunchPath {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req LaunchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid launch request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if len(req.Args) == 0 {
+		http.Error(w, "invalid launch request: command is empty", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	fw := &frameWriter{enc: json.NewEncoder(w)}
+	if f, ok := w.(http.Flusher); ok {
+		fw.flusher = f
+	}
+
+	exitCode, err := s.run(r, &req, fw)
+	exit := Frame{Stream: StreamExit, ExitCode: exitCode}
+	if err != nil {
+		exit.Error = err.Error()
+	}
+	_ = fw.write(exit)
+}
+
+func (s *Server) run(r *http.Request, req *LaunchRequest, fw *frameWriter) (int, error) {
+	var cmd *exec.Cmd
+	if req.Shell {
+		cmd = exec.CommandContext(r.Context(), s.shell(), "-c", strings.Join(req.Args, " "))
+	} else {
+		cmd = exec.CommandContext(r.Context(), req.Args[0], req.Args[1:]...) //nolint:gosec
+	}
+
+	cmd.Env = append(os.Environ(), req.Env...)
+	cmd.Dir = s.Dir
+	if req.Dir != "" {
+		cmd.Dir = req.Dir
+	}
+	cmd.Stdout = fw.stream(StreamStdout)
+	cmd.Stderr = fw.stream(StreamStderr)
+
+	// MPI daemons clean up their children on SIGTERM, so give them a chance
+	// to do so before resorting to SIGKILL.
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(syscall.SIGTERM)
+	}
+	cmd.WaitDelay = s.gracePeriod()
+
+	s.logf("launching %q for %s", req.Args, r.RemoteAddr)
+
+	if err := cmd.Start(); err != nil {
+		s.logf("cannot start %q: %v", req.Args, err)
+		return startFailedExitCode, err
+	}
+
+	err := cmd.Wait()
+	exitCode := exitCodeOf(cmd.ProcessState)
+	s.logf("process %d exited with code %d", cmd.Process.Pid, exitCode)
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		err = nil
+	}
+
+	return exitCode, err
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || len(s.Token) == 0 {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), s.Token) == 1
+}
+
+func (s *Server) shell() string {
+	if s.Shell != "" {
+		return s.Shell
+	}
+	return defaultShell
+}
+
+func (s *Server) gracePeriod() time.Duration {
+	if s.GracePeriod != 0 {
+		return s.GracePeriod
+	}
+	return defaultGracePeriod
+}
+
+func (s *Server) logf(format string, v ...interface{}) {
+	if s.Logger != nil {
+		s.Logger.Printf(format, v...)
+	}
+}
+
+// exitCodeOf follows shell conventions and reports processes terminated by a
+// signal with 128 plus the signal number.
+func exitCodeOf(state *os.ProcessState) int {
+	if state == nil {
+		return startFailedExitCode
+	}
+	if ws, ok := state.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		return 128 + int(ws.Signal())
+	}
+
+	return state.ExitCode()
+}
+
+// frameWriter serializes frames written concurrently by the output streams
+// of a process and flushes each one to the client immediately.
+type frameWriter struct {
+	mu      sync.Mutex
+	enc     *json.Encoder
+	flusher http.Flusher
+}
+
+func (fw *frameWriter) write(frame Frame) error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	if err := fw.enc.Encode(frame); err != nil {
+		return err
+	}
+	if fw.flusher != nil {
+		fw.flusher.Flush()
+	}
+
+	return nil
+}
+
+func (fw *frameWriter) stream(s Stream) *streamWriter {
+	return &streamWriter{fw: fw, stream: s}
+}
+
+type streamWriter struct {
+	fw     *frameWriter
+	stream Stream
+}
+
+func (sw *streamWriter) Write(p []byte) (int, error) {
+	data := make([]byte, len(p))
+	copy(data, p)
+
+	if err := sw.fw.write(Frame{Stream: sw.stream, Data: data}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,40 @@
+package rankagent
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+)
+
+// Number of random bytes in a generated token
+const tokenLength = 32
+
+// GenerateToken returns a new random token suitable for a cluster Secret.
+func GenerateToken() ([]byte, error) {
+	buf := make([]byte, tokenLength)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("cannot generate rank agent token: %w", err)
+	}
+
+	token := make([]byte, hex.EncodedLen(len(buf)))
+	hex.Encode(token, buf)
+
+	return token, nil
+}
+
+// ReadToken loads a token from a file, ignoring surrounding whitespace.
+func ReadToken(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read rank agent token: %w", err)
+	}
+
+	token := bytes.TrimSpace(content)
+	if len(token) == 0 {
+		return nil, fmt.Errorf("rank agent token file %q is empty", path)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,168 @@
+// Package accounting totals the compute resources of cluster pods and exports
+// them as Prometheus metrics for chargeback.
+package accounting
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+var clusterResources = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "distributed_compute_cluster_resources",
+		Help: "Compute resources requested or limited by the pods of a cluster.",
+	},
+	[]string{"kind", "namespace", "name", "node", "scope", "type", "resource"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(clusterResources)
+}
+
+// Compute totals the resources of a cluster. Maximums are derived from the
+// pod templates of the head and worker stateful sets, the latter scaled to
+// maxWorkers, while current totals come from the live pods selected by each
+// stateful set. The head is nil for clusters without one.
+func Compute(head, worker *appsv1.StatefulSet, maxWorkers int32, pods []corev1.Pod) (*dcv1alpha1.ClusterResources, error) {
+	res := &dcv1alpha1.ClusterResources{}
+
+	var err error
+	if head != nil {
+		res.Head, err = nodeResources(head, pointer.Int32Deref(head.Spec.Replicas, 1), pods)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if res.Workers, err = nodeResources(worker, maxWorkers, pods); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Pod returns the effective requests and limits of a pod: the larger of its
+// biggest init container and the sum of its containers, plus pod overhead.
+func Pod(spec *corev1.PodSpec) dcv1alpha1.ResourceTotals {
+	return dcv1alpha1.ResourceTotals{
+		Requests: effective(spec, func(c *corev1.Container) corev1.ResourceList { return c.Resources.Requests }),
+		Limits:   effective(spec, func(c *corev1.Container) corev1.ResourceList { return c.Resources.Limits }),
+	}
+}
+
+// UpdateMetrics replaces the exported metrics of a cluster.
+func UpdateMetrics(kind, namespace, name string, res *dcv1alpha1.ClusterResources) {
+	DeleteMetrics(kind, namespace, name)
+	if res == nil {
+		return
+	}
+
+	set := func(node, scope string, totals dcv1alpha1.ResourceTotals) {
+		for typ, rl := range map[string]corev1.ResourceList{"requests": totals.Requests, "limits": totals.Limits} {
+			for rn, q := range rl {
+				clusterResources.WithLabelValues(kind, namespace, name, node, scope, typ, string(rn)).Set(q.AsApproximateFloat64())
+			}
+		}
+	}
+	set("head", "current", res.Head.Current)
+	set("head", "max", res.Head.Max)
+	set("worker", "current", res.Workers.Current)
+	set("worker", "max", res.Workers.Max)
+}
+
+// DeleteMetrics removes the exported metrics of a cluster.
+func DeleteMetrics(kind, namespace, name string) {
+	clusterResources.DeletePartialMatch(prometheus.Labels{"kind": kind, "namespace": namespace, "name": name})
+}
+
+func nodeResources(sts *appsv1.StatefulSet, maxReplicas int32, pods []corev1.Pod) (dcv1alpha1.NodeResources, error) {
+	var nr dcv1alpha1.NodeResources
+
+	if maxReplicas > 0 {
+		pod := Pod(&sts.Spec.Template.Spec)
+		nr.Max.Requests = scale(pod.Requests, maxReplicas)
+		nr.Max.Limits = scale(pod.Limits, maxReplicas)
+	}
+
+	// a stateful set that has not been created yet selects nothing
+	selector := labels.Nothing()
+	if sts.Spec.Selector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(sts.Spec.Selector); err != nil {
+			return nr, fmt.Errorf("cannot parse %s selector: %w", sts.Name, err)
+		}
+	}
+
+	for idx := range pods {
+		pod := &pods[idx]
+		if !selector.Matches(labels.Set(pod.Labels)) || finished(pod) {
+			continue
+		}
+
+		totals := Pod(&pod.Spec)
+		nr.Current.Requests = add(nr.Current.Requests, totals.Requests)
+		nr.Current.Limits = add(nr.Current.Limits, totals.Limits)
+	}
+
+	return nr, nil
+}
+
+func effective(spec *corev1.PodSpec, list func(*corev1.Container) corev1.ResourceList) corev1.ResourceList {
+	var total corev1.ResourceList
+	for idx := range spec.Containers {
+		total = add(total, list(&spec.Containers[idx]))
+	}
+
+	for idx := range spec.InitContainers {
+		for rn, q := range list(&spec.InitContainers[idx]) {
+			if cur, ok := total[rn]; !ok || q.Cmp(cur) > 0 {
+				if total == nil {
+					total = corev1.ResourceList{}
+				}
+				total[rn] = q.DeepCopy()
+			}
+		}
+	}
+
+	return add(total, spec.Overhead)
+}
+
+func add(total, rl corev1.ResourceList) corev1.ResourceList {
+	for rn, q := range rl {
+		if total == nil {
+			total = corev1.ResourceList{}
+		}
+
+		sum := total[rn]
+		sum.Add(q)
+		total[rn] = sum
+	}
+
+	return total
+}
+
+func scale(rl corev1.ResourceList, n int32) corev1.ResourceList {
+	if len(rl) == 0 {
+		return nil
+	}
+
+	scaled := corev1.ResourceList{}
+	for rn, q := range rl {
+		scaled[rn] = *resource.NewMilliQuantity(q.MilliValue()*int64(n), q.Format)
+	}
+
+	return scaled
+}
+
+func finished(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
@@ -0,0 +1,135 @@
+package accounting
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func resources(cpu, memory string) corev1.ResourceRequirements {
+	rl := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+	return corev1.ResourceRequirements{Requests: rl, Limits: rl}
+}
+
+func assertQuantity(t *testing.T, expected string, rl corev1.ResourceList, name corev1.ResourceName) {
+	t.Helper()
+
+	actual, ok := rl[name]
+	if assert.True(t, ok, "missing %s", name) {
+		want := resource.MustParse(expected)
+		assert.Zero(t, want.Cmp(actual), "expected %s %s, got %s", name, expected, actual.String())
+	}
+}
+
+func TestPod(t *testing.T) {
+	spec := &corev1.PodSpec{
+		InitContainers: []corev1.Container{
+			{Resources: resources("3", "1Gi")},
+		},
+		Containers: []corev1.Container{
+			{Resources: resources("1", "2Gi")},
+			{Resources: resources("500m", "1Gi")},
+		},
+		Overhead: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+	}
+
+	totals := Pod(spec)
+
+	assertQuantity(t, "3100m", totals.Requests, corev1.ResourceCPU)
+	assertQuantity(t, "3Gi", totals.Requests, corev1.ResourceMemory)
+	assertQuantity(t, "3100m", totals.Limits, corev1.ResourceCPU)
+	assert.Empty(t, Pod(&corev1.PodSpec{Containers: []corev1.Container{{}}}).Requests)
+}
+
+func TestCompute(t *testing.T) {
+	newStatefulSet := func(component, cpu string) *appsv1.StatefulSet {
+		sts := &appsv1.StatefulSet{}
+		sts.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"component": component}}
+		sts.Spec.Template.Spec.Containers = []corev1.Container{{Resources: resources(cpu, "1Gi")}}
+		return sts
+	}
+	newPod := func(component, cpu string, phase corev1.PodPhase) corev1.Pod {
+		pod := corev1.Pod{}
+		pod.Labels = map[string]string{"component": component}
+		pod.Spec.Containers = []corev1.Container{{Resources: resources(cpu, "1Gi")}}
+		pod.Status.Phase = phase
+		return pod
+	}
+
+	head := newStatefulSet("head", "2")
+	head.Spec.Replicas = pointer.Int32(1)
+	worker := newStatefulSet("worker", "1")
+	pods := []corev1.Pod{
+		newPod("head", "2", corev1.PodRunning),
+		newPod("worker", "1", corev1.PodRunning),
+		newPod("worker", "1", corev1.PodPending),
+		newPod("worker", "1", corev1.PodFailed),
+		newPod("other", "8", corev1.PodRunning),
+	}
+
+	t.Run("head_and_workers", func(t *testing.T) {
+		res, err := Compute(head, worker, 5, pods)
+		require.NoError(t, err)
+
+		assertQuantity(t, "2", res.Head.Current.Requests, corev1.ResourceCPU)
+		assertQuantity(t, "2", res.Head.Max.Requests, corev1.ResourceCPU)
+		assertQuantity(t, "2", res.Workers.Current.Requests, corev1.ResourceCPU)
+		assertQuantity(t, "2Gi", res.Workers.Current.Limits, corev1.ResourceMemory)
+		assertQuantity(t, "5", res.Workers.Max.Requests, corev1.ResourceCPU)
+		assertQuantity(t, "5Gi", res.Workers.Max.Limits, corev1.ResourceMemory)
+	})
+
+	t.Run("no_head", func(t *testing.T) {
+		res, err := Compute(nil, worker, 0, pods)
+		require.NoError(t, err)
+
+		assert.Equal(t, dcv1alpha1.NodeResources{}, res.Head)
+		assert.Empty(t, res.Workers.Max.Requests)
+		assertQuantity(t, "2", res.Workers.Current.Requests, corev1.ResourceCPU)
+	})
+
+	t.Run("missing_stateful_set", func(t *testing.T) {
+		res, err := Compute(nil, &appsv1.StatefulSet{}, 3, pods)
+		require.NoError(t, err)
+
+		assert.Equal(t, dcv1alpha1.NodeResources{}, res.Workers)
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	res := &dcv1alpha1.ClusterResources{
+		Workers: dcv1alpha1.NodeResources{
+			Current: dcv1alpha1.ResourceTotals{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1500m")}},
+			Max:     dcv1alpha1.ResourceTotals{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")}},
+		},
+	}
+
+	UpdateMetrics("RayCluster", "ns", "test", res)
+	UpdateMetrics("RayCluster", "ns", "other", res)
+
+	expected := `
+# HELP distributed_compute_cluster_resources Compute resources requested or limited by the pods of a cluster.
+# TYPE distributed_compute_cluster_resources gauge
+distributed_compute_cluster_resources{kind="RayCluster",name="other",namespace="ns",node="worker",resource="cpu",scope="current",type="requests"} 1.5
+distributed_compute_cluster_resources{kind="RayCluster",name="other",namespace="ns",node="worker",resource="cpu",scope="max",type="requests"} 3
+distributed_compute_cluster_resources{kind="RayCluster",name="test",namespace="ns",node="worker",resource="cpu",scope="current",type="requests"} 1.5
+distributed_compute_cluster_resources{kind="RayCluster",name="test",namespace="ns",node="worker",resource="cpu",scope="max",type="requests"} 3
+`
+	assert.NoError(t, testutil.CollectAndCompare(clusterResources, strings.NewReader(expected)))
+
+	DeleteMetrics("RayCluster", "ns", "other")
+	assert.Equal(t, 2, testutil.CollectAndCount(clusterResources))
+}
@@ -0,0 +1,52 @@
+// Package clientaccess builds the network policy peers that grant clients
+// ingress access to cluster nodes. Clients may run in the cluster namespace,
+// in other namespaces selected by label, or outside of kubernetes.
+package clientaccess
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+// Peers returns the peers granted ingress access to client ports. Pods
+// matching the client labels are selected in namespaces matching the client
+// namespace labels, or in the cluster namespace when none are provided. Every
+// client IP block is added as a separate peer.
+func Peers(np dcv1alpha1.NetworkPolicyConfig) []networkingv1.NetworkPolicyPeer {
+	peer := networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: np.ClientLabels,
+		},
+	}
+	if np.ClientNamespaceLabels != nil {
+		peer.NamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: np.ClientNamespaceLabels,
+		}
+	}
+
+	return append([]networkingv1.NetworkPolicyPeer{peer}, IPBlockPeers(np)...)
+}
+
+// IPBlockPeers returns a peer for every client IP block.
+func IPBlockPeers(np dcv1alpha1.NetworkPolicyConfig) []networkingv1.NetworkPolicyPeer {
+	var peers []networkingv1.NetworkPolicyPeer
+	for idx := range np.ClientIPBlocks {
+		block := np.ClientIPBlocks[idx]
+		peers = append(peers, networkingv1.NetworkPolicyPeer{IPBlock: &block})
+	}
+
+	return peers
+}
+
+// StatusPeers returns the given peers when network policies are enabled and
+// nil otherwise, since no ingress restrictions apply without policies.
+func StatusPeers(np dcv1alpha1.NetworkPolicyConfig, peers []networkingv1.NetworkPolicyPeer) []networkingv1.NetworkPolicyPeer {
+	if util.BoolPtrIsNilOrFalse(np.Enabled) {
+		return nil
+	}
+
+	return peers
+}
@@ -0,0 +1,63 @@
+package clientaccess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestPeers(t *testing.T) {
+	np := dcv1alpha1.NetworkPolicyConfig{
+		Enabled:      pointer.Bool(true),
+		ClientLabels: map[string]string{"client": "true"},
+	}
+
+	t.Run("same_namespace", func(t *testing.T) {
+		assert.Equal(t, []networkingv1.NetworkPolicyPeer{
+			{
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"client": "true"},
+				},
+			},
+		}, Peers(np))
+	})
+
+	t.Run("cross_namespace", func(t *testing.T) {
+		np := np
+		np.ClientNamespaceLabels = map[string]string{"team": "data"}
+		np.ClientIPBlocks = []networkingv1.IPBlock{
+			{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}},
+			{CIDR: "192.168.0.0/24"},
+		}
+
+		assert.Equal(t, []networkingv1.NetworkPolicyPeer{
+			{
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"client": "true"},
+				},
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": "data"},
+				},
+			},
+			{
+				IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}},
+			},
+			{
+				IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.0/24"},
+			},
+		}, Peers(np))
+	})
+}
+
+func TestStatusPeers(t *testing.T) {
+	np := dcv1alpha1.NetworkPolicyConfig{ClientLabels: map[string]string{"client": "true"}}
+	assert.Nil(t, StatusPeers(np, Peers(np)))
+
+	np.Enabled = pointer.Bool(true)
+	assert.Equal(t, Peers(np), StatusPeers(np, Peers(np)))
+}
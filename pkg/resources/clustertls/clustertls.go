@@ -0,0 +1,311 @@
+// Package clustertls issues the certificates that secure the traffic between
+// cluster nodes and clients. Certificates are either requested from a
+// cert-manager issuer or signed by a CA that the operator generates for each
+// cluster. cert-manager APIs are provided by optional CRDs, so Certificates
+// are unstructured and a missing API is treated the same as a missing object.
+//
+// Frameworks that connect to nodes by pod IP can have each pod sign its own
+// node certificate at startup, since pod IPs are unknown until pods run. The
+// cluster CA then signs pod certificates: it is generated by the operator, or
+// issued by cert-manager as an intermediate CA of the referenced issuer.
+package clustertls
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+const (
+	// CAKey holds the PEM-encoded CA certificate in TLS Secrets.
+	CAKey = "ca.crt"
+	// VolumeName of the pod volume that holds the node certificate.
+	VolumeName = "cluster-tls"
+	// PodCertificateInitContainerName of the init container that signs pod
+	// certificates.
+	PodCertificateInitContainerName = "cluster-tls-init"
+
+	caPrivateKeyKey = "ca.key"
+	caVolumeName    = "cluster-tls-ca"
+	caMountPath     = "/etc/cluster-tls-ca"
+	podCertMountDir = "/etc/cluster-tls"
+)
+
+// podCertificateScript signs a node certificate that is valid for the pod IP
+// and bundles the signing certificate, so that intermediate CAs are trusted.
+const podCertificateScript = `set -e
+cd "$TLS_DIR"
+printf 'basicConstraints=CA:FALSE\nkeyUsage=digitalSignature,keyEncipherment\nextendedKeyUsage=serverAuth,clientAuth\nsubjectAltName=%s,IP:%s\n' "$TLS_SUBJECT_ALT_NAMES" "$POD_IP" > ext.cnf
+openssl req -new -newkey ec -pkeyopt ec_paramgen_curve:prime256v1 -nodes -subj "/CN=$TLS_COMMON_NAME" -keyout tls.key -out tls.csr
+openssl x509 -req -in tls.csr -CA "$TLS_SIGNING_CERT" -CAkey "$TLS_SIGNING_KEY" -set_serial "0x$(openssl rand -hex 16)" -days "$TLS_VALIDITY_DAYS" -extfile ext.cnf -out tls.crt
+cat "$TLS_SIGNING_CERT" >> tls.crt
+cp "$TLS_CA_CERT" ca.crt
+rm tls.csr ext.cnf`
+
+// CertificateGroupVersionKind of the cert-manager Certificate.
+var CertificateGroupVersionKind = schema.GroupVersionKind{
+	Group:   "cert-manager.io",
+	Version: "v1",
+	Kind:    "Certificate",
+}
+
+// Info describes the certificates of a cluster.
+type Info struct {
+	Namespace string
+	Labels    map[string]string
+	// CASecretName holds the CA generated by the operator.
+	CASecretName string
+	// SecretName holds the certificate presented by cluster nodes.
+	SecretName string
+	// ClientSecretName holds the certificate published for cluster clients.
+	ClientSecretName string
+	// CommonName, DNSNames and IPAddresses of the node certificate.
+	CommonName  string
+	DNSNames    []string
+	IPAddresses []string
+	// ClientCommonName of the client certificate.
+	ClientCommonName string
+	// PodCertificates replaces the node certificate Secret with certificates
+	// that pods sign at startup and that are also valid for the pod IP.
+	PodCertificates bool
+	Config          *dcv1alpha1.TLSConfig
+}
+
+// Enabled returns true when cluster traffic should be encrypted.
+func Enabled(tc *dcv1alpha1.TLSConfig) bool {
+	return tc != nil && tc.Enabled
+}
+
+// CertManagerEnabled returns true when certificates are issued by cert-manager.
+func CertManagerEnabled(tc *dcv1alpha1.TLSConfig) bool {
+	return Enabled(tc) && tc.IssuerRef != nil
+}
+
+// NewCertificates returns the cert-manager Certificates of the node and
+// client certificates. The node certificate is replaced by an intermediate CA
+// when pods sign their own certificates.
+func NewCertificates(info *Info) []*unstructured.Unstructured {
+	clientCert := newCertificate(info, info.ClientSecretName, info.ClientCommonName, nil, nil, "client auth")
+
+	if info.PodCertificates {
+		ca := newCertificate(info, info.CASecretName, CACommonName(info), nil, nil, "cert sign", "digital signature")
+		ca.Object["spec"].(map[string]interface{})["isCA"] = true
+
+		return []*unstructured.Unstructured{ca, clientCert}
+	}
+
+	return []*unstructured.Unstructured{
+		newCertificate(info, info.SecretName, info.CommonName, info.DNSNames, info.IPAddresses, "server auth", "client auth"),
+		clientCert,
+	}
+}
+
+// CertificateReferences returns empty Certificates that identify existing objects.
+func CertificateReferences(info *Info) []client.Object {
+	return []client.Object{
+		certificateReference(info.CASecretName, info.Namespace),
+		certificateReference(info.SecretName, info.Namespace),
+		certificateReference(info.ClientSecretName, info.Namespace),
+	}
+}
+
+// SecretReferences returns empty Secrets that identify the CA, node and
+// client Secrets.
+func SecretReferences(info *Info) []client.Object {
+	return []client.Object{
+		CASecretReference(info),
+		NodeSecretReference(info),
+		secretReference(info.ClientSecretName, info.Namespace),
+	}
+}
+
+// CASecretReference returns an empty Secret that identifies the CA Secret.
+func CASecretReference(info *Info) *corev1.Secret {
+	return secretReference(info.CASecretName, info.Namespace)
+}
+
+// NodeSecretReference returns an empty Secret that identifies the node Secret.
+func NodeSecretReference(info *Info) *corev1.Secret {
+	return secretReference(info.SecretName, info.Namespace)
+}
+
+// NewVolume returns the pod volume that holds the node certificate.
+func NewVolume(secretName string) corev1.Volume {
+	return corev1.Volume{
+		Name: VolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	}
+}
+
+// NewVolumeMount mounts the node certificate at the given path. The CA
+// certificate, certificate and private key are found under CAKey,
+// corev1.TLSCertKey and corev1.TLSPrivateKeyKey.
+func NewVolumeMount(mountPath string) corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      VolumeName,
+		MountPath: mountPath,
+		ReadOnly:  true,
+	}
+}
+
+// PodCertificateVolumes returns the pod volumes that hold the cluster CA and
+// the certificate signed by the init container. The certificate volume is
+// mounted in framework containers with NewVolumeMount.
+func PodCertificateVolumes(info *Info) []corev1.Volume {
+	return []corev1.Volume{
+		{
+			Name: caVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: info.CASecretName,
+				},
+			},
+		},
+		{
+			Name: VolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium: corev1.StorageMediumMemory,
+				},
+			},
+		},
+	}
+}
+
+// PodCertificateInitContainer returns a container that signs the node
+// certificate of the pod with the cluster CA. The image must provide openssl.
+func PodCertificateInitContainer(info *Info, image string, pullPolicy corev1.PullPolicy) corev1.Container {
+	// cert-manager stores the intermediate CA as a regular TLS Secret
+	signingCert, signingKey := CAKey, caPrivateKeyKey
+	if CertManagerEnabled(info.Config) {
+		signingCert, signingKey = corev1.TLSCertKey, corev1.TLSPrivateKeyKey
+	}
+
+	var sans []string
+	for _, name := range info.DNSNames {
+		sans = append(sans, "DNS:"+name)
+	}
+	for _, ip := range info.IPAddresses {
+		sans = append(sans, "IP:"+ip)
+	}
+
+	return corev1.Container{
+		Name:            PodCertificateInitContainerName,
+		Image:           image,
+		ImagePullPolicy: pullPolicy,
+		Command:         []string{"/bin/sh", "-c"},
+		Args:            []string{podCertificateScript},
+		Env: []corev1.EnvVar{
+			{
+				Name: "POD_IP",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"},
+				},
+			},
+			{Name: "TLS_DIR", Value: podCertMountDir},
+			{Name: "TLS_COMMON_NAME", Value: info.CommonName},
+			{Name: "TLS_SUBJECT_ALT_NAMES", Value: strings.Join(sans, ",")},
+			{Name: "TLS_SIGNING_CERT", Value: path.Join(caMountPath, signingCert)},
+			{Name: "TLS_SIGNING_KEY", Value: path.Join(caMountPath, signingKey)},
+			{Name: "TLS_CA_CERT", Value: path.Join(caMountPath, CAKey)},
+			{Name: "TLS_VALIDITY_DAYS", Value: strconv.Itoa(int(certValidity.Hours() / 24))},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: caVolumeName, MountPath: caMountPath, ReadOnly: true},
+			{Name: VolumeName, MountPath: podCertMountDir},
+		},
+	}
+}
+
+// CACommonName returns the common name of the cluster CA.
+func CACommonName(info *Info) string {
+	return info.CommonName + "-ca"
+}
+
+func secretReference(name, namespace string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
+
+func certificateReference(name, namespace string) *unstructured.Unstructured {
+	cert := &unstructured.Unstructured{}
+	cert.SetGroupVersionKind(CertificateGroupVersionKind)
+	cert.SetName(name)
+	cert.SetNamespace(namespace)
+
+	return cert
+}
+
+func newCertificate(
+	info *Info,
+	secretName, commonName string,
+	dnsNames, ipAddresses []string,
+	usages ...string,
+) *unstructured.Unstructured {
+	ref := info.Config.IssuerRef
+
+	issuerRef := map[string]interface{}{
+		"name": ref.Name,
+	}
+	if ref.Kind != "" {
+		issuerRef["kind"] = ref.Kind
+	}
+	if ref.Group != "" {
+		issuerRef["group"] = ref.Group
+	}
+
+	spec := map[string]interface{}{
+		"secretName": secretName,
+		"commonName": commonName,
+		"issuerRef":  issuerRef,
+		"usages":     toInterfaces(usages),
+		"privateKey": map[string]interface{}{
+			"algorithm": "ECDSA",
+			"size":      int64(256),
+		},
+	}
+	if len(dnsNames) > 0 {
+		spec["dnsNames"] = toInterfaces(dnsNames)
+	}
+	if len(ipAddresses) > 0 {
+		spec["ipAddresses"] = toInterfaces(ipAddresses)
+	}
+	if len(info.Labels) > 0 {
+		labels := map[string]interface{}{}
+		for k, v := range info.Labels {
+			labels[k] = v
+		}
+		spec["secretTemplate"] = map[string]interface{}{"labels": labels}
+	}
+
+	cert := certificateReference(secretName, info.Namespace)
+	cert.SetLabels(info.Labels)
+	cert.Object["spec"] = spec
+
+	return cert
+}
+
+func toInterfaces(values []string) []interface{} {
+	out := make([]interface{}, len(values))
+	for idx, v := range values {
+		out[idx] = v
+	}
+
+	return out
+}
@@ -0,0 +1,199 @@
+package clustertls
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func testInfo(tc *dcv1alpha1.TLSConfig) *Info {
+	return &Info{
+		Namespace:        "ns",
+		Labels:           map[string]string{"app": "dask"},
+		CASecretName:     "test-dask-tls-ca",
+		SecretName:       "test-dask-tls",
+		ClientSecretName: "test-dask-client-tls",
+		CommonName:       "test-dask",
+		DNSNames:         []string{"test-dask-scheduler", "*.test-dask-worker.ns.svc"},
+		ClientCommonName: "test-dask-client-tls",
+		Config:           tc,
+	}
+}
+
+func parseCertificate(t *testing.T, data []byte) *x509.Certificate {
+	block, _ := pem.Decode(data)
+	require.NotNil(t, block)
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	return cert
+}
+
+func TestNewCertificates(t *testing.T) {
+	info := testInfo(&dcv1alpha1.TLSConfig{
+		Enabled:   true,
+		IssuerRef: &dcv1alpha1.CertManagerIssuerReference{Name: "cluster-ca", Kind: "ClusterIssuer"},
+	})
+	certs := NewCertificates(info)
+	require.Len(t, certs, 2)
+
+	node := certs[0]
+	assert.Equal(t, "cert-manager.io/v1", node.GetAPIVersion())
+	assert.Equal(t, "Certificate", node.GetKind())
+	assert.Equal(t, "test-dask-tls", node.GetName())
+	assert.Equal(t, map[string]interface{}{
+		"secretName": "test-dask-tls",
+		"commonName": "test-dask",
+		"dnsNames":   []interface{}{"test-dask-scheduler", "*.test-dask-worker.ns.svc"},
+		"issuerRef":  map[string]interface{}{"name": "cluster-ca", "kind": "ClusterIssuer"},
+		"usages":     []interface{}{"server auth", "client auth"},
+		"privateKey": map[string]interface{}{"algorithm": "ECDSA", "size": int64(256)},
+		"secretTemplate": map[string]interface{}{
+			"labels": map[string]interface{}{"app": "dask"},
+		},
+	}, node.Object["spec"])
+
+	client := certs[1].Object["spec"].(map[string]interface{})
+	assert.Equal(t, "test-dask-client-tls", client["secretName"])
+	assert.Equal(t, []interface{}{"client auth"}, client["usages"])
+	assert.NotContains(t, client, "dnsNames")
+
+	t.Run("ip_addresses", func(t *testing.T) {
+		info := testInfo(info.Config)
+		info.IPAddresses = []string{"127.0.0.1"}
+
+		node := NewCertificates(info)[0].Object["spec"].(map[string]interface{})
+		assert.Equal(t, []interface{}{"127.0.0.1"}, node["ipAddresses"])
+	})
+
+	t.Run("pod_certificates", func(t *testing.T) {
+		info := testInfo(info.Config)
+		info.PodCertificates = true
+
+		certs := NewCertificates(info)
+		require.Len(t, certs, 2)
+		assert.Equal(t, "test-dask-tls-ca", certs[0].GetName())
+
+		ca := certs[0].Object["spec"].(map[string]interface{})
+		assert.Equal(t, "test-dask-tls-ca", ca["secretName"])
+		assert.Equal(t, "test-dask-ca", ca["commonName"])
+		assert.Equal(t, true, ca["isCA"])
+		assert.Equal(t, []interface{}{"cert sign", "digital signature"}, ca["usages"])
+		assert.Equal(t, "test-dask-client-tls", certs[1].GetName())
+	})
+}
+
+func TestPodCertificateInitContainer(t *testing.T) {
+	info := testInfo(&dcv1alpha1.TLSConfig{Enabled: true})
+	info.IPAddresses = []string{"127.0.0.1"}
+	info.PodCertificates = true
+
+	volumes := PodCertificateVolumes(info)
+	require.Len(t, volumes, 2)
+	assert.Equal(t, "test-dask-tls-ca", volumes[0].Secret.SecretName)
+	assert.Equal(t, VolumeName, volumes[1].Name)
+	assert.Equal(t, corev1.StorageMediumMemory, volumes[1].EmptyDir.Medium)
+
+	container := PodCertificateInitContainer(info, "ray:latest", corev1.PullIfNotPresent)
+	assert.Equal(t, "ray:latest", container.Image)
+	assert.Equal(t, corev1.PullIfNotPresent, container.ImagePullPolicy)
+	assert.Equal(t, "status.podIP", container.Env[0].ValueFrom.FieldRef.FieldPath)
+	assert.Contains(t, container.Env, corev1.EnvVar{
+		Name:  "TLS_SUBJECT_ALT_NAMES",
+		Value: "DNS:test-dask-scheduler,DNS:*.test-dask-worker.ns.svc,IP:127.0.0.1",
+	})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "TLS_SIGNING_KEY", Value: "/etc/cluster-tls-ca/ca.key"})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "TLS_VALIDITY_DAYS", Value: "365"})
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: VolumeName, MountPath: "/etc/cluster-tls"})
+
+	t.Run("cert_manager", func(t *testing.T) {
+		info.Config.IssuerRef = &dcv1alpha1.CertManagerIssuerReference{Name: "cluster-ca"}
+
+		container := PodCertificateInitContainer(info, "ray:latest", "")
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "TLS_SIGNING_CERT", Value: "/etc/cluster-tls-ca/tls.crt"})
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "TLS_SIGNING_KEY", Value: "/etc/cluster-tls-ca/tls.key"})
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "TLS_CA_CERT", Value: "/etc/cluster-tls-ca/ca.crt"})
+	})
+}
+
+func TestIssueCertificates(t *testing.T) {
+	info := testInfo(&dcv1alpha1.TLSConfig{Enabled: true})
+	now := time.Now()
+
+	ca, err := LoadCA(nil, "test-dask-ca", now)
+	require.NoError(t, err)
+	caSecret := NewCASecret(info, ca)
+	assert.Equal(t, "test-dask-tls-ca", caSecret.Name)
+
+	node, err := NewNodeSecret(info, ca, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.SecretTypeTLS, node.Type)
+	assert.Equal(t, caSecret.Data[CAKey], node.Data[CAKey])
+
+	cert := parseCertificate(t, node.Data[corev1.TLSCertKey])
+	assert.Equal(t, "test-dask", cert.Subject.CommonName)
+	assert.Equal(t, info.DNSNames, cert.DNSNames)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}, cert.ExtKeyUsage)
+	assert.NoError(t, cert.CheckSignatureFrom(ca.cert))
+
+	client, err := NewClientSecret(info, ca, nil, now)
+	require.NoError(t, err)
+	clientCert := parseCertificate(t, client.Data[corev1.TLSCertKey])
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, clientCert.ExtKeyUsage)
+
+	t.Run("reuse", func(t *testing.T) {
+		loaded, err := LoadCA(caSecret, "test-dask-ca", now)
+		require.NoError(t, err)
+		assert.Equal(t, ca.certPEM, loaded.certPEM)
+
+		reissued, err := NewNodeSecret(info, loaded, node, now)
+		require.NoError(t, err)
+		assert.Equal(t, node.Data, reissued.Data)
+	})
+
+	t.Run("renew", func(t *testing.T) {
+		info := testInfo(info.Config)
+		info.DNSNames = append(info.DNSNames, "extra")
+
+		reissued, err := NewNodeSecret(info, ca, node, now)
+		require.NoError(t, err)
+		assert.NotEqual(t, node.Data[corev1.TLSCertKey], reissued.Data[corev1.TLSCertKey])
+
+		reissued, err = NewNodeSecret(testInfo(info.Config), ca, node, now.Add(certValidity))
+		require.NoError(t, err)
+		assert.NotEqual(t, node.Data[corev1.TLSCertKey], reissued.Data[corev1.TLSCertKey])
+	})
+
+	t.Run("ip_addresses", func(t *testing.T) {
+		info := testInfo(info.Config)
+		info.IPAddresses = []string{"127.0.0.1"}
+
+		reissued, err := NewNodeSecret(info, ca, node, now)
+		require.NoError(t, err)
+		cert := parseCertificate(t, reissued.Data[corev1.TLSCertKey])
+		require.Len(t, cert.IPAddresses, 1)
+		assert.Equal(t, "127.0.0.1", cert.IPAddresses[0].String())
+
+		again, err := NewNodeSecret(info, ca, reissued, now)
+		require.NoError(t, err)
+		assert.Equal(t, reissued.Data, again.Data)
+	})
+
+	t.Run("new_ca", func(t *testing.T) {
+		other, err := LoadCA(nil, "test-dask-ca", now)
+		require.NoError(t, err)
+
+		reissued, err := NewNodeSecret(info, other, node, now)
+		require.NoError(t, err)
+		assert.Equal(t, other.certPEM, reissued.Data[CAKey])
+		assert.NoError(t, parseCertificate(t, reissued.Data[corev1.TLSCertKey]).CheckSignatureFrom(other.cert))
+	})
+}
@@ -0,0 +1,266 @@
+package clustertls
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	caValidity   = 10 * 365 * 24 * time.Hour
+	certValidity = 365 * 24 * time.Hour
+	// certificates are reissued once they are this close to expiring.
+	renewBefore = 30 * 24 * time.Hour
+)
+
+// CA signs the certificates of a cluster when cert-manager is not used.
+type CA struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// LoadCA returns the CA stored in an existing CA Secret, or a new one when
+// the Secret is missing, invalid or about to expire. The CA is preserved
+// across reconciliations, otherwise nodes and clients holding certificates
+// signed by it would no longer be trusted.
+func LoadCA(current *corev1.Secret, commonName string, now time.Time) (*CA, error) {
+	if current != nil {
+		cert, key, err := parseKeyPair(current.Data[CAKey], current.Data[caPrivateKeyKey])
+		if err == nil && cert.IsCA && now.Add(renewBefore).Before(cert.NotAfter) {
+			return &CA{
+				cert:    cert,
+				key:     key,
+				certPEM: current.Data[CAKey],
+				keyPEM:  current.Data[caPrivateKeyKey],
+			}, nil
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate ca key: %w", err)
+	}
+	tmpl, err := certificateTemplate(commonName, now, caValidity)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.IsCA = true
+	tmpl.BasicConstraintsValid = true
+	tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create ca certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := encodeKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CA{
+		cert:    cert,
+		key:     key,
+		certPEM: encodeCertificate(der),
+		keyPEM:  keyPEM,
+	}, nil
+}
+
+// NewCASecret returns the Secret that stores the CA of a cluster.
+func NewCASecret(info *Info, ca *CA) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      info.CASecretName,
+			Namespace: info.Namespace,
+			Labels:    info.Labels,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			CAKey:           ca.certPEM,
+			caPrivateKeyKey: ca.keyPEM,
+		},
+	}
+}
+
+// NewNodeSecret returns the Secret that holds the certificate of cluster
+// nodes, which is used both to serve and to connect to other nodes.
+func NewNodeSecret(info *Info, ca *CA, current *corev1.Secret, now time.Time) (*corev1.Secret, error) {
+	usages := []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	return newLeafSecret(info, info.SecretName, info.CommonName, info.DNSNames, info.IPAddresses, usages, ca, current, now)
+}
+
+// NewClientSecret returns the Secret that holds the certificate published
+// for cluster clients.
+func NewClientSecret(info *Info, ca *CA, current *corev1.Secret, now time.Time) (*corev1.Secret, error) {
+	usages := []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	return newLeafSecret(info, info.ClientSecretName, info.ClientCommonName, nil, nil, usages, ca, current, now)
+}
+
+// newLeafSecret reuses the certificate of the current Secret while it is
+// signed by the CA, matches the requested names and is not about to expire.
+// Otherwise, a new certificate is issued.
+func newLeafSecret(
+	info *Info,
+	name, commonName string,
+	dnsNames, ipAddresses []string,
+	usages []x509.ExtKeyUsage,
+	ca *CA,
+	current *corev1.Secret,
+	now time.Time,
+) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: info.Namespace,
+			Labels:    info.Labels,
+		},
+		Type: corev1.SecretTypeTLS,
+	}
+
+	if current != nil && leafValid(current, ca, commonName, dnsNames, ipAddresses, now) {
+		secret.Data = map[string][]byte{
+			CAKey:                   ca.certPEM,
+			corev1.TLSCertKey:       current.Data[corev1.TLSCertKey],
+			corev1.TLSPrivateKeyKey: current.Data[corev1.TLSPrivateKeyKey],
+		}
+		return secret, nil
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate certificate key: %w", err)
+	}
+	tmpl, err := certificateTemplate(commonName, now, certValidity)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.DNSNames = dnsNames
+	for _, addr := range ipAddresses {
+		tmpl.IPAddresses = append(tmpl.IPAddresses, net.ParseIP(addr))
+	}
+	tmpl.ExtKeyUsage = usages
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create certificate %q: %w", commonName, err)
+	}
+	keyPEM, err := encodeKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	secret.Data = map[string][]byte{
+		CAKey:                   ca.certPEM,
+		corev1.TLSCertKey:       encodeCertificate(der),
+		corev1.TLSPrivateKeyKey: keyPEM,
+	}
+
+	return secret, nil
+}
+
+func leafValid(current *corev1.Secret, ca *CA, commonName string, dnsNames, ipAddresses []string, now time.Time) bool {
+	if !bytes.Equal(current.Data[CAKey], ca.certPEM) {
+		return false
+	}
+
+	cert, _, err := parseKeyPair(current.Data[corev1.TLSCertKey], current.Data[corev1.TLSPrivateKeyKey])
+	if err != nil || cert.CheckSignatureFrom(ca.cert) != nil {
+		return false
+	}
+
+	return cert.Subject.CommonName == commonName &&
+		equalNames(cert.DNSNames, dnsNames) &&
+		equalNames(ipStrings(cert.IPAddresses), ipAddresses) &&
+		now.Add(renewBefore).Before(cert.NotAfter)
+}
+
+func certificateTemplate(commonName string, now time.Time, validity time.Duration) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate serial number: %w", err)
+	}
+
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(validity),
+	}, nil
+}
+
+func parseKeyPair(certPEM, keyPEM []byte) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	keyBlock, _ := pem.Decode(keyPEM)
+	if certBlock == nil || keyBlock == nil {
+		return nil, nil, fmt.Errorf("invalid pem data")
+	}
+
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cert, key, nil
+}
+
+func encodeCertificate(der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func encodeKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode private key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+}
+
+func ipStrings(ips []net.IP) []string {
+	out := make([]string, len(ips))
+	for idx, ip := range ips {
+		out[idx] = ip.String()
+	}
+
+	return out
+}
+
+func equalNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,332 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+const (
+	// AuthContainerName of the proxy sidecar.
+	AuthContainerName = "dashboard-auth"
+	// AuthPortName of the proxy container port.
+	AuthPortName = "dashboard-auth"
+	// AuthSubresource of the cluster object that TokenReview requests are
+	// authorized against.
+	AuthSubresource = "dashboard"
+
+	authConfigVolumeName = "dashboard-auth-config"
+	authConfigMountPath  = "/etc/kube-rbac-proxy"
+	authConfigKey        = "config.yaml"
+	authCallbackPath     = "/oauth2"
+
+	// authTokenVolumeName holds the API credentials of the TokenReview proxy.
+	// It is mounted at the default in-cluster path so that the proxy finds it
+	// without flags.
+	authTokenVolumeName = "dashboard-auth-token"
+	authTokenMountPath  = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+	// authDelegatorRole grants permission to create TokenReviews and
+	// SubjectAccessReviews.
+	authDelegatorRole = "system:auth-delegator"
+)
+
+var (
+	defaultOIDCImage = &dcv1alpha1.OCIImageDefinition{
+		Registry:   "quay.io",
+		Repository: "oauth2-proxy/oauth2-proxy",
+		Tag:        "v7.5.1",
+	}
+	defaultTokenReviewImage = &dcv1alpha1.OCIImageDefinition{
+		Registry:   "quay.io",
+		Repository: "brancz/kube-rbac-proxy",
+		Tag:        "v0.15.0",
+	}
+)
+
+// AuthInfo describes the proxy that authenticates requests to a cluster
+// dashboard.
+type AuthInfo struct {
+	// ClusterName, Namespace and Resource identify the cluster object that
+	// TokenReview requests are authorized against.
+	ClusterName string
+	Namespace   string
+	Resource    string
+	// ConfigMapName and Labels of the kube-rbac-proxy configuration. The
+	// name is also used by the cluster role binding of the proxy, prefixed by
+	// the namespace.
+	ConfigMapName string
+	Labels        map[string]string
+	// ServiceAccount of the pod serving the dashboard, which is bound to the
+	// auth delegator role when TokenReview authentication is used.
+	ServiceAccount string
+	// UpstreamPort of the dashboard server.
+	UpstreamPort int32
+	Config       *dcv1alpha1.DashboardConfig
+}
+
+// Port returns the port clients use to reach the dashboard, which is the
+// proxy port when authentication is enabled.
+func Port(dc *dcv1alpha1.DashboardConfig, dashboardPort int32) int32 {
+	if dc.AuthEnabled() {
+		return dc.Auth.Port
+	}
+	return dashboardPort
+}
+
+// AuthConfigMapEnabled returns true when the proxy reads its configuration
+// from a config map, which is only the case for TokenReview authentication.
+func AuthConfigMapEnabled(dc *dcv1alpha1.DashboardConfig) bool {
+	return dc.AuthEnabled() && dc.Auth.Type == dcv1alpha1.DashboardAuthTokenReview
+}
+
+// AuthConfigMap returns the kube-rbac-proxy configuration that authorizes
+// requests against the dashboard subresource of the cluster object. The
+// verb is derived from the HTTP method of the request.
+func AuthConfigMap(info *AuthInfo) *corev1.ConfigMap {
+	config := fmt.Sprintf(`authorization:
+  resourceAttributes:
+    namespace: %s
+    apiGroup: %s
+    apiVersion: %s
+    resource: %s
+    subresource: %s
+    name: %s
+`,
+		info.Namespace,
+		dcv1alpha1.GroupVersion.Group,
+		dcv1alpha1.GroupVersion.Version,
+		info.Resource,
+		AuthSubresource,
+		info.ClusterName,
+	)
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      info.ConfigMapName,
+			Namespace: info.Namespace,
+			Labels:    info.Labels,
+		},
+		Data: map[string]string{authConfigKey: config},
+	}
+}
+
+// AuthClusterRoleBinding returns the binding that allows the TokenReview
+// proxy to review tokens and authorize requests. Bindings are cluster scoped,
+// so the name is prefixed by the namespace and the binding is not owned by the
+// cluster.
+func AuthClusterRoleBinding(info *AuthInfo) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   fmt.Sprintf("%s:%s", info.Namespace, info.ConfigMapName),
+			Labels: info.Labels,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     authDelegatorRole,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      info.ServiceAccount,
+				Namespace: info.Namespace,
+			},
+		},
+	}
+}
+
+// AuthVolumes returns the pod volumes used by the proxy sidecar. The
+// TokenReview proxy receives a projected service account token so that API
+// credentials are not mounted into the other containers of the pod.
+func AuthVolumes(info *AuthInfo) []corev1.Volume {
+	if !AuthConfigMapEnabled(info.Config) {
+		return nil
+	}
+
+	return []corev1.Volume{
+		{
+			Name: authConfigVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: info.ConfigMapName,
+					},
+				},
+			},
+		},
+		{
+			Name: authTokenVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{
+						{
+							ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+								Path: "token",
+							},
+						},
+						{
+							ConfigMap: &corev1.ConfigMapProjection{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: "kube-root-ca.crt",
+								},
+								Items: []corev1.KeyToPath{
+									{Key: "ca.crt", Path: "ca.crt"},
+								},
+							},
+						},
+						{
+							DownwardAPI: &corev1.DownwardAPIProjection{
+								Items: []corev1.DownwardAPIVolumeFile{
+									{
+										Path: "namespace",
+										FieldRef: &corev1.ObjectFieldSelector{
+											FieldPath: "metadata.namespace",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// AuthSidecar returns the proxy container that forwards authenticated
+// requests to the dashboard server over the loopback interface.
+func AuthSidecar(info *AuthInfo) (corev1.Container, error) {
+	ac := info.Config.Auth
+
+	image := ac.Image
+	if image == nil {
+		image = defaultTokenReviewImage
+		if ac.Type == dcv1alpha1.DashboardAuthOIDC {
+			image = defaultOIDCImage
+		}
+	}
+	imageRef, err := util.ParseImageDefinition(image)
+	if err != nil {
+		return corev1.Container{}, fmt.Errorf("cannot parse dashboard auth image: %w", err)
+	}
+
+	container := corev1.Container{
+		Name:            AuthContainerName,
+		Image:           imageRef,
+		ImagePullPolicy: image.PullPolicy,
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          AuthPortName,
+				ContainerPort: ac.Port,
+			},
+		},
+		Resources: ac.Resources,
+	}
+
+	upstream := fmt.Sprintf("--upstream=http://127.0.0.1:%d/", info.UpstreamPort)
+	if ac.Type == dcv1alpha1.DashboardAuthOIDC {
+		args, err := oidcArgs(info)
+		if err != nil {
+			return corev1.Container{}, err
+		}
+
+		container.Args = append([]string{upstream}, args...)
+		container.Env = oidcEnv(ac.OIDC)
+	} else {
+		// plain HTTP is served because dashboard routes and clients do not
+		// trust the self-signed certificate of the proxy
+		container.Args = []string{
+			fmt.Sprintf("--insecure-listen-address=0.0.0.0:%d", ac.Port),
+			upstream,
+			fmt.Sprintf("--config-file=%s/%s", authConfigMountPath, authConfigKey),
+		}
+		container.VolumeMounts = []corev1.VolumeMount{
+			{
+				Name:      authConfigVolumeName,
+				MountPath: authConfigMountPath,
+				ReadOnly:  true,
+			},
+			{
+				Name:      authTokenVolumeName,
+				MountPath: authTokenMountPath,
+				ReadOnly:  true,
+			},
+		}
+	}
+	container.Args = append(container.Args, ac.ExtraArgs...)
+
+	return container, nil
+}
+
+// oidcArgs configures oauth2-proxy. The callback is served below the exposed
+// dashboard path so that it is routed to the proxy without rewrites.
+func oidcArgs(info *AuthInfo) ([]string, error) {
+	ac := info.Config.Auth
+
+	args := []string{
+		fmt.Sprintf("--http-address=0.0.0.0:%d", ac.Port),
+		"--provider=oidc",
+		"--oidc-issuer-url=" + ac.OIDC.IssuerURL,
+		"--client-id=" + ac.OIDC.ClientID,
+		"--reverse-proxy=true",
+		"--skip-provider-button=true",
+	}
+
+	domains := ac.OIDC.EmailDomains
+	if len(domains) == 0 {
+		domains = []string{"*"}
+	}
+	for _, domain := range domains {
+		args = append(args, "--email-domain="+domain)
+	}
+
+	if Enabled(info.Config) {
+		ec := info.Config.Expose
+
+		_, path, err := ec.Endpoint(info.ClusterName, info.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		dashboardURL, err := ec.URL(info.ClusterName, info.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		prefix := strings.TrimSuffix(path, "/") + authCallbackPath
+		args = append(args,
+			"--proxy-prefix="+prefix,
+			"--redirect-url="+strings.TrimSuffix(dashboardURL, "/")+authCallbackPath+"/callback",
+		)
+		if strings.HasPrefix(dashboardURL, "http://") {
+			args = append(args, "--cookie-secure=false")
+		}
+	}
+
+	return args, nil
+}
+
+func oidcEnv(oc *dcv1alpha1.OIDCConfig) []corev1.EnvVar {
+	secretEnv := func(name, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: oc.SecretName},
+					Key:                  key,
+				},
+			},
+		}
+	}
+
+	return []corev1.EnvVar{
+		secretEnv("OAUTH2_PROXY_CLIENT_SECRET", "client-secret"),
+		secretEnv("OAUTH2_PROXY_COOKIE_SECRET", "cookie-secret"),
+	}
+}
@@ -0,0 +1,152 @@
+package dashboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func testAuthInfo(ac *dcv1alpha1.DashboardAuthConfig) *AuthInfo {
+	return &AuthInfo{
+		ClusterName:    "test",
+		Namespace:      "ns",
+		Resource:       "rayclusters",
+		ConfigMapName:  "test-ray-dashboard-auth",
+		Labels:         map[string]string{"app": "ray"},
+		ServiceAccount: "test-ray",
+		UpstreamPort:   8265,
+		Config:         &dcv1alpha1.DashboardConfig{Auth: ac},
+	}
+}
+
+func TestPort(t *testing.T) {
+	assert.Equal(t, int32(8265), Port(nil, 8265))
+	assert.Equal(t, int32(8265), Port(&dcv1alpha1.DashboardConfig{Auth: &dcv1alpha1.DashboardAuthConfig{Port: 4180}}, 8265))
+	assert.Equal(t, int32(4180), Port(&dcv1alpha1.DashboardConfig{Auth: &dcv1alpha1.DashboardAuthConfig{Enabled: true, Port: 4180}}, 8265))
+}
+
+func TestAuthClusterRoleBinding(t *testing.T) {
+	info := testAuthInfo(&dcv1alpha1.DashboardAuthConfig{Enabled: true, Type: dcv1alpha1.DashboardAuthTokenReview})
+
+	crb := AuthClusterRoleBinding(info)
+	assert.Equal(t, "ns:test-ray-dashboard-auth", crb.Name)
+	assert.Empty(t, crb.Namespace)
+	assert.Equal(t, map[string]string{"app": "ray"}, crb.Labels)
+	assert.Equal(t, rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "ClusterRole",
+		Name:     "system:auth-delegator",
+	}, crb.RoleRef)
+	assert.Equal(t, []rbacv1.Subject{
+		{Kind: rbacv1.ServiceAccountKind, Name: "test-ray", Namespace: "ns"},
+	}, crb.Subjects)
+}
+
+func TestAuthSidecar(t *testing.T) {
+	t.Run("token_review", func(t *testing.T) {
+		info := testAuthInfo(&dcv1alpha1.DashboardAuthConfig{
+			Enabled:   true,
+			Type:      dcv1alpha1.DashboardAuthTokenReview,
+			Port:      4180,
+			ExtraArgs: []string{"--v=2"},
+		})
+
+		container, err := AuthSidecar(info)
+		require.NoError(t, err)
+
+		assert.Equal(t, "quay.io/brancz/kube-rbac-proxy:v0.15.0", container.Image)
+		assert.Equal(t, []string{
+			"--insecure-listen-address=0.0.0.0:4180",
+			"--upstream=http://127.0.0.1:8265/",
+			"--config-file=/etc/kube-rbac-proxy/config.yaml",
+			"--v=2",
+		}, container.Args)
+		assert.Equal(t, AuthPortName, container.Ports[0].Name)
+		assert.Equal(t, int32(4180), container.Ports[0].ContainerPort)
+		require.Len(t, container.VolumeMounts, 2)
+
+		volumes := AuthVolumes(info)
+		require.Len(t, volumes, 2)
+		assert.Equal(t, container.VolumeMounts[0].Name, volumes[0].Name)
+		assert.Equal(t, "test-ray-dashboard-auth", volumes[0].ConfigMap.Name)
+		assert.Equal(t, container.VolumeMounts[1].Name, volumes[1].Name)
+		assert.Equal(t, "/var/run/secrets/kubernetes.io/serviceaccount", container.VolumeMounts[1].MountPath)
+		require.NotNil(t, volumes[1].Projected)
+		assert.Equal(t, "token", volumes[1].Projected.Sources[0].ServiceAccountToken.Path)
+
+		cm := AuthConfigMap(info)
+		assert.Equal(t, "test-ray-dashboard-auth", cm.Name)
+		assert.Equal(t, "ns", cm.Namespace)
+		assert.Equal(t, `authorization:
+  resourceAttributes:
+    namespace: ns
+    apiGroup: distributed-compute.dominodatalab.com
+    apiVersion: v1alpha1
+    resource: rayclusters
+    subresource: dashboard
+    name: test
+`, cm.Data["config.yaml"])
+	})
+
+	t.Run("oidc", func(t *testing.T) {
+		info := testAuthInfo(&dcv1alpha1.DashboardAuthConfig{
+			Enabled: true,
+			Type:    dcv1alpha1.DashboardAuthOIDC,
+			Port:    4180,
+			Image:   &dcv1alpha1.OCIImageDefinition{Repository: "oauth2-proxy", Tag: "latest"},
+			OIDC: &dcv1alpha1.OIDCConfig{
+				IssuerURL:    "https://idp.example.com",
+				ClientID:     "dashboards",
+				SecretName:   "dashboard-oidc",
+				EmailDomains: []string{"example.com"},
+			},
+		})
+
+		container, err := AuthSidecar(info)
+		require.NoError(t, err)
+
+		assert.Equal(t, "docker.io/library/oauth2-proxy:latest", container.Image)
+		assert.Equal(t, []string{
+			"--upstream=http://127.0.0.1:8265/",
+			"--http-address=0.0.0.0:4180",
+			"--provider=oidc",
+			"--oidc-issuer-url=https://idp.example.com",
+			"--client-id=dashboards",
+			"--reverse-proxy=true",
+			"--skip-provider-button=true",
+			"--email-domain=example.com",
+		}, container.Args)
+		require.Len(t, container.Env, 2)
+		assert.Equal(t, "dashboard-oidc", container.Env[0].ValueFrom.SecretKeyRef.Name)
+		assert.Equal(t, "client-secret", container.Env[0].ValueFrom.SecretKeyRef.Key)
+		assert.Equal(t, "cookie-secret", container.Env[1].ValueFrom.SecretKeyRef.Key)
+		assert.Empty(t, AuthVolumes(info))
+	})
+
+	t.Run("oidc_exposed", func(t *testing.T) {
+		info := testAuthInfo(&dcv1alpha1.DashboardAuthConfig{
+			Enabled: true,
+			Type:    dcv1alpha1.DashboardAuthOIDC,
+			Port:    4180,
+			OIDC:    &dcv1alpha1.OIDCConfig{IssuerURL: "https://idp.example.com"},
+		})
+		info.Config.Expose = &dcv1alpha1.DashboardExposeConfig{
+			Enabled: true,
+			Host:    "dashboards.example.com",
+			Path:    "/{{ .Namespace }}/{{ .Name }}/",
+		}
+
+		container, err := AuthSidecar(info)
+		require.NoError(t, err)
+
+		assert.Equal(t, "quay.io/oauth2-proxy/oauth2-proxy:v7.5.1", container.Image)
+		assert.Contains(t, container.Args, "--email-domain=*")
+		assert.Contains(t, container.Args, "--proxy-prefix=/ns/test/oauth2")
+		assert.Contains(t, container.Args, "--redirect-url=http://dashboards.example.com/ns/test/oauth2/callback")
+		assert.Contains(t, container.Args, "--cookie-secure=false")
+	})
+}
@@ -0,0 +1,224 @@
+// Package dashboard builds the Ingress and HTTPRoute objects that expose
+// cluster dashboards outside of the cluster. Gateway APIs are provided by
+// optional CRDs, so HTTPRoutes are unstructured and a missing API is treated
+// the same as a missing object.
+package dashboard
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+// HTTPRouteGroupVersionKind of the Gateway API HTTPRoute.
+var HTTPRouteGroupVersionKind = schema.GroupVersionKind{
+	Group:   "gateway.networking.k8s.io",
+	Version: "v1",
+	Kind:    "HTTPRoute",
+}
+
+// Info describes the routing object of a cluster dashboard.
+type Info struct {
+	// Name and Namespace of the routing object.
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	// ClusterName is used to render the host and path templates.
+	ClusterName string
+	// ServiceName and ServicePort route to the dashboard server.
+	ServiceName string
+	ServicePort int32
+	Config      *dcv1alpha1.DashboardConfig
+}
+
+// Enabled returns true when dashboard exposure is requested.
+func Enabled(dc *dcv1alpha1.DashboardConfig) bool {
+	return dc != nil && dc.Expose != nil && dc.Expose.Enabled
+}
+
+// New returns the routing object described by info, either an Ingress or an
+// HTTPRoute.
+func New(info *Info) (client.Object, error) {
+	ec := info.Config.Expose
+
+	host, path, err := ec.Endpoint(info.ClusterName, info.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if ec.Type == dcv1alpha1.DashboardExposureHTTPRoute {
+		return newHTTPRoute(info, host, path), nil
+	}
+	return newIngress(info, host, path), nil
+}
+
+// Stale returns the routing objects of a cluster that should not exist, i.e.
+// both kinds when exposure is disabled, or the kind that is not used.
+func Stale(info *Info) []client.Object {
+	var stale []client.Object
+	if !Enabled(info.Config) || info.Config.Expose.Type != dcv1alpha1.DashboardExposureIngress {
+		stale = append(stale, IngressReference(info.Name, info.Namespace))
+	}
+	if !Enabled(info.Config) || info.Config.Expose.Type != dcv1alpha1.DashboardExposureHTTPRoute {
+		stale = append(stale, HTTPRouteReference(info.Name, info.Namespace))
+	}
+
+	return stale
+}
+
+// URL returns the external URL of an exposed dashboard. It is blank when the
+// dashboard is not exposed.
+func URL(info *Info) (string, error) {
+	if !Enabled(info.Config) {
+		return "", nil
+	}
+	return info.Config.Expose.URL(info.ClusterName, info.Namespace)
+}
+
+// IngressReference returns an empty Ingress that identifies an existing object.
+func IngressReference(name, namespace string) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
+
+// HTTPRouteReference returns an empty HTTPRoute that identifies an existing object.
+func HTTPRouteReference(name, namespace string) *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(HTTPRouteGroupVersionKind)
+	route.SetName(name)
+	route.SetNamespace(namespace)
+
+	return route
+}
+
+// IngressRules returns the network policy rules that admit the ingress
+// controller or gateway to the dashboard port. No rules are returned when the
+// dashboard is not exposed. Controller pods are only matched in the cluster
+// namespace unless namespace labels are provided.
+func IngressRules(dc *dcv1alpha1.DashboardConfig, port int32) []networkingv1.NetworkPolicyIngressRule {
+	if !Enabled(dc) {
+		return nil
+	}
+
+	peer := networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: dc.Expose.ControllerLabels,
+		},
+	}
+	if len(dc.Expose.ControllerNamespaceLabels) > 0 {
+		peer.NamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: dc.Expose.ControllerNamespaceLabels,
+		}
+	}
+
+	tcpProto := corev1.ProtocolTCP
+	targetPort := intstr.FromInt(int(port))
+
+	return []networkingv1.NetworkPolicyIngressRule{
+		{
+			From: []networkingv1.NetworkPolicyPeer{peer},
+			Ports: []networkingv1.NetworkPolicyPort{
+				{
+					Port:     &targetPort,
+					Protocol: &tcpProto,
+				},
+			},
+		},
+	}
+}
+
+func newIngress(info *Info, host, path string) *networkingv1.Ingress {
+	ec := info.Config.Expose
+	pathType := networkingv1.PathTypePrefix
+
+	ing := IngressReference(info.Name, info.Namespace)
+	ing.Labels = info.Labels
+	ing.Annotations = ec.Annotations
+	ing.Spec = networkingv1.IngressSpec{
+		IngressClassName: ec.IngressClassName,
+		Rules: []networkingv1.IngressRule{
+			{
+				Host: host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{
+							{
+								Path:     path,
+								PathType: &pathType,
+								Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{
+										Name: info.ServiceName,
+										Port: networkingv1.ServiceBackendPort{Number: info.ServicePort},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if ec.TLSSecretName != "" {
+		ing.Spec.TLS = []networkingv1.IngressTLS{
+			{
+				Hosts:      []string{host},
+				SecretName: ec.TLSSecretName,
+			},
+		}
+	}
+
+	return ing
+}
+
+func newHTTPRoute(info *Info, host, path string) *unstructured.Unstructured {
+	gw := info.Config.Expose.Gateway
+
+	route := HTTPRouteReference(info.Name, info.Namespace)
+	route.SetLabels(info.Labels)
+	route.SetAnnotations(info.Config.Expose.Annotations)
+
+	parentRef := map[string]interface{}{
+		"name": gw.Name,
+	}
+	if gw.Namespace != "" {
+		parentRef["namespace"] = gw.Namespace
+	}
+	if gw.SectionName != "" {
+		parentRef["sectionName"] = gw.SectionName
+	}
+
+	route.Object["spec"] = map[string]interface{}{
+		"parentRefs": []interface{}{parentRef},
+		"hostnames":  []interface{}{host},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"matches": []interface{}{
+					map[string]interface{}{
+						"path": map[string]interface{}{
+							"type":  "PathPrefix",
+							"value": path,
+						},
+					},
+				},
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": info.ServiceName,
+						"port": int64(info.ServicePort),
+					},
+				},
+			},
+		},
+	}
+
+	return route
+}
@@ -0,0 +1,133 @@
+package dashboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func testInfo(ec *dcv1alpha1.DashboardExposeConfig) *Info {
+	return &Info{
+		Name:        "test-ray-dashboard",
+		Namespace:   "ns",
+		Labels:      map[string]string{"app": "ray"},
+		ClusterName: "test",
+		ServiceName: "test-ray-client",
+		ServicePort: 8265,
+		Config:      &dcv1alpha1.DashboardConfig{Expose: ec},
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("ingress", func(t *testing.T) {
+		info := testInfo(&dcv1alpha1.DashboardExposeConfig{
+			Enabled:          true,
+			Type:             dcv1alpha1.DashboardExposureIngress,
+			Host:             "{{ .Name }}.{{ .Namespace }}.example.com",
+			Path:             "/",
+			IngressClassName: pointer.String("nginx"),
+			TLSSecretName:    "dashboard-tls",
+		})
+
+		obj, err := New(info)
+		require.NoError(t, err)
+
+		ing := obj.(*networkingv1.Ingress)
+		assert.Equal(t, "test-ray-dashboard", ing.Name)
+		assert.Equal(t, map[string]string{"app": "ray"}, ing.Labels)
+		assert.Equal(t, pointer.String("nginx"), ing.Spec.IngressClassName)
+		assert.Equal(t, []networkingv1.IngressTLS{
+			{Hosts: []string{"test.ns.example.com"}, SecretName: "dashboard-tls"},
+		}, ing.Spec.TLS)
+
+		require.Len(t, ing.Spec.Rules, 1)
+		assert.Equal(t, "test.ns.example.com", ing.Spec.Rules[0].Host)
+		path := ing.Spec.Rules[0].HTTP.Paths[0]
+		assert.Equal(t, "/", path.Path)
+		assert.Equal(t, "test-ray-client", path.Backend.Service.Name)
+		assert.Equal(t, int32(8265), path.Backend.Service.Port.Number)
+
+		url, err := URL(info)
+		require.NoError(t, err)
+		assert.Equal(t, "https://test.ns.example.com/", url)
+	})
+
+	t.Run("http_route", func(t *testing.T) {
+		info := testInfo(&dcv1alpha1.DashboardExposeConfig{
+			Enabled: true,
+			Type:    dcv1alpha1.DashboardExposureHTTPRoute,
+			Host:    "dashboards.example.com",
+			Path:    "/{{ .Namespace }}/{{ .Name }}",
+			Gateway: &dcv1alpha1.GatewayReference{Name: "public", Namespace: "gateways"},
+		})
+
+		obj, err := New(info)
+		require.NoError(t, err)
+
+		route := obj.(*unstructured.Unstructured)
+		assert.Equal(t, "gateway.networking.k8s.io/v1", route.GetAPIVersion())
+		assert.Equal(t, "HTTPRoute", route.GetKind())
+		assert.Equal(t, map[string]interface{}{
+			"parentRefs": []interface{}{
+				map[string]interface{}{"name": "public", "namespace": "gateways"},
+			},
+			"hostnames": []interface{}{"dashboards.example.com"},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"matches": []interface{}{
+						map[string]interface{}{
+							"path": map[string]interface{}{"type": "PathPrefix", "value": "/ns/test"},
+						},
+					},
+					"backendRefs": []interface{}{
+						map[string]interface{}{"name": "test-ray-client", "port": int64(8265)},
+					},
+				},
+			},
+		}, route.Object["spec"])
+
+		url, err := URL(info)
+		require.NoError(t, err)
+		assert.Equal(t, "http://dashboards.example.com/ns/test", url)
+	})
+
+	t.Run("invalid_template", func(t *testing.T) {
+		_, err := New(testInfo(&dcv1alpha1.DashboardExposeConfig{Enabled: true, Host: "{{ .Missing }}"}))
+		assert.Error(t, err)
+	})
+}
+
+func TestStale(t *testing.T) {
+	assert.Len(t, Stale(testInfo(nil)), 2)
+
+	stale := Stale(testInfo(&dcv1alpha1.DashboardExposeConfig{
+		Enabled: true,
+		Type:    dcv1alpha1.DashboardExposureIngress,
+	}))
+	require.Len(t, stale, 1)
+	assert.IsType(t, &unstructured.Unstructured{}, stale[0])
+}
+
+func TestIngressRules(t *testing.T) {
+	assert.Nil(t, IngressRules(nil, 8265))
+
+	dc := &dcv1alpha1.DashboardConfig{
+		Expose: &dcv1alpha1.DashboardExposeConfig{
+			Enabled:                   true,
+			ControllerLabels:          map[string]string{"app": "ingress-nginx"},
+			ControllerNamespaceLabels: map[string]string{"name": "ingress"},
+		},
+	}
+	rules := IngressRules(dc, 8265)
+
+	require.Len(t, rules, 1)
+	assert.Equal(t, map[string]string{"app": "ingress-nginx"}, rules[0].From[0].PodSelector.MatchLabels)
+	assert.Equal(t, map[string]string{"name": "ingress"}, rules[0].From[0].NamespaceSelector.MatchLabels)
+	assert.Equal(t, 8265, rules[0].Ports[0].Port.IntValue())
+}
@@ -0,0 +1,289 @@
+package filesync
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+const (
+	// PortName is used for rsync ports on sync sidecars and services.
+	PortName = "tcp-rsync"
+	// ContainerSuffix is appended to the application name to produce the sidecar name.
+	ContainerSuffix = "-sync"
+
+	// Location of the mounted rsync credentials and their mode
+	authPath = "/etc/rsync"
+	authMode = 0444 // octal!
+
+	// Name of the virtual rsync user authorized to access sync modules
+	authUserName = "rsync"
+
+	// User and group for running the sidecar container;
+	// they should match a user provisioned in the sidecar image.
+	userID  = 12574
+	groupID = 12574
+
+	// Number of random bytes used to generate the rsync password
+	passwordLength = 24
+
+	authVolumeName   = "sync-auth"
+	sharedVolumeName = "sync-data"
+)
+
+var command = []string{
+	"/opt/domino/rsync/bin/rsync-start.sh",
+}
+
+// SidecarInfo defines fields used to generate rsync sidecar containers.
+type SidecarInfo struct {
+	Name         string
+	Image        string
+	PullPolicy   corev1.PullPolicy
+	Port         int32
+	SecretName   string
+	Modules      []dcv1alpha1.SyncModule
+	VolumeMounts []corev1.VolumeMount
+}
+
+// NewSidecar uses SidecarInfo to generate an rsync daemon container that
+// authenticates clients with the credentials found in the referenced Secret.
+func NewSidecar(info *SidecarInfo) corev1.Container {
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt(int(info.Port)),
+			},
+		},
+	}
+
+	mounts := make([]corev1.VolumeMount, 0, len(info.VolumeMounts)+1)
+	mounts = append(mounts, info.VolumeMounts...)
+	mounts = append(mounts, corev1.VolumeMount{
+		Name:      authVolumeName,
+		ReadOnly:  true,
+		MountPath: authPath,
+	})
+
+	user := int64(userID)
+	group := int64(groupID)
+
+	return corev1.Container{
+		Name:            info.Name,
+		Command:         command,
+		Image:           info.Image,
+		ImagePullPolicy: info.PullPolicy,
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          PortName,
+				ContainerPort: info.Port,
+			},
+		},
+		Env: []corev1.EnvVar{
+			{
+				Name:  "RSYNC_PORT",
+				Value: strconv.Itoa(int(info.Port)),
+			},
+			{
+				Name:  "RSYNC_AUTH_DIR",
+				Value: authPath,
+			},
+			{
+				Name:  "RSYNC_MODULES",
+				Value: ModulesValue(info.Modules),
+			},
+		},
+		VolumeMounts: mounts,
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:  &user,
+			RunAsGroup: &group,
+		},
+		LivenessProbe:  probe,
+		ReadinessProbe: probe,
+	}
+}
+
+// NewAuthVolume returns the pod volume that backs the sidecar credentials.
+func NewAuthVolume(secretName string) corev1.Volume {
+	mode := int32(authMode)
+
+	return corev1.Volume{
+		Name: authVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName:  secretName,
+				DefaultMode: &mode,
+			},
+		},
+	}
+}
+
+// NewSharedVolume returns a scratch volume and a mount for it at the given
+// path so that synced files are visible to both the sidecar and the framework.
+func NewSharedVolume(mountPath string) (corev1.Volume, corev1.VolumeMount) {
+	volume := corev1.Volume{
+		Name: sharedVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+	mount := corev1.VolumeMount{
+		Name:      sharedVolumeName,
+		MountPath: mountPath,
+	}
+
+	return volume, mount
+}
+
+// ModulesValue serializes rsync modules into space-separated
+// "name:path:access" entries consumed by rsync-start.sh.
+func ModulesValue(modules []dcv1alpha1.SyncModule) string {
+	entries := make([]string, 0, len(modules))
+	for _, module := range modules {
+		access := "rw"
+		if module.ReadOnly {
+			access = "ro"
+		}
+		entries = append(entries, fmt.Sprintf("%s:%s:%s", module.Name, module.Path, access))
+	}
+
+	return strings.Join(entries, " ")
+}
+
+// Modules returns the modules exported by a sync sidecar configured with
+// the given settings, falling back to a single read-write module serving the
+// shared volume.
+func Modules(cfg *dcv1alpha1.SyncConfig) []dcv1alpha1.SyncModule {
+	if len(cfg.Modules) != 0 {
+		return cfg.Modules
+	}
+
+	return []dcv1alpha1.SyncModule{
+		{Name: "sync", Path: cfg.MountPath},
+	}
+}
+
+// SecretInfo defines fields used to generate rsync credential Secrets.
+type SecretInfo struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	Password  []byte
+}
+
+// NewSecret uses SecretInfo to generate a basic-auth Secret holding the rsync credentials.
+func NewSecret(info *SecretInfo) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      info.Name,
+			Namespace: info.Namespace,
+			Labels:    info.Labels,
+		},
+		Type: corev1.SecretTypeBasicAuth,
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte(authUserName),
+			corev1.BasicAuthPasswordKey: info.Password,
+		},
+	}
+}
+
+// Password returns the password stored in an existing credential Secret or a
+// new random one when the Secret is missing or empty. Passwords are preserved
+// across reconciliations, otherwise running clients would lose access.
+func Password(current *corev1.Secret) ([]byte, error) {
+	if current != nil && len(current.Data[corev1.BasicAuthPasswordKey]) != 0 {
+		return current.Data[corev1.BasicAuthPasswordKey], nil
+	}
+
+	buf := make([]byte, passwordLength)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("cannot generate sync password: %w", err)
+	}
+
+	password := make([]byte, base64.RawURLEncoding.EncodedLen(len(buf)))
+	base64.RawURLEncoding.Encode(password, buf)
+
+	return password, nil
+}
+
+// FetchPassword returns the password of the credential Secret with the given
+// key, or a new random one when the Secret does not exist yet.
+func FetchPassword(ctx context.Context, c client.Reader, key client.ObjectKey) ([]byte, error) {
+	current := &corev1.Secret{}
+	if err := c.Get(ctx, key, current); client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("cannot fetch sync secret: %w", err)
+	}
+
+	return Password(current)
+}
+
+// UpdateStatus reports the credential Secret and the readiness of the named
+// sync sidecar in a cluster status. The secret name is blank when file sync is
+// disabled. It returns true when the status was modified.
+func UpdateStatus(csc *dcv1alpha1.ClusterStatusConfig, secretName string, pods []corev1.Pod, containerName string) bool {
+	var syncSecret *corev1.LocalObjectReference
+	var syncNodes []dcv1alpha1.SyncNodeStatus
+	if secretName != "" {
+		syncSecret = &corev1.LocalObjectReference{Name: secretName}
+		syncNodes = NodeStatuses(pods, containerName)
+	}
+
+	var modified bool
+	if !reflect.DeepEqual(syncSecret, csc.SyncSecret) {
+		csc.SyncSecret = syncSecret
+		modified = true
+	}
+	if !reflect.DeepEqual(syncNodes, csc.SyncNodes) {
+		csc.SyncNodes = syncNodes
+		modified = true
+	}
+
+	return modified
+}
+
+// NodeStatuses reports the readiness of the named sync sidecar for every pod
+// that runs one. The result is sorted by pod name.
+func NodeStatuses(pods []corev1.Pod, containerName string) []dcv1alpha1.SyncNodeStatus {
+	var statuses []dcv1alpha1.SyncNodeStatus
+	for _, pod := range pods {
+		if !hasContainer(pod, containerName) {
+			continue
+		}
+
+		status := dcv1alpha1.SyncNodeStatus{Pod: pod.Name}
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name == containerName {
+				status.Ready = cs.Ready
+				break
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Pod < statuses[j].Pod
+	})
+
+	return statuses
+}
+
+func hasContainer(pod corev1.Pod, name string) bool {
+	for _, c := range pod.Spec.Containers {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,233 @@
+package filesync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestNewSidecar(t *testing.T) {
+	actual := NewSidecar(&SidecarInfo{
+		Name:       "dask-sync",
+		Image:      "rsync:test",
+		PullPolicy: corev1.PullIfNotPresent,
+		Port:       2223,
+		SecretName: "cluster-sync",
+		Modules: []dcv1alpha1.SyncModule{
+			{Name: "data", Path: "/mnt/data"},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "sync-data", MountPath: "/mnt/data"},
+		},
+	})
+
+	assert.Equal(t, "dask-sync", actual.Name)
+	assert.Equal(t, "rsync:test", actual.Image)
+	assert.Equal(t, corev1.PullIfNotPresent, actual.ImagePullPolicy)
+	assert.Equal(t, []corev1.ContainerPort{{Name: PortName, ContainerPort: 2223}}, actual.Ports)
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "RSYNC_PORT", Value: "2223"},
+		{Name: "RSYNC_AUTH_DIR", Value: "/etc/rsync"},
+		{Name: "RSYNC_MODULES", Value: "data:/mnt/data:rw"},
+	}, actual.Env)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "sync-data", MountPath: "/mnt/data"},
+		{Name: "sync-auth", ReadOnly: true, MountPath: "/etc/rsync"},
+	}, actual.VolumeMounts)
+
+	require.NotNil(t, actual.SecurityContext)
+	assert.Equal(t, int64(12574), *actual.SecurityContext.RunAsUser)
+	assert.Equal(t, int64(12574), *actual.SecurityContext.RunAsGroup)
+
+	require.NotNil(t, actual.ReadinessProbe)
+	assert.Equal(t, 2223, actual.ReadinessProbe.TCPSocket.Port.IntValue())
+	assert.Equal(t, actual.ReadinessProbe, actual.LivenessProbe)
+}
+
+func TestNewAuthVolume(t *testing.T) {
+	actual := NewAuthVolume("cluster-sync")
+
+	mode := int32(0444)
+	expected := corev1.Volume{
+		Name: "sync-auth",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName:  "cluster-sync",
+				DefaultMode: &mode,
+			},
+		},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestModulesValue(t *testing.T) {
+	modules := []dcv1alpha1.SyncModule{
+		{Name: "mnt", Path: "/mnt"},
+		{Name: "imported", Path: "/mnt/imported", ReadOnly: true},
+	}
+	assert.Equal(t, "mnt:/mnt:rw imported:/mnt/imported:ro", ModulesValue(modules))
+	assert.Empty(t, ModulesValue(nil))
+}
+
+func TestModules(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		cfg := &dcv1alpha1.SyncConfig{Enabled: true, MountPath: "/mnt/sync"}
+
+		expected := []dcv1alpha1.SyncModule{{Name: "sync", Path: "/mnt/sync"}}
+		assert.Equal(t, expected, Modules(cfg))
+	})
+
+	t.Run("provided", func(t *testing.T) {
+		modules := []dcv1alpha1.SyncModule{{Name: "code", Path: "/repos", ReadOnly: true}}
+		cfg := &dcv1alpha1.SyncConfig{Enabled: true, MountPath: "/mnt/sync", Modules: modules}
+
+		assert.Equal(t, modules, Modules(cfg))
+	})
+}
+
+func TestNewSecret(t *testing.T) {
+	actual := NewSecret(&SecretInfo{
+		Name:      "cluster-sync",
+		Namespace: "ns",
+		Labels:    map[string]string{"app": "test"},
+		Password:  []byte("s3cret"),
+	})
+
+	expected := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-sync",
+			Namespace: "ns",
+			Labels:    map[string]string{"app": "test"},
+		},
+		Type: corev1.SecretTypeBasicAuth,
+		Data: map[string][]byte{
+			"username": []byte("rsync"),
+			"password": []byte("s3cret"),
+		},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestPassword(t *testing.T) {
+	t.Run("generated", func(t *testing.T) {
+		first, err := Password(&corev1.Secret{})
+		require.NoError(t, err)
+		assert.Len(t, first, 32)
+
+		second, err := Password(nil)
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("preserved", func(t *testing.T) {
+		current := &corev1.Secret{
+			Data: map[string][]byte{"password": []byte("existing")},
+		}
+
+		actual, err := Password(current)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("existing"), actual)
+	})
+}
+
+func TestFetchPassword(t *testing.T) {
+	ctx := context.Background()
+	key := client.ObjectKey{Name: "sync", Namespace: "ns"}
+
+	t.Run("generated", func(t *testing.T) {
+		c := fake.NewClientBuilder().Build()
+
+		actual, err := FetchPassword(ctx, c, key)
+		require.NoError(t, err)
+		assert.Len(t, actual, 32)
+	})
+
+	t.Run("preserved", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync", Namespace: "ns"},
+			Data:       map[string][]byte{"password": []byte("existing")},
+		}).Build()
+
+		actual, err := FetchPassword(ctx, c, key)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("existing"), actual)
+	})
+}
+
+func TestUpdateStatus(t *testing.T) {
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-0"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "app-sync"}},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "app-sync", Ready: true}},
+			},
+		},
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		csc := &dcv1alpha1.ClusterStatusConfig{}
+
+		assert.True(t, UpdateStatus(csc, "sync", pods, "app-sync"))
+		assert.Equal(t, &corev1.LocalObjectReference{Name: "sync"}, csc.SyncSecret)
+		assert.Equal(t, []dcv1alpha1.SyncNodeStatus{{Pod: "worker-0", Ready: true}}, csc.SyncNodes)
+
+		assert.False(t, UpdateStatus(csc, "sync", pods, "app-sync"))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		csc := &dcv1alpha1.ClusterStatusConfig{
+			SyncSecret: &corev1.LocalObjectReference{Name: "sync"},
+			SyncNodes:  []dcv1alpha1.SyncNodeStatus{{Pod: "worker-0", Ready: true}},
+		}
+
+		assert.True(t, UpdateStatus(csc, "", pods, "app-sync"))
+		assert.Nil(t, csc.SyncSecret)
+		assert.Nil(t, csc.SyncNodes)
+
+		assert.False(t, UpdateStatus(csc, "", nil, "app-sync"))
+	})
+}
+
+func TestNodeStatuses(t *testing.T) {
+	pod := func(name string, ready *bool) corev1.Pod {
+		p := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "app"}},
+			},
+		}
+		if ready != nil {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Name: "app-sync"})
+			p.Status.ContainerStatuses = []corev1.ContainerStatus{
+				{Name: "app", Ready: true},
+				{Name: "app-sync", Ready: *ready},
+			}
+		}
+		return p
+	}
+	ready, notReady := true, false
+
+	pods := []corev1.Pod{
+		pod("worker-1", &notReady),
+		pod("head-0", nil),
+		pod("worker-0", &ready),
+	}
+
+	expected := []dcv1alpha1.SyncNodeStatus{
+		{Pod: "worker-0", Ready: true},
+		{Pod: "worker-1", Ready: false},
+	}
+	assert.Equal(t, expected, NodeStatuses(pods, "app-sync"))
+	assert.Nil(t, NodeStatuses(nil, "app-sync"))
+}
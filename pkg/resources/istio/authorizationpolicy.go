@@ -0,0 +1,229 @@
+package istio
+
+import (
+	"encoding/json"
+	"fmt"
+
+	securityv1beta1 "istio.io/api/security/v1beta1"
+	"istio.io/api/type/v1beta1"
+	istio "istio.io/client-go/pkg/apis/security/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+// AuthorizationPolicyInfo defines fields used to generate Istio AuthorizationPolicy objects.
+type AuthorizationPolicyInfo struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	Selector  map[string]string
+	// ServiceAccount used by cluster pods. Its workloads are granted access
+	// to every port.
+	ServiceAccount string
+	// ClientPorts exposed to client workloads. Every port is exposed when
+	// no ports are provided.
+	ClientPorts []int32
+	// DashboardPorts exposed to dashboard workloads.
+	DashboardPorts []int32
+	// MonitoringPorts exposed to monitoring workloads. They are only set
+	// when cluster monitoring is enabled.
+	MonitoringPorts []int32
+	// Waypoint service account that forwards traffic to cluster pods in
+	// ambient mode. It is granted access to every port because the client
+	// restrictions are enforced by the waypoint policy of the cluster
+	// services.
+	Waypoint string
+	// Services of the cluster that the waypoint policy is attached to.
+	Services []string
+	Config   *dcv1alpha1.IstioAuthorizationConfig
+}
+
+// WaypointPolicySuffix is appended to the name of the cluster policy to name
+// the waypoint policy.
+const WaypointPolicySuffix = "-waypoint"
+
+// AuthorizationPolicyGroupVersionKind of the Istio AuthorizationPolicy API.
+var AuthorizationPolicyGroupVersionKind = istio.SchemeGroupVersion.WithKind("AuthorizationPolicy")
+
+// AuthorizationEnabled returns true when an AuthorizationPolicy should be
+// generated for a cluster.
+func AuthorizationEnabled(ic dcv1alpha1.IstioConfig) bool {
+	return ic.Istio != nil && ic.Istio.Authorization != nil && ic.Istio.Authorization.Enabled
+}
+
+// Waypoint returns the waypoint service account of a cluster, which is only
+// used in ambient mode.
+func Waypoint(ic dcv1alpha1.IstioConfig, m Mode) string {
+	if !m.Ambient() || ic.Istio == nil || ic.Istio.Authorization == nil {
+		return ""
+	}
+
+	return ic.Istio.Authorization.Waypoint
+}
+
+// ServiceAccountPrincipal returns the Istio principal of a service account.
+// The trust domain is matched with a wildcard so that policies do not depend
+// on the mesh configuration.
+func ServiceAccountPrincipal(namespace, name string) string {
+	return fmt.Sprintf("*/ns/%s/sa/%s", namespace, name)
+}
+
+// NewAuthorizationPolicy uses AuthorizationPolicyInfo to generate and return a
+// new AuthorizationPolicy object. The policy only allows traffic from the
+// cluster service account and waypoint, and from the client, dashboard and
+// monitoring workloads to their respective ports.
+func NewAuthorizationPolicy(info *AuthorizationPolicyInfo) *istio.AuthorizationPolicy {
+	principals := []string{ServiceAccountPrincipal(info.Namespace, info.ServiceAccount)}
+	if info.Waypoint != "" {
+		principals = append(principals, ServiceAccountPrincipal(info.Namespace, info.Waypoint))
+	}
+
+	return &istio.AuthorizationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      info.Name,
+			Namespace: info.Namespace,
+			Labels:    info.Labels,
+		},
+		Spec: securityv1beta1.AuthorizationPolicy{
+			Selector: &v1beta1.WorkloadSelector{
+				MatchLabels: info.Selector,
+			},
+			Action: securityv1beta1.AuthorizationPolicy_ALLOW,
+			Rules:  authorizationRules(info, principals),
+		},
+	}
+}
+
+// WaypointPolicyEnabled returns true when traffic to the cluster services is
+// forwarded by a waypoint that must enforce the client restrictions.
+func WaypointPolicyEnabled(info *AuthorizationPolicyInfo) bool {
+	return info.Waypoint != ""
+}
+
+// NewWaypointAuthorizationPolicy returns the AuthorizationPolicy enforced by
+// the waypoint for traffic sent to the cluster services. It carries the rules
+// of the cluster policy without the waypoint principal, since the waypoint
+// sees the identity of the original clients. Policies are attached with
+// "targetRefs", which requires Istio 1.22 or later, so the object is
+// unstructured.
+func NewWaypointAuthorizationPolicy(info *AuthorizationPolicyInfo) (*unstructured.Unstructured, error) {
+	spec := &securityv1beta1.AuthorizationPolicy{
+		Rules: authorizationRules(info, []string{ServiceAccountPrincipal(info.Namespace, info.ServiceAccount)}),
+	}
+
+	data, err := spec.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal waypoint authorization policy: %w", err)
+	}
+	content := map[string]interface{}{}
+	if err = json.Unmarshal(data, &content); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal waypoint authorization policy: %w", err)
+	}
+
+	var targetRefs []interface{}
+	for _, svc := range info.Services {
+		targetRefs = append(targetRefs, map[string]interface{}{
+			"group": "",
+			"kind":  "Service",
+			"name":  svc,
+		})
+	}
+	// the zero action is omitted by the marshaler
+	content["action"] = securityv1beta1.AuthorizationPolicy_ALLOW.String()
+	content["targetRefs"] = targetRefs
+
+	policy := WaypointAuthorizationPolicyReference(info.Name, info.Namespace)
+	policy.SetLabels(info.Labels)
+	policy.Object["spec"] = content
+
+	return policy, nil
+}
+
+// WaypointAuthorizationPolicyReference returns an empty waypoint policy that
+// identifies an existing object.
+func WaypointAuthorizationPolicyReference(name, namespace string) *unstructured.Unstructured {
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(AuthorizationPolicyGroupVersionKind)
+	policy.SetName(name + WaypointPolicySuffix)
+	policy.SetNamespace(namespace)
+
+	return policy
+}
+
+// authorizationRules allows every port to the principals, and the client,
+// dashboard and monitoring ports to their respective workloads.
+func authorizationRules(info *AuthorizationPolicyInfo, principals []string) []*securityv1beta1.Rule {
+	rules := []*securityv1beta1.Rule{
+		{
+			From: []*securityv1beta1.Rule_From{
+				{
+					Source: &securityv1beta1.Source{Principals: principals},
+				},
+			},
+		},
+	}
+	if info.Config == nil {
+		return rules
+	}
+
+	if from := ruleSources(info.Config.ClientPrincipals, info.Config.ClientNamespaces); from != nil {
+		rules = append(rules, &securityv1beta1.Rule{
+			From: from,
+			To:   ruleOperations(info.ClientPorts),
+		})
+	}
+
+	from := ruleSources(info.Config.DashboardPrincipals, info.Config.DashboardNamespaces)
+	if from != nil && len(info.DashboardPorts) != 0 {
+		rules = append(rules, &securityv1beta1.Rule{
+			From: from,
+			To:   ruleOperations(info.DashboardPorts),
+		})
+	}
+
+	from = ruleSources(info.Config.MonitoringPrincipals, info.Config.MonitoringNamespaces)
+	if from != nil && len(info.MonitoringPorts) != 0 {
+		rules = append(rules, &securityv1beta1.Rule{
+			From: from,
+			To:   ruleOperations(info.MonitoringPorts),
+		})
+	}
+
+	return rules
+}
+
+// ruleSources returns sources that match either the principals or the
+// namespaces. Separate sources are used because fields within a source must
+// all match.
+func ruleSources(principals, namespaces []string) []*securityv1beta1.Rule_From {
+	var from []*securityv1beta1.Rule_From
+	if len(principals) != 0 {
+		from = append(from, &securityv1beta1.Rule_From{
+			Source: &securityv1beta1.Source{Principals: principals},
+		})
+	}
+	if len(namespaces) != 0 {
+		from = append(from, &securityv1beta1.Rule_From{
+			Source: &securityv1beta1.Source{Namespaces: namespaces},
+		})
+	}
+
+	return from
+}
+
+func ruleOperations(ports []int32) []*securityv1beta1.Rule_To {
+	if len(ports) == 0 {
+		return nil
+	}
+
+	return []*securityv1beta1.Rule_To{
+		{
+			Operation: &securityv1beta1.Operation{
+				Ports: util.IntsToStrings(ports),
+			},
+		},
+	}
+}
@@ -0,0 +1,186 @@
+package istio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	securityv1beta1 "istio.io/api/security/v1beta1"
+	"istio.io/api/type/v1beta1"
+	istio "istio.io/client-go/pkg/apis/security/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestNewAuthorizationPolicy(t *testing.T) {
+	info := &AuthorizationPolicyInfo{
+		Name:      "cluster",
+		Namespace: "ns",
+		Labels: map[string]string{
+			"awesome": "true",
+		},
+		Selector: map[string]string{
+			"app.kubernetes.io/name": "compute-r",
+		},
+		ServiceAccount: "cluster-sa",
+		ClientPorts:    []int32{10001},
+		DashboardPorts: []int32{8265},
+		Config: &dcv1alpha1.IstioAuthorizationConfig{
+			Enabled:             true,
+			ClientPrincipals:    []string{"cluster.local/ns/ns/sa/client"},
+			ClientNamespaces:    []string{"clients"},
+			DashboardNamespaces: []string{"gateway"},
+		},
+	}
+	actual := NewAuthorizationPolicy(info)
+
+	expected := &istio.AuthorizationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster",
+			Namespace: "ns",
+			Labels: map[string]string{
+				"awesome": "true",
+			},
+		},
+		Spec: securityv1beta1.AuthorizationPolicy{
+			Selector: &v1beta1.WorkloadSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name": "compute-r",
+				},
+			},
+			Action: securityv1beta1.AuthorizationPolicy_ALLOW,
+			Rules: []*securityv1beta1.Rule{
+				{
+					From: []*securityv1beta1.Rule_From{
+						{Source: &securityv1beta1.Source{Principals: []string{"*/ns/ns/sa/cluster-sa"}}},
+					},
+				},
+				{
+					From: []*securityv1beta1.Rule_From{
+						{Source: &securityv1beta1.Source{Principals: []string{"cluster.local/ns/ns/sa/client"}}},
+						{Source: &securityv1beta1.Source{Namespaces: []string{"clients"}}},
+					},
+					To: []*securityv1beta1.Rule_To{
+						{Operation: &securityv1beta1.Operation{Ports: []string{"10001"}}},
+					},
+				},
+				{
+					From: []*securityv1beta1.Rule_From{
+						{Source: &securityv1beta1.Source{Namespaces: []string{"gateway"}}},
+					},
+					To: []*securityv1beta1.Rule_To{
+						{Operation: &securityv1beta1.Operation{Ports: []string{"8265"}}},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual)
+
+	t.Run("all_client_ports", func(t *testing.T) {
+		info.ClientPorts = nil
+		info.DashboardPorts = nil
+
+		rules := NewAuthorizationPolicy(info).Spec.Rules
+		assert.Len(t, rules, 2)
+		assert.Nil(t, rules[1].To)
+	})
+
+	t.Run("monitoring", func(t *testing.T) {
+		info.MonitoringPorts = []int32{9090}
+		info.Config.MonitoringNamespaces = []string{"monitoring"}
+
+		rules := NewAuthorizationPolicy(info).Spec.Rules
+		assert.Len(t, rules, 3)
+		assert.Equal(t, &securityv1beta1.Rule{
+			From: []*securityv1beta1.Rule_From{
+				{Source: &securityv1beta1.Source{Namespaces: []string{"monitoring"}}},
+			},
+			To: []*securityv1beta1.Rule_To{
+				{Operation: &securityv1beta1.Operation{Ports: []string{"9090"}}},
+			},
+		}, rules[2])
+
+		info.MonitoringPorts = nil
+		assert.Len(t, NewAuthorizationPolicy(info).Spec.Rules, 2)
+
+		info.Config.MonitoringNamespaces = nil
+	})
+
+	t.Run("waypoint", func(t *testing.T) {
+		info.Waypoint = "waypoint"
+
+		rules := NewAuthorizationPolicy(info).Spec.Rules
+		assert.Equal(t, []string{"*/ns/ns/sa/cluster-sa", "*/ns/ns/sa/waypoint"}, rules[0].From[0].Source.Principals)
+		assert.Nil(t, rules[0].To)
+
+		info.Waypoint = ""
+	})
+
+	t.Run("waypoint_policy", func(t *testing.T) {
+		assert.False(t, WaypointPolicyEnabled(info))
+
+		info.Waypoint = "waypoint"
+		info.Services = []string{"cluster-client", "cluster-headless"}
+		assert.True(t, WaypointPolicyEnabled(info))
+
+		policy, err := NewWaypointAuthorizationPolicy(info)
+		require.NoError(t, err)
+		assert.Equal(t, "cluster-waypoint", policy.GetName())
+		assert.Equal(t, "ns", policy.GetNamespace())
+		assert.Equal(t, AuthorizationPolicyGroupVersionKind, policy.GroupVersionKind())
+		assert.Equal(t, map[string]string{"awesome": "true"}, policy.GetLabels())
+
+		spec := policy.Object["spec"].(map[string]interface{})
+		assert.Equal(t, "ALLOW", spec["action"])
+		assert.NotContains(t, spec, "selector")
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"group": "", "kind": "Service", "name": "cluster-client"},
+			map[string]interface{}{"group": "", "kind": "Service", "name": "cluster-headless"},
+		}, spec["targetRefs"])
+
+		rules := spec["rules"].([]interface{})
+		require.Len(t, rules, 2)
+		assert.Equal(t, map[string]interface{}{
+			"from": []interface{}{
+				map[string]interface{}{
+					"source": map[string]interface{}{"principals": []interface{}{"*/ns/ns/sa/cluster-sa"}},
+				},
+			},
+		}, rules[0])
+		assert.Contains(t, rules[1].(map[string]interface{}), "from")
+
+		info.Waypoint = ""
+		info.Services = nil
+	})
+
+	t.Run("no_clients", func(t *testing.T) {
+		info.Config = nil
+
+		rules := NewAuthorizationPolicy(info).Spec.Rules
+		assert.Len(t, rules, 1)
+	})
+}
+
+func TestAuthorizationEnabled(t *testing.T) {
+	assert.False(t, AuthorizationEnabled(dcv1alpha1.IstioConfig{}))
+	assert.False(t, AuthorizationEnabled(dcv1alpha1.IstioConfig{Istio: &dcv1alpha1.IstioResourcesConfig{}}))
+	assert.True(t, AuthorizationEnabled(dcv1alpha1.IstioConfig{
+		Istio: &dcv1alpha1.IstioResourcesConfig{
+			Authorization: &dcv1alpha1.IstioAuthorizationConfig{Enabled: true},
+		},
+	}))
+}
+
+func TestWaypoint(t *testing.T) {
+	ic := dcv1alpha1.IstioConfig{
+		Istio: &dcv1alpha1.IstioResourcesConfig{
+			Authorization: &dcv1alpha1.IstioAuthorizationConfig{Enabled: true, Waypoint: "waypoint"},
+		},
+	}
+
+	assert.Equal(t, "waypoint", Waypoint(ic, ModeAmbient))
+	assert.Empty(t, Waypoint(ic, ModeSidecar))
+	assert.Empty(t, Waypoint(dcv1alpha1.IstioConfig{}, ModeAmbient))
+}
@@ -0,0 +1,122 @@
+package istio
+
+import (
+	"strconv"
+	"time"
+
+	spb "google.golang.org/protobuf/types/known/structpb"
+	networkingv1alpha3 "istio.io/api/networking/v1alpha3"
+	apinetworkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+const tcpProxyFilterName = "envoy.filters.network.tcp_proxy"
+
+// EnvoyFilterInfo defines fields used to generate Istio EnvoyFilter objects.
+type EnvoyFilterInfo struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	// Selector of the patched workloads, used unless the config provides
+	// workload labels.
+	Selector map[string]string
+	Config   *dcv1alpha1.IstioEnvoyFilterConfig
+}
+
+// EnvoyFilterConfig returns the EnvoyFilter config of a cluster.
+func EnvoyFilterConfig(ic dcv1alpha1.IstioConfig) *dcv1alpha1.IstioEnvoyFilterConfig {
+	if ic.Istio == nil {
+		return nil
+	}
+
+	return ic.Istio.EnvoyFilter
+}
+
+// NewEnvoyFilter uses EnvoyFilterInfo to generate and return a new EnvoyFilter
+// object that sets the idle_timeout of the TCP proxy filter.
+func NewEnvoyFilter(info *EnvoyFilterInfo) *apinetworkingv1alpha3.EnvoyFilter {
+	selector := info.Selector
+	var idleTimeout time.Duration
+	if info.Config != nil {
+		if info.Config.WorkloadLabels != nil {
+			selector = info.Config.WorkloadLabels
+		}
+		if info.Config.IdleTimeout != nil {
+			idleTimeout = info.Config.IdleTimeout.Duration
+		}
+	}
+
+	match := networkingv1alpha3.EnvoyFilter_EnvoyConfigObjectMatch{
+		Context: networkingv1alpha3.EnvoyFilter_ANY,
+		ObjectTypes: &networkingv1alpha3.EnvoyFilter_EnvoyConfigObjectMatch_Listener{
+			Listener: &networkingv1alpha3.EnvoyFilter_ListenerMatch{
+				FilterChain: &networkingv1alpha3.EnvoyFilter_ListenerMatch_FilterChainMatch{
+					Filter: &networkingv1alpha3.EnvoyFilter_ListenerMatch_FilterMatch{
+						Name: tcpProxyFilterName,
+					},
+				},
+			},
+		},
+	}
+
+	patch := networkingv1alpha3.EnvoyFilter_Patch{
+		Operation: networkingv1alpha3.EnvoyFilter_Patch_MERGE,
+		Value: &spb.Struct{
+			Fields: map[string]*spb.Value{
+				"name": {
+					Kind: &spb.Value_StringValue{
+						StringValue: tcpProxyFilterName,
+					},
+				},
+				"typed_config": {
+					Kind: &spb.Value_StructValue{
+						StructValue: &spb.Struct{
+							Fields: map[string]*spb.Value{
+								"@type": {
+									Kind: &spb.Value_StringValue{
+										StringValue: "type.googleapis.com/envoy.extensions.filters.network.tcp_proxy.v3.TcpProxy",
+									},
+								},
+								"idle_timeout": {
+									Kind: &spb.Value_StringValue{
+										StringValue: protoDuration(idleTimeout),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	configPatches := []*networkingv1alpha3.EnvoyFilter_EnvoyConfigObjectPatch{
+		{
+			ApplyTo: networkingv1alpha3.EnvoyFilter_NETWORK_FILTER,
+			Match:   &match,
+			Patch:   &patch,
+		},
+	}
+
+	return &apinetworkingv1alpha3.EnvoyFilter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      info.Name,
+			Namespace: info.Namespace,
+			Labels:    info.Labels,
+		},
+		Spec: networkingv1alpha3.EnvoyFilter{
+			WorkloadSelector: &networkingv1alpha3.WorkloadSelector{
+				Labels: selector,
+			},
+			ConfigPatches: configPatches,
+		},
+	}
+}
+
+// protoDuration formats a duration as expected by the JSON mapping of
+// protobuf durations, e.g. "0s" or "3600s".
+func protoDuration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
@@ -0,0 +1,62 @@
+package istio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestNewEnvoyFilter(t *testing.T) {
+	info := &EnvoyFilterInfo{
+		Name:      "cluster-envoyfilter",
+		Namespace: "ns",
+		Labels: map[string]string{
+			"awesome": "true",
+		},
+		Selector: map[string]string{
+			"app.kubernetes.io/name": "compute-r",
+		},
+	}
+
+	idleTimeout := func(info *EnvoyFilterInfo) string {
+		patches := NewEnvoyFilter(info).Spec.ConfigPatches
+		require.Len(t, patches, 1)
+
+		typedConfig := patches[0].Patch.Value.Fields["typed_config"].GetStructValue()
+		return typedConfig.Fields["idle_timeout"].GetStringValue()
+	}
+
+	t.Run("default", func(t *testing.T) {
+		actual := NewEnvoyFilter(info)
+
+		assert.Equal(t, "cluster-envoyfilter", actual.Name)
+		assert.Equal(t, "ns", actual.Namespace)
+		assert.Equal(t, map[string]string{"awesome": "true"}, actual.Labels)
+		assert.Equal(t, info.Selector, actual.Spec.WorkloadSelector.Labels)
+		assert.Equal(t, "0s", idleTimeout(info))
+	})
+
+	t.Run("config", func(t *testing.T) {
+		info.Config = &dcv1alpha1.IstioEnvoyFilterConfig{
+			IdleTimeout:    &metav1.Duration{Duration: 90 * time.Minute},
+			WorkloadLabels: map[string]string{"driver": "true"},
+		}
+		actual := NewEnvoyFilter(info)
+
+		assert.Equal(t, map[string]string{"driver": "true"}, actual.Spec.WorkloadSelector.Labels)
+		assert.Equal(t, "5400s", idleTimeout(info))
+	})
+
+	t.Run("fractional_timeout", func(t *testing.T) {
+		info.Config = &dcv1alpha1.IstioEnvoyFilterConfig{
+			IdleTimeout: &metav1.Duration{Duration: 1500 * time.Millisecond},
+		}
+
+		assert.Equal(t, "1.5s", idleTimeout(info))
+	})
+}
@@ -0,0 +1,53 @@
+package istio
+
+import "fmt"
+
+// Mode is the Istio data plane mode that captures cluster traffic. The zero
+// value means Istio support is disabled.
+type Mode string
+
+const (
+	// ModeSidecar captures traffic with Envoy sidecars injected into pods.
+	ModeSidecar Mode = "sidecar"
+	// ModeAmbient captures traffic with the node-level ztunnel proxies, and
+	// optionally waypoint proxies.
+	ModeAmbient Mode = "ambient"
+
+	// DataplaneModeLabel enrolls pods in the ambient mesh.
+	DataplaneModeLabel = "istio.io/dataplane-mode"
+)
+
+// ParseMode returns the Mode named by s.
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(s); m {
+	case ModeSidecar, ModeAmbient:
+		return m, nil
+	default:
+		return "", fmt.Errorf("invalid istio mode %q: must be one of %q or %q", s, ModeSidecar, ModeAmbient)
+	}
+}
+
+// Enabled returns true when Istio support is enabled.
+func (m Mode) Enabled() bool {
+	return m != ""
+}
+
+// Sidecar returns true when traffic is captured by Envoy sidecars.
+func (m Mode) Sidecar() bool {
+	return m == ModeSidecar
+}
+
+// Ambient returns true when traffic is captured by ztunnel.
+func (m Mode) Ambient() bool {
+	return m == ModeAmbient
+}
+
+// PodLabels returns the labels that enroll cluster pods in the mesh. Pods
+// enrolled through sidecar injection do not need any.
+func PodLabels(m Mode) map[string]string {
+	if !m.Ambient() {
+		return nil
+	}
+
+	return map[string]string{DataplaneModeLabel: string(ModeAmbient)}
+}
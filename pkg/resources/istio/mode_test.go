@@ -0,0 +1,34 @@
+package istio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMode(t *testing.T) {
+	mode, err := ParseMode("sidecar")
+	require.NoError(t, err)
+	assert.Equal(t, ModeSidecar, mode)
+	assert.True(t, mode.Enabled())
+	assert.True(t, mode.Sidecar())
+	assert.False(t, mode.Ambient())
+
+	mode, err = ParseMode("ambient")
+	require.NoError(t, err)
+	assert.Equal(t, ModeAmbient, mode)
+	assert.True(t, mode.Ambient())
+	assert.False(t, mode.Sidecar())
+
+	_, err = ParseMode("garbage")
+	assert.Error(t, err)
+
+	assert.False(t, Mode("").Enabled())
+}
+
+func TestPodLabels(t *testing.T) {
+	assert.Nil(t, PodLabels(""))
+	assert.Nil(t, PodLabels(ModeSidecar))
+	assert.Equal(t, map[string]string{"istio.io/dataplane-mode": "ambient"}, PodLabels(ModeAmbient))
+}
@@ -0,0 +1,111 @@
+package istio
+
+import (
+	"fmt"
+
+	networkingv1beta1 "istio.io/api/networking/v1beta1"
+	istionetworking "istio.io/client-go/pkg/apis/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+// clusterDomain is the default DNS domain of Kubernetes services, which Istio
+// uses to name service hosts.
+const clusterDomain = "cluster.local"
+
+// TrafficInfo defines fields used to generate Istio Sidecar and
+// DestinationRule objects.
+type TrafficInfo struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	Selector  map[string]string
+	// Services created for the cluster.
+	Services []string
+	// Mode of the mesh data plane.
+	Mode   Mode
+	Config *dcv1alpha1.IstioResourcesConfig
+}
+
+// SidecarEnabled returns true when a Sidecar should be generated for a cluster.
+// Sidecars only configure Envoy sidecar proxies, so they are never generated
+// in ambient mode.
+func SidecarEnabled(ic dcv1alpha1.IstioConfig, m Mode) bool {
+	return m.Sidecar() && ic.Istio != nil && ic.Istio.Sidecar != nil && ic.Istio.Sidecar.Enabled
+}
+
+// DestinationRuleEnabled returns true when DestinationRules should be
+// generated for the cluster services.
+func DestinationRuleEnabled(ic dcv1alpha1.IstioConfig) bool {
+	return ic.Istio != nil && ic.Istio.DestinationRule != nil && ic.Istio.DestinationRule.Enabled
+}
+
+// ServiceHost returns the fully qualified Istio host of a service.
+func ServiceHost(name, namespace string) string {
+	return fmt.Sprintf("%s.%s.svc.%s", name, namespace, clusterDomain)
+}
+
+// NewSidecar uses TrafficInfo to generate and return a new Sidecar object that
+// restricts the egress hosts of cluster pods to the cluster services, to the
+// client namespaces of the authorization config and to the configured hosts.
+// Client namespaces are reachable because frameworks connect back to their
+// clients, e.g. Spark executors to drivers and Dask workers to clients.
+func NewSidecar(info *TrafficInfo) *istionetworking.Sidecar {
+	var hosts []string
+	for _, svc := range info.Services {
+		hosts = append(hosts, "./"+ServiceHost(svc, info.Namespace))
+	}
+	if info.Config != nil && info.Config.Authorization != nil {
+		for _, ns := range info.Config.Authorization.ClientNamespaces {
+			hosts = append(hosts, ns+"/*")
+		}
+	}
+	if info.Config != nil && info.Config.Sidecar != nil {
+		hosts = append(hosts, info.Config.Sidecar.EgressHosts...)
+	}
+
+	return &istionetworking.Sidecar{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      info.Name,
+			Namespace: info.Namespace,
+			Labels:    info.Labels,
+		},
+		Spec: networkingv1beta1.Sidecar{
+			WorkloadSelector: &networkingv1beta1.WorkloadSelector{
+				Labels: info.Selector,
+			},
+			Egress: []*networkingv1beta1.IstioEgressListener{
+				{
+					Hosts: hosts,
+				},
+			},
+		},
+	}
+}
+
+// NewDestinationRules uses TrafficInfo to generate and return a new
+// DestinationRule object for every cluster service. The rules require Istio
+// mutual TLS and are named after their service.
+func NewDestinationRules(info *TrafficInfo) []*istionetworking.DestinationRule {
+	var rules []*istionetworking.DestinationRule
+	for _, svc := range info.Services {
+		rules = append(rules, &istionetworking.DestinationRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      svc,
+				Namespace: info.Namespace,
+				Labels:    info.Labels,
+			},
+			Spec: networkingv1beta1.DestinationRule{
+				Host: ServiceHost(svc, info.Namespace),
+				TrafficPolicy: &networkingv1beta1.TrafficPolicy{
+					Tls: &networkingv1beta1.ClientTLSSettings{
+						Mode: networkingv1beta1.ClientTLSSettings_ISTIO_MUTUAL,
+					},
+				},
+			},
+		})
+	}
+
+	return rules
+}
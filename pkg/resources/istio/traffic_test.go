@@ -0,0 +1,74 @@
+package istio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1beta1 "istio.io/api/networking/v1beta1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func testTrafficInfo() *TrafficInfo {
+	return &TrafficInfo{
+		Name:      "cluster",
+		Namespace: "ns",
+		Labels: map[string]string{
+			"awesome": "true",
+		},
+		Selector: map[string]string{
+			"app.kubernetes.io/name": "compute-r",
+		},
+		Services: []string{"cluster-head", "cluster-worker"},
+		Config: &dcv1alpha1.IstioResourcesConfig{
+			Authorization: &dcv1alpha1.IstioAuthorizationConfig{
+				ClientNamespaces: []string{"clients"},
+			},
+			Sidecar: &dcv1alpha1.IstioSidecarConfig{
+				Enabled:     true,
+				EgressHosts: []string{"istio-system/*"},
+			},
+		},
+	}
+}
+
+func TestNewSidecar(t *testing.T) {
+	sidecar := NewSidecar(testTrafficInfo())
+
+	assert.Equal(t, "cluster", sidecar.Name)
+	assert.Equal(t, "ns", sidecar.Namespace)
+	assert.Equal(t, map[string]string{"awesome": "true"}, sidecar.Labels)
+	assert.Equal(t, map[string]string{"app.kubernetes.io/name": "compute-r"}, sidecar.Spec.WorkloadSelector.Labels)
+	require.Len(t, sidecar.Spec.Egress, 1)
+	assert.Equal(t, []string{
+		"./cluster-head.ns.svc.cluster.local",
+		"./cluster-worker.ns.svc.cluster.local",
+		"clients/*",
+		"istio-system/*",
+	}, sidecar.Spec.Egress[0].Hosts)
+}
+
+func TestNewDestinationRules(t *testing.T) {
+	rules := NewDestinationRules(testTrafficInfo())
+	require.Len(t, rules, 2)
+
+	for idx, name := range []string{"cluster-head", "cluster-worker"} {
+		rule := rules[idx]
+
+		assert.Equal(t, name, rule.Name)
+		assert.Equal(t, "ns", rule.Namespace)
+		assert.Equal(t, map[string]string{"awesome": "true"}, rule.Labels)
+		assert.Equal(t, name+".ns.svc.cluster.local", rule.Spec.Host)
+		assert.Equal(t, networkingv1beta1.ClientTLSSettings_ISTIO_MUTUAL, rule.Spec.TrafficPolicy.Tls.Mode)
+	}
+}
+
+func TestTrafficEnabled(t *testing.T) {
+	ic := dcv1alpha1.IstioConfig{Istio: testTrafficInfo().Config}
+
+	assert.True(t, SidecarEnabled(ic, ModeSidecar))
+	assert.False(t, SidecarEnabled(ic, ModeAmbient))
+	assert.False(t, DestinationRuleEnabled(ic))
+	assert.False(t, SidecarEnabled(dcv1alpha1.IstioConfig{}, ModeSidecar))
+}
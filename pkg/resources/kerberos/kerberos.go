@@ -0,0 +1,218 @@
+package kerberos
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+const (
+	// ConfigKey holds krb5.conf in kerberos ConfigMaps.
+	ConfigKey = "krb5.conf"
+	// SidecarName is the name of the ticket renewal sidecar.
+	SidecarName = "kerberos"
+	// InitContainerName is the name of the init container that obtains the
+	// first ticket before framework containers start.
+	InitContainerName = "kerberos-init"
+
+	ticketCacheVolumeName = "kerberos-ccache"
+	configVolumeName      = "kerberos-config"
+	ticketCacheDir        = "/var/run/krb5"
+	configDir             = "/etc/krb5"
+	keytabDir             = "/var/run/krb5-keytab"
+	// retryIntervalSeconds is how long the sidecar waits after a failed kinit.
+	retryIntervalSeconds = 10
+	// renewIntervalSeconds is used when the config does not set an interval.
+	renewIntervalSeconds = 3600
+)
+
+// Info describes the kerberos configuration of a cluster.
+type Info struct {
+	// ConfigMapName of the generated krb5.conf ConfigMap.
+	ConfigMapName string
+	Namespace     string
+	Labels        map[string]string
+	// Image of the cluster, used by the sidecar unless the config sets one.
+	Image *dcv1alpha1.OCIImageDefinition
+	// KeytabVolume is the name of the pod volume that holds the keytab file
+	// under KeytabKey.
+	KeytabVolume string
+	Config       *dcv1alpha1.KerberosConfig
+}
+
+// Enabled returns true when cluster nodes should obtain kerberos tickets.
+func Enabled(kc *dcv1alpha1.KerberosConfig) bool {
+	return kc != nil && kc.Enabled
+}
+
+// ConfigMapEnabled returns true when krb5.conf is generated by the operator
+// instead of being read from an existing ConfigMap.
+func ConfigMapEnabled(kc *dcv1alpha1.KerberosConfig) bool {
+	return Enabled(kc) && kc.ConfigMapRef == nil
+}
+
+// Principal returns the principal of the keytab qualified with its realm.
+func Principal(kc *dcv1alpha1.KerberosConfig) string {
+	if kc.Realm == "" || strings.Contains(kc.Principal, "@") {
+		return kc.Principal
+	}
+
+	return fmt.Sprintf("%s@%s", kc.Principal, kc.Realm)
+}
+
+// NewConfigMap returns the ConfigMap that holds krb5.conf. A configuration
+// that locates KDCs through DNS is generated when no contents are provided.
+func NewConfigMap(info *Info) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      info.ConfigMapName,
+			Namespace: info.Namespace,
+			Labels:    info.Labels,
+		},
+	}
+	if !ConfigMapEnabled(info.Config) {
+		return cm
+	}
+
+	conf := info.Config.Config
+	if conf == "" {
+		conf = fmt.Sprintf(`[libdefaults]
+  default_realm = %s
+  dns_lookup_kdc = true
+  dns_lookup_realm = false
+  rdns = false
+`, info.Config.Realm)
+	}
+	cm.Data = map[string]string{ConfigKey: conf}
+
+	return cm
+}
+
+// Volumes returns the in-memory ticket cache and the krb5.conf volumes.
+func Volumes(info *Info) []corev1.Volume {
+	configMapName := info.ConfigMapName
+	if ref := info.Config.ConfigMapRef; ref != nil {
+		configMapName = ref.Name
+	}
+
+	return []corev1.Volume{
+		{
+			Name: ticketCacheVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium: corev1.StorageMediumMemory,
+				},
+			},
+		},
+		{
+			Name: configVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+					Items: []corev1.KeyToPath{
+						{
+							Key:  ConfigKey,
+							Path: ConfigKey,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// VolumeMounts returns the mounts that give framework containers access to
+// the ticket cache and krb5.conf.
+func VolumeMounts() []corev1.VolumeMount {
+	return []corev1.VolumeMount{
+		{
+			Name:      ticketCacheVolumeName,
+			MountPath: ticketCacheDir,
+		},
+		{
+			Name:      configVolumeName,
+			MountPath: configDir,
+			ReadOnly:  true,
+		},
+	}
+}
+
+// Env points kerberos clients at the ticket cache and krb5.conf.
+func Env() []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name:  "KRB5CCNAME",
+			Value: "FILE:" + path.Join(ticketCacheDir, "krb5cc"),
+		},
+		{
+			Name:  "KRB5_CONFIG",
+			Value: path.Join(configDir, ConfigKey),
+		},
+	}
+}
+
+// InitContainer returns a container that obtains the first ticket so that it
+// is available as soon as framework containers start.
+func InitContainer(info *Info) (corev1.Container, error) {
+	return newContainer(info, InitContainerName, fmt.Sprintf(
+		`until kinit -k -t "$KRB5_KEYTAB" "$KRB5_PRINCIPAL"; do sleep %d; done`,
+		retryIntervalSeconds,
+	))
+}
+
+// Sidecar returns a container that renews the ticket for as long as the pod
+// runs. A fresh ticket is obtained with the keytab on every renewal, so
+// tickets do not expire once their maximum renewable lifetime is reached.
+func Sidecar(info *Info) (corev1.Container, error) {
+	return newContainer(info, SidecarName, fmt.Sprintf(
+		`while true; do if kinit -k -t "$KRB5_KEYTAB" "$KRB5_PRINCIPAL"; then sleep %d; else sleep %d; fi; done`,
+		renewInterval(info.Config),
+		retryIntervalSeconds,
+	))
+}
+
+func renewInterval(kc *dcv1alpha1.KerberosConfig) int32 {
+	if kc.RenewIntervalSeconds == nil {
+		return renewIntervalSeconds
+	}
+
+	return *kc.RenewIntervalSeconds
+}
+
+func newContainer(info *Info, name, script string) (corev1.Container, error) {
+	image := info.Config.Image
+	if image == nil {
+		image = info.Image
+	}
+
+	ref, err := util.ParseImageDefinition(image)
+	if err != nil {
+		return corev1.Container{}, fmt.Errorf("cannot parse kerberos image: %w", err)
+	}
+
+	env := append(Env(),
+		corev1.EnvVar{Name: "KRB5_KEYTAB", Value: path.Join(keytabDir, KeytabKey)},
+		corev1.EnvVar{Name: "KRB5_PRINCIPAL", Value: Principal(info.Config)},
+	)
+	mounts := append(VolumeMounts(), corev1.VolumeMount{
+		Name:      info.KeytabVolume,
+		MountPath: keytabDir,
+		ReadOnly:  true,
+	})
+
+	return corev1.Container{
+		Name:            name,
+		Image:           ref,
+		ImagePullPolicy: image.PullPolicy,
+		Command:         []string{"/bin/sh", "-c", script},
+		Env:             env,
+		VolumeMounts:    mounts,
+		Resources:       info.Config.Resources,
+	}, nil
+}
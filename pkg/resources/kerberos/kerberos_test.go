@@ -0,0 +1,105 @@
+package kerberos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func testInfo() *Info {
+	return &Info{
+		ConfigMapName: "test-kerberos",
+		Namespace:     "ns",
+		Labels:        map[string]string{"app": "test"},
+		Image:         &dcv1alpha1.OCIImageDefinition{Repository: "cluster", Tag: "1.0.0"},
+		KeytabVolume:  "keytab",
+		Config: &dcv1alpha1.KerberosConfig{
+			Enabled:              true,
+			Principal:            "svc",
+			Realm:                "EXAMPLE.COM",
+			RenewIntervalSeconds: pointer.Int32(600),
+		},
+	}
+}
+
+func TestPrincipal(t *testing.T) {
+	kc := &dcv1alpha1.KerberosConfig{Principal: "svc", Realm: "EXAMPLE.COM"}
+	assert.Equal(t, "svc@EXAMPLE.COM", Principal(kc))
+
+	kc.Principal = "svc@OTHER.COM"
+	assert.Equal(t, "svc@OTHER.COM", Principal(kc))
+
+	kc.Realm = ""
+	kc.Principal = "svc"
+	assert.Equal(t, "svc", Principal(kc))
+}
+
+func TestNewConfigMap(t *testing.T) {
+	t.Run("generated", func(t *testing.T) {
+		cm := NewConfigMap(testInfo())
+		assert.Equal(t, "test-kerberos", cm.Name)
+		assert.Equal(t, "ns", cm.Namespace)
+		assert.Contains(t, cm.Data[ConfigKey], "default_realm = EXAMPLE.COM")
+	})
+
+	t.Run("contents", func(t *testing.T) {
+		info := testInfo()
+		info.Config.Config = "[libdefaults]\n"
+
+		cm := NewConfigMap(info)
+		assert.Equal(t, map[string]string{ConfigKey: "[libdefaults]\n"}, cm.Data)
+	})
+
+	t.Run("config_map_ref", func(t *testing.T) {
+		info := testInfo()
+		info.Config.ConfigMapRef = &corev1.LocalObjectReference{Name: "krb5"}
+		assert.False(t, ConfigMapEnabled(info.Config))
+
+		cm := NewConfigMap(info)
+		assert.Nil(t, cm.Data)
+
+		volumes := Volumes(info)
+		assert.Equal(t, "krb5", volumes[1].ConfigMap.Name)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.False(t, Enabled(nil))
+		assert.False(t, ConfigMapEnabled(&dcv1alpha1.KerberosConfig{}))
+	})
+}
+
+func TestSidecar(t *testing.T) {
+	info := testInfo()
+
+	sidecar, err := Sidecar(info)
+	require.NoError(t, err)
+	assert.Equal(t, SidecarName, sidecar.Name)
+	assert.Equal(t, "docker.io/library/cluster:1.0.0", sidecar.Image)
+	assert.Contains(t, sidecar.Command[2], "sleep 600")
+	assert.Contains(t, sidecar.Env, corev1.EnvVar{Name: "KRB5_PRINCIPAL", Value: "svc@EXAMPLE.COM"})
+	assert.Contains(t, sidecar.Env, corev1.EnvVar{Name: "KRB5_KEYTAB", Value: "/var/run/krb5-keytab/keytab"})
+	assert.Contains(t, sidecar.Env, corev1.EnvVar{Name: "KRB5CCNAME", Value: "FILE:/var/run/krb5/krb5cc"})
+	assert.Contains(t, sidecar.VolumeMounts, corev1.VolumeMount{
+		Name:      "keytab",
+		MountPath: "/var/run/krb5-keytab",
+		ReadOnly:  true,
+	})
+
+	initContainer, err := InitContainer(info)
+	require.NoError(t, err)
+	assert.Equal(t, InitContainerName, initContainer.Name)
+	assert.Contains(t, initContainer.Command[2], "until kinit")
+
+	t.Run("image", func(t *testing.T) {
+		info.Config.Image = &dcv1alpha1.OCIImageDefinition{Repository: "krb5", Tag: "latest"}
+
+		sidecar, err := Sidecar(info)
+		require.NoError(t, err)
+		assert.Equal(t, "docker.io/library/krb5:latest", sidecar.Image)
+	})
+}
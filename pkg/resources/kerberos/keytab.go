@@ -0,0 +1,108 @@
+// Package kerberos generates the pod configuration that provides kerberos
+// credentials to cluster nodes.
+package kerberos
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+const (
+	// KeytabKey is the file name of the keytab in keytab volumes, and its key
+	// in the Secrets generated from inline contents.
+	KeytabKey = "keytab"
+	// InlineKeytabReason of the warning event recorded when inline contents
+	// are copied into a Secret.
+	InlineKeytabReason = "InlineKeytab"
+
+	inlineKeytabMessage = "Inline keytab contents are readable by anyone who can view the cluster, use secretRef instead"
+)
+
+// KeytabInfo describes the Secret that holds inline keytab contents.
+type KeytabInfo struct {
+	SecretName string
+	// ConfigMapName of the ConfigMap that held inline contents in earlier
+	// versions. It is deleted so that keytabs are only stored in Secrets.
+	ConfigMapName string
+	Namespace     string
+	Labels        map[string]string
+	Config        *dcv1alpha1.KerberosKeytabConfig
+}
+
+// InlineKeytab returns true when the keytab contents are embedded in the
+// cluster spec and must be copied into a Secret.
+func InlineKeytab(kc *dcv1alpha1.KerberosKeytabConfig) bool {
+	return kc != nil && kc.SecretRef == nil
+}
+
+// NewKeytabSecret returns the Secret that holds inline keytab contents under
+// KeytabKey.
+func NewKeytabSecret(info *KeytabInfo) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      info.SecretName,
+			Namespace: info.Namespace,
+			Labels:    info.Labels,
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+	if InlineKeytab(info.Config) {
+		secret.Data = map[string][]byte{KeytabKey: info.Config.Contents}
+	}
+
+	return secret
+}
+
+// RecordInlineKeytab warns on obj that its keytab is stored in the cluster
+// spec rather than in a Secret.
+func RecordInlineKeytab(recorder record.EventRecorder, obj runtime.Object) {
+	if recorder != nil {
+		recorder.Event(obj, corev1.EventTypeWarning, InlineKeytabReason, inlineKeytabMessage)
+	}
+}
+
+// KeytabConfigMapReference returns an empty ConfigMap that identifies the
+// ConfigMap that held inline contents in earlier versions.
+func KeytabConfigMapReference(info *KeytabInfo) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      info.ConfigMapName,
+			Namespace: info.Namespace,
+		},
+	}
+}
+
+// NewKeytabVolume returns a volume that holds the keytab file under
+// KeytabKey. It is backed by the referenced Secret, or by the Secret
+// generated from inline contents.
+func NewKeytabVolume(name string, kc *dcv1alpha1.KerberosKeytabConfig, secretName string) corev1.Volume {
+	if InlineKeytab(kc) {
+		return corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secretName,
+				},
+			},
+		}
+	}
+
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: kc.SecretRef.Name,
+				Items: []corev1.KeyToPath{
+					{
+						Key:  kc.SecretRef.Key,
+						Path: KeytabKey,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package kerberos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestNewKeytabVolume(t *testing.T) {
+	t.Run("inline", func(t *testing.T) {
+		kc := &dcv1alpha1.KerberosKeytabConfig{Contents: []byte("keytab"), MountPath: "/etc/keytab"}
+		assert.True(t, InlineKeytab(kc))
+
+		volume := NewKeytabVolume("kerberos", kc, "test-keytab")
+		assert.Equal(t, "kerberos", volume.Name)
+		assert.Equal(t, &corev1.SecretVolumeSource{SecretName: "test-keytab"}, volume.Secret)
+	})
+
+	t.Run("secret_ref", func(t *testing.T) {
+		kc := &dcv1alpha1.KerberosKeytabConfig{
+			SecretRef: &dcv1alpha1.KerberosKeytabSecretReference{Name: "user-keytab", Key: "svc.keytab"},
+			MountPath: "/etc/keytab",
+		}
+		assert.False(t, InlineKeytab(kc))
+
+		volume := NewKeytabVolume("kerberos", kc, "test-keytab")
+		assert.Nil(t, volume.ConfigMap)
+		assert.Equal(t, &corev1.SecretVolumeSource{
+			SecretName: "user-keytab",
+			Items:      []corev1.KeyToPath{{Key: "svc.keytab", Path: "keytab"}},
+		}, volume.Secret)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		assert.False(t, InlineKeytab(nil))
+	})
+}
+
+func TestNewKeytabSecret(t *testing.T) {
+	info := &KeytabInfo{
+		SecretName:    "test-keytab",
+		ConfigMapName: "test-keytab-legacy",
+		Namespace:     "ns",
+		Labels:        map[string]string{"app": "ray"},
+		Config:        &dcv1alpha1.KerberosKeytabConfig{Contents: []byte("keytab"), MountPath: "/etc/keytab"},
+	}
+
+	secret := NewKeytabSecret(info)
+	assert.Equal(t, "test-keytab", secret.Name)
+	assert.Equal(t, "ns", secret.Namespace)
+	assert.Equal(t, corev1.SecretTypeOpaque, secret.Type)
+	assert.Equal(t, map[string][]byte{"keytab": []byte("keytab")}, secret.Data)
+
+	cm := KeytabConfigMapReference(info)
+	assert.Equal(t, "test-keytab-legacy", cm.Name)
+	assert.Equal(t, "ns", cm.Namespace)
+
+	t.Run("secret_ref", func(t *testing.T) {
+		info.Config = &dcv1alpha1.KerberosKeytabConfig{
+			SecretRef: &dcv1alpha1.KerberosKeytabSecretReference{Name: "user-keytab", Key: "keytab"},
+		}
+		assert.Nil(t, NewKeytabSecret(info).Data)
+	})
+}
+
+func TestRecordInlineKeytab(t *testing.T) {
+	recorder := record.NewFakeRecorder(1)
+	RecordInlineKeytab(recorder, &corev1.Pod{})
+
+	assert.Contains(t, <-recorder.Events, "Warning InlineKeytab")
+	assert.NotPanics(t, func() { RecordInlineKeytab(nil, &corev1.Pod{}) })
+}
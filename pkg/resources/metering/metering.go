@@ -0,0 +1,154 @@
+// Package metering accumulates the resources requested by cluster pods over
+// their lifetimes and reports the final usage of deleted clusters.
+package metering
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/accounting"
+)
+
+// PersistPeriod is how often accumulated usage is written to cluster status.
+const PersistPeriod = time.Minute
+
+const gibibyte = 1 << 30
+
+// Due returns true when usage has not been accumulated for a full period.
+func Due(usage *dcv1alpha1.ClusterUsage, now time.Time) bool {
+	if usage == nil || usage.LastUpdateTime == nil {
+		return true
+	}
+	return now.Sub(usage.LastUpdateTime.Time) >= PersistPeriod
+}
+
+// Accumulate returns a copy of usage that includes the resources requested by
+// pods between the last update and now. Pods are grouped by their component
+// label and only the portion of their lifetime that falls within that window
+// is counted, so the result can be persisted and accumulated again after an
+// operator restart. The lifetimes of pods observed for the first time are
+// counted from their start.
+func Accumulate(usage *dcv1alpha1.ClusterUsage, pods []corev1.Pod, now time.Time) *dcv1alpha1.ClusterUsage {
+	// status timestamps only have a resolution of seconds
+	now = now.Truncate(time.Second)
+
+	result := &dcv1alpha1.ClusterUsage{LastUpdateTime: &metav1.Time{Time: now}}
+	var since time.Time
+	if usage != nil {
+		for idx := range usage.Components {
+			result.Components = append(result.Components, *usage.Components[idx].DeepCopy())
+		}
+		if usage.LastUpdateTime != nil {
+			since = usage.LastUpdateTime.Time
+		}
+	}
+
+	for idx := range pods {
+		pod := &pods[idx]
+
+		seconds := int64(lifetime(pod, since, now) / time.Second)
+		if seconds <= 0 {
+			continue
+		}
+
+		cu := component(result, pod.Labels[resources.ApplicationComponentLabelKey])
+		cu.PodSeconds += seconds
+
+		requests := accounting.Pod(&pod.Spec).Requests
+		if cpu, ok := requests[corev1.ResourceCPU]; ok {
+			cu.CPUCoreSeconds.Add(*resource.NewMilliQuantity(cpu.MilliValue()*seconds, resource.DecimalSI))
+		}
+		if mem, ok := requests[corev1.ResourceMemory]; ok {
+			gibSeconds := mem.AsApproximateFloat64() * float64(seconds) / gibibyte
+			cu.MemoryGiBSeconds.Add(*resource.NewMilliQuantity(int64(math.Round(gibSeconds*1000)), resource.DecimalSI))
+		}
+	}
+
+	sort.Slice(result.Components, func(i, j int) bool {
+		return result.Components[i].Component < result.Components[j].Component
+	})
+
+	return result
+}
+
+// Summary describes usage in a single line.
+func Summary(usage *dcv1alpha1.ClusterUsage) string {
+	if usage == nil || len(usage.Components) == 0 {
+		return "no pods were observed"
+	}
+
+	var parts []string
+	for _, cu := range usage.Components {
+		parts = append(parts, fmt.Sprintf("%s: %d pod-seconds, %s CPU-core-seconds, %s memory-GiB-seconds",
+			componentName(cu.Component), cu.PodSeconds, decimal(cu.CPUCoreSeconds), decimal(cu.MemoryGiBSeconds)))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// lifetime returns how long a pod ran between since and now.
+func lifetime(pod *corev1.Pod, since, now time.Time) time.Duration {
+	if pod.Status.StartTime == nil {
+		return 0
+	}
+
+	start := pod.Status.StartTime.Time
+	if start.Before(since) {
+		start = since
+	}
+
+	end := now
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		end = finishTime(pod)
+	}
+	if end.After(now) {
+		end = now
+	}
+
+	return end.Sub(start)
+}
+
+// finishTime returns the time the last container of a finished pod
+// terminated, or its start time when that is unknown.
+func finishTime(pod *corev1.Pod) time.Time {
+	finish := pod.Status.StartTime.Time
+	for _, cs := range pod.Status.ContainerStatuses {
+		if t := cs.State.Terminated; t != nil && t.FinishedAt.After(finish) {
+			finish = t.FinishedAt.Time
+		}
+	}
+
+	return finish
+}
+
+func component(usage *dcv1alpha1.ClusterUsage, name string) *dcv1alpha1.ComponentUsage {
+	for idx := range usage.Components {
+		if usage.Components[idx].Component == name {
+			return &usage.Components[idx]
+		}
+	}
+
+	usage.Components = append(usage.Components, dcv1alpha1.ComponentUsage{Component: name})
+	return &usage.Components[len(usage.Components)-1]
+}
+
+func decimal(q resource.Quantity) string {
+	return strconv.FormatFloat(q.AsApproximateFloat64(), 'f', -1, 64)
+}
+
+func componentName(name string) string {
+	if name == "" {
+		return "pods"
+	}
+	return name
+}
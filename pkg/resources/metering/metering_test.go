@@ -0,0 +1,141 @@
+package metering
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources"
+)
+
+var start = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func testPod(component string, started time.Time) corev1.Pod {
+	pod := corev1.Pod{}
+	pod.Labels = map[string]string{resources.ApplicationComponentLabelKey: component}
+	pod.Spec.Containers = []corev1.Container{
+		{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+		},
+	}
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.StartTime = &metav1.Time{Time: started}
+	return pod
+}
+
+func assertQuantity(t *testing.T, expected string, actual resource.Quantity) {
+	t.Helper()
+
+	want := resource.MustParse(expected)
+	assert.Zero(t, want.Cmp(actual), "expected %s, got %s", expected, actual.String())
+}
+
+func TestDue(t *testing.T) {
+	assert.True(t, Due(nil, start))
+	assert.True(t, Due(&dcv1alpha1.ClusterUsage{}, start))
+
+	usage := &dcv1alpha1.ClusterUsage{LastUpdateTime: &metav1.Time{Time: start}}
+	assert.False(t, Due(usage, start.Add(30*time.Second)))
+	assert.True(t, Due(usage, start.Add(PersistPeriod)))
+}
+
+func TestAccumulate(t *testing.T) {
+	pods := []corev1.Pod{
+		testPod("head", start),
+		testPod("worker", start),
+		testPod("worker", start.Add(60*time.Second)),
+	}
+	pending := testPod("worker", start)
+	pending.Status.StartTime = nil
+	pods = append(pods, pending)
+
+	usage := Accumulate(nil, pods, start.Add(100*time.Second+500*time.Millisecond))
+
+	assert.Equal(t, start.Add(100*time.Second), usage.LastUpdateTime.Time)
+	require.Len(t, usage.Components, 2)
+
+	head := usage.Components[0]
+	assert.Equal(t, "head", head.Component)
+	assert.Equal(t, int64(100), head.PodSeconds)
+	assertQuantity(t, "50", head.CPUCoreSeconds)
+	assertQuantity(t, "200", head.MemoryGiBSeconds)
+
+	worker := usage.Components[1]
+	assert.Equal(t, "worker", worker.Component)
+	assert.Equal(t, int64(140), worker.PodSeconds)
+	assertQuantity(t, "70", worker.CPUCoreSeconds)
+
+	t.Run("continues_from_last_update", func(t *testing.T) {
+		finished := testPod("worker", start)
+		finished.Status.Phase = corev1.PodSucceeded
+		finished.Status.ContainerStatuses = []corev1.ContainerStatus{
+			{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				FinishedAt: metav1.Time{Time: start.Add(130 * time.Second)},
+			}}},
+		}
+
+		next := Accumulate(usage, []corev1.Pod{testPod("head", start), finished}, start.Add(200*time.Second))
+
+		assert.Equal(t, int64(200), next.Components[0].PodSeconds)
+		assert.Equal(t, int64(170), next.Components[1].PodSeconds)
+		assert.Equal(t, int64(100), usage.Components[0].PodSeconds, "previous usage was modified")
+	})
+}
+
+func TestReport(t *testing.T) {
+	obj := &dcv1alpha1.RayCluster{}
+	obj.Name = "test"
+	obj.Namespace = "ns"
+	obj.UID = "1234"
+	usage := Accumulate(nil, []corev1.Pod{testPod("head", start)}, start.Add(time.Minute))
+
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/cloudevents+json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	recorder := record.NewFakeRecorder(2)
+	err := Report(context.Background(), recorder, NewHTTPSink(server.URL), "RayCluster", obj, usage)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Normal UsageRecorded head: 60 pod-seconds, 30 CPU-core-seconds, 120 memory-GiB-seconds", <-recorder.Events)
+	assert.Equal(t, EventType, received["type"])
+	assert.Equal(t, "1234", received["id"])
+	assert.Equal(t, "/apis/distributed-compute.dominodatalab.com/v1alpha1/namespaces/ns/raycluster", received["source"])
+
+	data := received["data"].(map[string]interface{})
+	assert.Equal(t, "test", data["name"])
+	assert.Len(t, data["components"], 1)
+
+	t.Run("sink_failure", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer failing.Close()
+
+		recorder := record.NewFakeRecorder(2)
+		err := Report(context.Background(), recorder, NewHTTPSink(failing.URL), "RayCluster", obj, usage)
+
+		assert.Error(t, err)
+		<-recorder.Events
+		assert.Contains(t, <-recorder.Events, "Warning UsageSinkFailed")
+	})
+}
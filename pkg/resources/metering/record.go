@@ -0,0 +1,142 @@
+package metering
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+const (
+	// EventType is the CloudEvents type of usage records.
+	EventType = "com.dominodatalab.distributed-compute.usage"
+	// UsageRecordedReason is the reason of the event emitted with a final usage record.
+	UsageRecordedReason = "UsageRecorded"
+	// UsageSinkFailedReason is the reason of the event emitted when a record cannot be sent.
+	UsageSinkFailedReason = "UsageSinkFailed"
+
+	sinkTimeout = 10 * time.Second
+)
+
+// Record is the final usage of a deleted cluster.
+type Record struct {
+	Kind       string                      `json:"kind"`
+	Namespace  string                      `json:"namespace"`
+	Name       string                      `json:"name"`
+	UID        string                      `json:"uid"`
+	StartTime  time.Time                   `json:"startTime"`
+	EndTime    time.Time                   `json:"endTime"`
+	Components []dcv1alpha1.ComponentUsage `json:"components"`
+}
+
+// NewRecord returns the final usage record of a cluster.
+func NewRecord(kind string, obj client.Object, usage *dcv1alpha1.ClusterUsage) *Record {
+	r := &Record{
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		UID:       string(obj.GetUID()),
+		StartTime: obj.GetCreationTimestamp().Time,
+		EndTime:   time.Now().Truncate(time.Second),
+	}
+	if usage != nil {
+		r.Components = usage.Components
+		if usage.LastUpdateTime != nil {
+			r.EndTime = usage.LastUpdateTime.Time
+		}
+	}
+
+	return r
+}
+
+// Sink receives the final usage records of deleted clusters.
+type Sink interface {
+	Send(ctx context.Context, r *Record) error
+}
+
+// NewHTTPSink returns a sink that POSTs records to url as structured
+// CloudEvents. The cluster UID is used as the event ID so that receivers can
+// discard records delivered more than once.
+func NewHTTPSink(url string) Sink {
+	return &httpSink{url: url, client: &http.Client{Timeout: sinkTimeout}}
+}
+
+type httpSink struct {
+	url    string
+	client *http.Client
+}
+
+type cloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	Type            string    `json:"type"`
+	Source          string    `json:"source"`
+	Subject         string    `json:"subject"`
+	ID              string    `json:"id"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            *Record   `json:"data"`
+}
+
+func (s *httpSink) Send(ctx context.Context, r *Record) error {
+	body, err := json.Marshal(&cloudEvent{
+		SpecVersion:     "1.0",
+		Type:            EventType,
+		Source:          fmt.Sprintf("/apis/%s/namespaces/%s/%s", dcv1alpha1.GroupVersion, r.Namespace, strings.ToLower(r.Kind)),
+		Subject:         r.Name,
+		ID:              r.UID,
+		Time:            r.EndTime,
+		DataContentType: "application/json",
+		Data:            r,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot encode usage record: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot reach usage sink: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("usage sink rejected record: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	return nil
+}
+
+// Report emits the final usage of a cluster as an event and sends its record
+// to sink when one is configured. Failures to reach the sink are reported as
+// warning events and returned, but should not block cluster deletion.
+func Report(ctx context.Context, recorder record.EventRecorder, sink Sink, kind string, obj client.Object, usage *dcv1alpha1.ClusterUsage) error {
+	if recorder != nil {
+		recorder.Event(obj, corev1.EventTypeNormal, UsageRecordedReason, Summary(usage))
+	}
+	if sink == nil {
+		return nil
+	}
+
+	err := sink.Send(ctx, NewRecord(kind, obj, usage))
+	if err != nil && recorder != nil {
+		recorder.Event(obj, corev1.EventTypeWarning, UsageSinkFailedReason, err.Error())
+	}
+
+	return err
+}
@@ -0,0 +1,148 @@
+// Package monitoring builds the PodMonitor objects used by the Prometheus
+// Operator to scrape framework metrics. PodMonitor APIs are provided by
+// optional CRDs, so objects are unstructured and a missing API is treated the
+// same as a missing object.
+package monitoring
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+// componentSourceLabel is the Prometheus service discovery label holding
+// the value of the "app.kubernetes.io/component" pod label.
+const componentSourceLabel = "__meta_kubernetes_pod_label_app_kubernetes_io_component"
+
+// GroupVersionKind of the PodMonitor API.
+var GroupVersionKind = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "PodMonitor",
+}
+
+// Endpoint describes a metrics endpoint exposed by cluster pods.
+type Endpoint struct {
+	// Port is the name of the container port serving metrics.
+	Port string
+	// Path of the metrics endpoint.
+	Path string
+	// Component restricts the endpoint to pods of a single component, which
+	// is required when components serve metrics at different paths.
+	Component string
+}
+
+// Info describes the PodMonitor of a cluster.
+type Info struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	// Selector matches all cluster pods.
+	Selector  map[string]string
+	Endpoints []Endpoint
+	Config    *dcv1alpha1.MonitoringConfig
+}
+
+// Enabled returns true when monitoring is requested.
+func Enabled(mc *dcv1alpha1.MonitoringConfig) bool {
+	return mc != nil && mc.Enabled
+}
+
+// New returns the PodMonitor described by info.
+func New(info *Info) *unstructured.Unstructured {
+	pm := Reference(info.Name, info.Namespace)
+
+	labels := map[string]string{}
+	for k, v := range info.Labels {
+		labels[k] = v
+	}
+	for k, v := range info.Config.Labels {
+		labels[k] = v
+	}
+	pm.SetLabels(labels)
+
+	var endpoints []interface{}
+	for _, ep := range info.Endpoints {
+		endpoint := map[string]interface{}{
+			"port": ep.Port,
+			"path": ep.Path,
+		}
+		if info.Config.Interval != "" {
+			endpoint["interval"] = info.Config.Interval
+		}
+		if ep.Component != "" {
+			endpoint["relabelings"] = []interface{}{
+				map[string]interface{}{
+					"action":       "keep",
+					"sourceLabels": []interface{}{componentSourceLabel},
+					"regex":        ep.Component,
+				},
+			}
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	matchLabels := map[string]interface{}{}
+	for k, v := range info.Selector {
+		matchLabels[k] = v
+	}
+
+	pm.Object["spec"] = map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": matchLabels,
+		},
+		"podMetricsEndpoints": endpoints,
+	}
+
+	return pm
+}
+
+// Reference returns an empty PodMonitor that identifies an existing object.
+func Reference(name, namespace string) *unstructured.Unstructured {
+	pm := &unstructured.Unstructured{}
+	pm.SetGroupVersionKind(GroupVersionKind)
+	pm.SetName(name)
+	pm.SetNamespace(namespace)
+
+	return pm
+}
+
+// IngressRules returns the network policy rules that admit Prometheus to
+// the given metrics ports. No rules are returned when monitoring is disabled.
+// Prometheus pods are only matched in the cluster namespace unless namespace
+// labels are provided.
+func IngressRules(mc *dcv1alpha1.MonitoringConfig, ports ...int32) []networkingv1.NetworkPolicyIngressRule {
+	if !Enabled(mc) || len(ports) == 0 {
+		return nil
+	}
+
+	peer := networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: mc.PrometheusLabels,
+		},
+	}
+	if len(mc.PrometheusNamespaceLabels) > 0 {
+		peer.NamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: mc.PrometheusNamespaceLabels,
+		}
+	}
+
+	tcpProto := corev1.ProtocolTCP
+	rule := networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{peer},
+	}
+	for _, port := range ports {
+		p := intstr.FromInt(int(port))
+		rule.Ports = append(rule.Ports, networkingv1.NetworkPolicyPort{
+			Port:     &p,
+			Protocol: &tcpProto,
+		})
+	}
+
+	return []networkingv1.NetworkPolicyIngressRule{rule}
+}
@@ -0,0 +1,93 @@
+package monitoring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestNew(t *testing.T) {
+	info := &Info{
+		Name:      "test-spark",
+		Namespace: "ns",
+		Labels:    map[string]string{"app": "spark"},
+		Selector:  map[string]string{"instance": "test"},
+		Endpoints: []Endpoint{
+			{Port: "http", Path: "/metrics/master/prometheus", Component: "master"},
+			{Port: "http", Path: "/metrics/prometheus", Component: "worker"},
+		},
+		Config: &dcv1alpha1.MonitoringConfig{
+			Enabled:  true,
+			Interval: "15s",
+			Labels:   map[string]string{"release": "prometheus"},
+		},
+	}
+	pm := New(info)
+
+	assert.Equal(t, "monitoring.coreos.com/v1", pm.GetAPIVersion())
+	assert.Equal(t, "PodMonitor", pm.GetKind())
+	assert.Equal(t, "test-spark", pm.GetName())
+	assert.Equal(t, "ns", pm.GetNamespace())
+	assert.Equal(t, map[string]string{"app": "spark", "release": "prometheus"}, pm.GetLabels())
+
+	spec := pm.Object["spec"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"matchLabels": map[string]interface{}{"instance": "test"},
+	}, spec["selector"])
+
+	endpoints := spec["podMetricsEndpoints"].([]interface{})
+	require.Len(t, endpoints, 2)
+	assert.Equal(t, map[string]interface{}{
+		"port":     "http",
+		"path":     "/metrics/master/prometheus",
+		"interval": "15s",
+		"relabelings": []interface{}{
+			map[string]interface{}{
+				"action":       "keep",
+				"sourceLabels": []interface{}{componentSourceLabel},
+				"regex":        "master",
+			},
+		},
+	}, endpoints[0])
+
+	t.Run("all_components", func(t *testing.T) {
+		info.Endpoints = []Endpoint{{Port: "metrics", Path: "/metrics"}}
+		info.Config.Interval = ""
+
+		spec := New(info).Object["spec"].(map[string]interface{})
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"port": "metrics", "path": "/metrics"},
+		}, spec["podMetricsEndpoints"])
+	})
+}
+
+func TestIngressRules(t *testing.T) {
+	assert.Nil(t, IngressRules(nil, 8787))
+	assert.Nil(t, IngressRules(&dcv1alpha1.MonitoringConfig{}, 8787))
+
+	mc := &dcv1alpha1.MonitoringConfig{
+		Enabled:          true,
+		PrometheusLabels: map[string]string{"app": "prometheus"},
+	}
+	rules := IngressRules(mc, 8787)
+
+	require.Len(t, rules, 1)
+	require.Len(t, rules[0].From, 1)
+	assert.Equal(t, &metav1.LabelSelector{MatchLabels: mc.PrometheusLabels}, rules[0].From[0].PodSelector)
+	assert.Nil(t, rules[0].From[0].NamespaceSelector)
+	require.Len(t, rules[0].Ports, 1)
+	assert.Equal(t, intstr.FromInt(8787), *rules[0].Ports[0].Port)
+
+	t.Run("other_namespace", func(t *testing.T) {
+		mc.PrometheusNamespaceLabels = map[string]string{"name": "monitoring"}
+		rules := IngressRules(mc, 8080, 8081)
+
+		assert.Equal(t, &metav1.LabelSelector{MatchLabels: mc.PrometheusNamespaceLabels}, rules[0].From[0].NamespaceSelector)
+		assert.Len(t, rules[0].Ports, 2)
+	})
+}
@@ -0,0 +1,179 @@
+// Package podgroup builds the PodGroup objects used by gang-aware schedulers.
+// PodGroup APIs are provided by optional CRDs, so objects are unstructured
+// and a missing API is treated the same as a missing object.
+package podgroup
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+const (
+	// CoschedulingLabel assigns a pod to a coscheduling PodGroup.
+	CoschedulingLabel = "scheduling.x-k8s.io/pod-group"
+	// VolcanoAnnotation assigns a pod to a Volcano PodGroup.
+	VolcanoAnnotation = "scheduling.k8s.io/group-name"
+
+	kind = "PodGroup"
+)
+
+var (
+	providerGroupVersions = map[dcv1alpha1.GangSchedulingProvider]schema.GroupVersion{
+		dcv1alpha1.GangSchedulingCoscheduling: {Group: "scheduling.x-k8s.io", Version: "v1alpha1"},
+		dcv1alpha1.GangSchedulingVolcano:      {Group: "scheduling.volcano.sh", Version: "v1beta1"},
+	}
+
+	// Phases after which a PodGroup no longer changes while its pods run
+	settledPhases = map[string]bool{
+		"Scheduled": true,
+		"Running":   true,
+		"Succeeded": true,
+		"Failed":    true,
+		"Finished":  true,
+		"Completed": true,
+	}
+)
+
+// Info describes the PodGroup of a cluster.
+type Info struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	// MinMember is the number of pods that must be schedulable together.
+	MinMember int32
+	Config    *dcv1alpha1.GangSchedulingConfig
+}
+
+// Enabled returns true when gang scheduling is requested.
+func Enabled(gs *dcv1alpha1.GangSchedulingConfig) bool {
+	return gs != nil && gs.Enabled
+}
+
+// MinMember adds the minimum number of workers to the given number of
+// singleton pods, e.g. a head node. Autoscaled clusters never scale below
+// their minimum replicas, while other clusters need all of their workers.
+func MinMember(singletons int32, replicas *int32, as *dcv1alpha1.Autoscaling) int32 {
+	switch {
+	case as == nil && replicas != nil:
+		return singletons + *replicas
+	case as != nil && as.MinReplicas != nil:
+		return singletons + *as.MinReplicas
+	case as != nil:
+		return singletons + 1
+	}
+	return singletons
+}
+
+// GroupVersionKind returns the PodGroup kind served by a provider.
+func GroupVersionKind(provider dcv1alpha1.GangSchedulingProvider) schema.GroupVersionKind {
+	return providerGroupVersions[provider].WithKind(kind)
+}
+
+// New returns the PodGroup described by info.
+func New(info *Info) *unstructured.Unstructured {
+	pg := newObject(info.Config.Provider, info.Name, info.Namespace)
+	pg.SetLabels(info.Labels)
+
+	spec := map[string]interface{}{
+		"minMember": int64(info.MinMember),
+	}
+	switch info.Config.Provider {
+	case dcv1alpha1.GangSchedulingCoscheduling:
+		if info.Config.ScheduleTimeoutSeconds != nil {
+			spec["scheduleTimeoutSeconds"] = int64(*info.Config.ScheduleTimeoutSeconds)
+		}
+	case dcv1alpha1.GangSchedulingVolcano:
+		if info.Config.Queue != "" {
+			spec["queue"] = info.Config.Queue
+		}
+	}
+	pg.Object["spec"] = spec
+
+	return pg
+}
+
+// Stale returns the PodGroups of a cluster that should not exist, i.e. all of
+// them when gang scheduling is disabled, or those of other providers.
+func Stale(gs *dcv1alpha1.GangSchedulingConfig, name, namespace string) []*unstructured.Unstructured {
+	var stale []*unstructured.Unstructured
+	for _, provider := range []dcv1alpha1.GangSchedulingProvider{
+		dcv1alpha1.GangSchedulingCoscheduling,
+		dcv1alpha1.GangSchedulingVolcano,
+	} {
+		if Enabled(gs) && gs.Provider == provider {
+			continue
+		}
+		stale = append(stale, newObject(provider, name, namespace))
+	}
+
+	return stale
+}
+
+// ConfigurePodTemplate assigns the pods of a template to the named PodGroup
+// and hands them over to the gang-aware scheduler.
+func ConfigurePodTemplate(gs *dcv1alpha1.GangSchedulingConfig, name string, tmpl *corev1.PodTemplateSpec) {
+	if !Enabled(gs) {
+		return
+	}
+
+	tmpl.Spec.SchedulerName = gs.SchedulerName
+
+	switch gs.Provider {
+	case dcv1alpha1.GangSchedulingCoscheduling:
+		tmpl.Labels = withEntry(tmpl.Labels, CoschedulingLabel, name)
+	case dcv1alpha1.GangSchedulingVolcano:
+		tmpl.Annotations = withEntry(tmpl.Annotations, VolcanoAnnotation, name)
+	}
+}
+
+// FetchPhase returns the phase of the cluster PodGroup. It is blank when gang
+// scheduling is disabled, or when the PodGroup or its API does not exist.
+func FetchPhase(ctx context.Context, c client.Reader, gs *dcv1alpha1.GangSchedulingConfig, name, namespace string) (string, error) {
+	if !Enabled(gs) {
+		return "", nil
+	}
+
+	pg := newObject(gs.Provider, name, namespace)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(pg), pg); err != nil {
+		if util.IgnoreMissing(err) == nil {
+			return "", nil
+		}
+		return "", fmt.Errorf("cannot fetch pod group: %w", err)
+	}
+
+	phase, _, err := unstructured.NestedString(pg.Object, "status", "phase")
+	return phase, err
+}
+
+// Settled returns false while a PodGroup is still waiting for its pods to be
+// scheduled, in which case its phase should be polled.
+func Settled(phase string) bool {
+	return settledPhases[phase]
+}
+
+func newObject(provider dcv1alpha1.GangSchedulingProvider, name, namespace string) *unstructured.Unstructured {
+	pg := &unstructured.Unstructured{}
+	pg.SetGroupVersionKind(GroupVersionKind(provider))
+	pg.SetName(name)
+	pg.SetNamespace(namespace)
+
+	return pg
+}
+
+func withEntry(m map[string]string, key, value string) map[string]string {
+	out := make(map[string]string, len(m)+1)
+	for k, v := range m {
+		out[k] = v
+	}
+	out[key] = value
+
+	return out
+}
@@ -0,0 +1,123 @@
+package podgroup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestMinMember(t *testing.T) {
+	assert.Equal(t, int32(4), MinMember(1, pointer.Int32(3), nil))
+	assert.Equal(t, int32(3), MinMember(1, pointer.Int32(5), &dcv1alpha1.Autoscaling{MinReplicas: pointer.Int32(2)}))
+	assert.Equal(t, int32(2), MinMember(1, pointer.Int32(5), &dcv1alpha1.Autoscaling{}))
+	assert.Equal(t, int32(1), MinMember(1, nil, nil))
+}
+
+func TestNew(t *testing.T) {
+	info := &Info{
+		Name:      "test-dask",
+		Namespace: "ns",
+		Labels:    map[string]string{"app": "dask"},
+		MinMember: 3,
+	}
+
+	t.Run("coscheduling", func(t *testing.T) {
+		info.Config = &dcv1alpha1.GangSchedulingConfig{
+			Enabled:                true,
+			Provider:               dcv1alpha1.GangSchedulingCoscheduling,
+			ScheduleTimeoutSeconds: pointer.Int32(60),
+		}
+		pg := New(info)
+
+		assert.Equal(t, "scheduling.x-k8s.io/v1alpha1", pg.GetAPIVersion())
+		assert.Equal(t, "PodGroup", pg.GetKind())
+		assert.Equal(t, "test-dask", pg.GetName())
+		assert.Equal(t, "ns", pg.GetNamespace())
+		assert.Equal(t, map[string]string{"app": "dask"}, pg.GetLabels())
+		assert.Equal(t, map[string]interface{}{
+			"minMember":              int64(3),
+			"scheduleTimeoutSeconds": int64(60),
+		}, pg.Object["spec"])
+	})
+
+	t.Run("volcano", func(t *testing.T) {
+		info.Config = &dcv1alpha1.GangSchedulingConfig{
+			Enabled:  true,
+			Provider: dcv1alpha1.GangSchedulingVolcano,
+			Queue:    "research",
+		}
+		pg := New(info)
+
+		assert.Equal(t, "scheduling.volcano.sh/v1beta1", pg.GetAPIVersion())
+		assert.Equal(t, map[string]interface{}{
+			"minMember": int64(3),
+			"queue":     "research",
+		}, pg.Object["spec"])
+	})
+}
+
+func TestStale(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		assert.Len(t, Stale(nil, "test", "ns"), 2)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		gs := &dcv1alpha1.GangSchedulingConfig{Enabled: true, Provider: dcv1alpha1.GangSchedulingVolcano}
+
+		stale := Stale(gs, "test", "ns")
+		if assert.Len(t, stale, 1) {
+			assert.Equal(t, GroupVersionKind(dcv1alpha1.GangSchedulingCoscheduling), stale[0].GroupVersionKind())
+		}
+	})
+}
+
+func TestConfigurePodTemplate(t *testing.T) {
+	newTemplate := func() *corev1.PodTemplateSpec {
+		tmpl := &corev1.PodTemplateSpec{}
+		tmpl.Labels = map[string]string{"app": "dask"}
+		return tmpl
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		tmpl := newTemplate()
+		ConfigurePodTemplate(&dcv1alpha1.GangSchedulingConfig{}, "test", tmpl)
+
+		assert.Equal(t, newTemplate(), tmpl)
+	})
+
+	t.Run("coscheduling", func(t *testing.T) {
+		tmpl := newTemplate()
+		labels := tmpl.Labels
+		ConfigurePodTemplate(&dcv1alpha1.GangSchedulingConfig{
+			Enabled:       true,
+			Provider:      dcv1alpha1.GangSchedulingCoscheduling,
+			SchedulerName: "scheduler-plugins-scheduler",
+		}, "test", tmpl)
+
+		assert.Equal(t, "scheduler-plugins-scheduler", tmpl.Spec.SchedulerName)
+		assert.Equal(t, map[string]string{"app": "dask", CoschedulingLabel: "test"}, tmpl.Labels)
+		assert.NotContains(t, labels, CoschedulingLabel, "shared label map was modified")
+	})
+
+	t.Run("volcano", func(t *testing.T) {
+		tmpl := newTemplate()
+		ConfigurePodTemplate(&dcv1alpha1.GangSchedulingConfig{
+			Enabled:       true,
+			Provider:      dcv1alpha1.GangSchedulingVolcano,
+			SchedulerName: "volcano",
+		}, "test", tmpl)
+
+		assert.Equal(t, "volcano", tmpl.Spec.SchedulerName)
+		assert.Equal(t, map[string]string{VolcanoAnnotation: "test"}, tmpl.Annotations)
+	})
+}
+
+func TestSettled(t *testing.T) {
+	assert.True(t, Settled("Running"))
+	assert.False(t, Settled("Pending"))
+	assert.False(t, Settled(""))
+}
@@ -0,0 +1,64 @@
+package podsecurity
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+// Harden modifies a pod spec so that it meets the given Pod Security Standard.
+//
+// Only the "restricted" profile requires changes; settings that would violate
+// the "baseline" profile are rejected by the validating webhooks instead.
+// Containers and security contexts are copied before they are modified
+// because generated specs share them with the owning cluster resource.
+func Harden(profile dcv1alpha1.SecurityProfile, spec *corev1.PodSpec) {
+	if profile != dcv1alpha1.SecurityProfileRestricted {
+		return
+	}
+
+	psc := spec.SecurityContext.DeepCopy()
+	if psc == nil {
+		psc = &corev1.PodSecurityContext{}
+	}
+	if psc.RunAsNonRoot == nil {
+		psc.RunAsNonRoot = pointer.Bool(true)
+	}
+	if psc.SeccompProfile == nil {
+		psc.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	}
+	spec.SecurityContext = psc
+
+	spec.InitContainers = hardenContainers(spec.InitContainers)
+	spec.Containers = hardenContainers(spec.Containers)
+}
+
+func hardenContainers(containers []corev1.Container) []corev1.Container {
+	if containers == nil {
+		return nil
+	}
+
+	hardened := make([]corev1.Container, len(containers))
+	for idx := range containers {
+		hardened[idx] = containers[idx]
+		hardenContainer(&hardened[idx])
+	}
+
+	return hardened
+}
+
+func hardenContainer(container *corev1.Container) {
+	sc := container.SecurityContext.DeepCopy()
+	if sc == nil {
+		sc = &corev1.SecurityContext{}
+	}
+	if sc.AllowPrivilegeEscalation == nil {
+		sc.AllowPrivilegeEscalation = pointer.Bool(false)
+	}
+	if sc.Capabilities == nil {
+		sc.Capabilities = &corev1.Capabilities{}
+	}
+	sc.Capabilities.Drop = []corev1.Capability{"ALL"}
+	container.SecurityContext = sc
+}
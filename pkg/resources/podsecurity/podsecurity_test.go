@@ -0,0 +1,70 @@
+package podsecurity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestHarden(t *testing.T) {
+	newSpec := func(psc *corev1.PodSecurityContext, sc *corev1.SecurityContext) *corev1.PodSpec {
+		return &corev1.PodSpec{
+			SecurityContext: psc,
+			InitContainers:  []corev1.Container{{Name: "init"}},
+			Containers:      []corev1.Container{{Name: "main", SecurityContext: sc}},
+		}
+	}
+
+	t.Run("restricted", func(t *testing.T) {
+		spec := newSpec(nil, nil)
+		Harden(dcv1alpha1.SecurityProfileRestricted, spec)
+
+		expected := &corev1.PodSecurityContext{
+			RunAsNonRoot:   pointer.Bool(true),
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		}
+		assert.Equal(t, expected, spec.SecurityContext)
+
+		expectedContainer := &corev1.SecurityContext{
+			AllowPrivilegeEscalation: pointer.Bool(false),
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		}
+		assert.Equal(t, expectedContainer, spec.InitContainers[0].SecurityContext)
+		assert.Equal(t, expectedContainer, spec.Containers[0].SecurityContext)
+	})
+
+	t.Run("restricted_keeps_user_settings", func(t *testing.T) {
+		psc := &corev1.PodSecurityContext{
+			RunAsUser:      pointer.Int64(1000),
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost},
+		}
+		sc := &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE"}},
+		}
+		spec := newSpec(psc, sc)
+		initContainers := spec.InitContainers
+		Harden(dcv1alpha1.SecurityProfileRestricted, spec)
+
+		assert.Equal(t, pointer.Int64(1000), spec.SecurityContext.RunAsUser)
+		assert.Equal(t, corev1.SeccompProfileTypeLocalhost, spec.SecurityContext.SeccompProfile.Type)
+		assert.Equal(t, []corev1.Capability{"NET_BIND_SERVICE"}, spec.Containers[0].SecurityContext.Capabilities.Add)
+		assert.Equal(t, []corev1.Capability{"ALL"}, spec.Containers[0].SecurityContext.Capabilities.Drop)
+
+		assert.Nil(t, psc.RunAsNonRoot, "shared pod security context was modified")
+		assert.Nil(t, sc.Capabilities.Drop, "shared security context was modified")
+		assert.Nil(t, initContainers[0].SecurityContext, "shared init containers were modified")
+	})
+
+	for _, profile := range []dcv1alpha1.SecurityProfile{"", dcv1alpha1.SecurityProfilePrivileged, dcv1alpha1.SecurityProfileBaseline} {
+		t.Run("unchanged_"+string(profile), func(t *testing.T) {
+			spec := newSpec(nil, nil)
+			Harden(profile, spec)
+
+			assert.Equal(t, newSpec(nil, nil), spec)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package ray
+
+import (
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashboard"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+)
+
+// AuthorizationPolicyInfo describes the Istio authorization policy of cluster
+// pods. Clients are granted access to the client server and file sync ports,
+// which mirrors the cluster network policies.
+func AuthorizationPolicyInfo(rc *dcv1alpha1.RayCluster, istioMode istio.Mode) *istio.AuthorizationPolicyInfo {
+	clientPorts := []int32{rc.Spec.ClientServerPort}
+	if SyncEnabled(rc) {
+		clientPorts = append(clientPorts, rc.Spec.Sync.Port)
+	}
+
+	info := &istio.AuthorizationPolicyInfo{
+		Name:            InstanceObjectName(rc.Name, ComponentNone),
+		Namespace:       rc.Namespace,
+		Labels:          AddGlobalLabels(MetadataLabels(rc), rc.Spec.GlobalLabels),
+		Selector:        SelectorLabels(rc),
+		ServiceAccount:  ServiceAccountName(rc),
+		ClientPorts:     clientPorts,
+		DashboardPorts:  []int32{dashboard.Port(rc.Spec.Dashboard, rc.Spec.DashboardPort)},
+		MonitoringPorts: MetricsPorts(rc),
+		Waypoint:        istio.Waypoint(rc.Spec.IstioConfig, istioMode),
+		Services:        TrafficInfo(rc, istioMode).Services,
+	}
+	if rc.Spec.Istio != nil {
+		info.Config = rc.Spec.Istio.Authorization
+	}
+
+	return info
+}
@@ -0,0 +1,56 @@
+package ray
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+)
+
+func TestAuthorizationPolicyInfo(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.ServiceAccount.Name = "custom"
+	rc.Spec.Istio = &dcv1alpha1.IstioResourcesConfig{
+		Authorization: &dcv1alpha1.IstioAuthorizationConfig{Enabled: true},
+	}
+
+	info := AuthorizationPolicyInfo(rc, istio.ModeSidecar)
+	assert.Equal(t, "test-id-ray", info.Name)
+	assert.Equal(t, "fake-ns", info.Namespace)
+	assert.Equal(t, SelectorLabels(rc), info.Selector)
+	assert.Equal(t, "custom", info.ServiceAccount)
+	assert.Equal(t, []int32{rc.Spec.ClientServerPort}, info.ClientPorts)
+	assert.Equal(t, []int32{rc.Spec.DashboardPort}, info.DashboardPorts)
+	assert.Nil(t, info.MonitoringPorts)
+	assert.Equal(t, TrafficInfo(rc, istio.ModeSidecar).Services, info.Services)
+	assert.Empty(t, info.Waypoint)
+	assert.Equal(t, rc.Spec.Istio.Authorization, info.Config)
+
+	t.Run("monitoring", func(t *testing.T) {
+		rc := rayClusterFixture()
+		rc.Spec.MetricsExportPort = 8080
+		rc.Spec.Monitoring = &dcv1alpha1.MonitoringConfig{Enabled: true}
+
+		info := AuthorizationPolicyInfo(rc, istio.ModeSidecar)
+		assert.Equal(t, []int32{8080}, info.MonitoringPorts)
+	})
+
+	t.Run("sync", func(t *testing.T) {
+		rc.Spec.Sync = &dcv1alpha1.SyncConfig{Enabled: true, Port: 9999}
+
+		info := AuthorizationPolicyInfo(rc, istio.ModeSidecar)
+		assert.Equal(t, []int32{rc.Spec.ClientServerPort, 9999}, info.ClientPorts)
+	})
+
+	t.Run("waypoint", func(t *testing.T) {
+		rc.Spec.Istio.Authorization.Waypoint = "waypoint"
+
+		info := AuthorizationPolicyInfo(rc, istio.ModeSidecar)
+		assert.Empty(t, info.Waypoint)
+
+		info = AuthorizationPolicyInfo(rc, istio.ModeAmbient)
+		assert.Equal(t, "waypoint", info.Waypoint)
+	})
+}
@@ -0,0 +1,69 @@
+package ray
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/clustertls"
+)
+
+// tlsMountPath is where the node certificate is mounted in ray containers.
+const tlsMountPath = "/etc/ray/tls"
+
+// TLSEnabled returns true when ray gRPC communication is encrypted.
+func TLSEnabled(rc *dcv1alpha1.RayCluster) bool {
+	return clustertls.Enabled(rc.Spec.TLS)
+}
+
+// TLSClientSecretName returns the name of the secret holding the certificate
+// published for ray clients.
+func TLSClientSecretName(name string) string {
+	return InstanceObjectName(name, Component("client-tls"))
+}
+
+// ClusterTLSInfo describes the certificates of the cluster. Ray nodes connect
+// to each other by IP address and verify the server name, so every pod signs
+// its own certificate at startup. Node certificates are valid for the pod IP,
+// the loopback address, the client and headless services, and for the DNS
+// names of individual head and worker pods.
+func ClusterTLSInfo(rc *dcv1alpha1.RayCluster) *clustertls.Info {
+	var dnsNames []string
+	for _, svc := range []string{InstanceObjectName(rc.Name, "client"), HeadlessHeadServiceName(rc.Name)} {
+		dnsNames = append(dnsNames,
+			svc,
+			fmt.Sprintf("%s.%s", svc, rc.Namespace),
+			fmt.Sprintf("%s.%s.svc", svc, rc.Namespace),
+		)
+	}
+	for _, svc := range []string{HeadlessHeadServiceName(rc.Name), HeadlessWorkerServiceName(rc.Name)} {
+		dnsNames = append(dnsNames, fmt.Sprintf("*.%s.%s.svc", svc, rc.Namespace))
+	}
+	// processes on the same node also connect to each other locally
+	dnsNames = append(dnsNames, "localhost")
+
+	return &clustertls.Info{
+		Namespace:        rc.Namespace,
+		Labels:           AddGlobalLabels(MetadataLabels(rc), rc.Spec.GlobalLabels),
+		CASecretName:     InstanceObjectName(rc.Name, Component("tls-ca")),
+		SecretName:       InstanceObjectName(rc.Name, Component("tls")),
+		ClientSecretName: TLSClientSecretName(rc.Name),
+		CommonName:       InstanceObjectName(rc.Name, ComponentNone),
+		DNSNames:         dnsNames,
+		IPAddresses:      []string{"127.0.0.1"},
+		ClientCommonName: TLSClientSecretName(rc.Name),
+		PodCertificates:  true,
+		Config:           rc.Spec.TLS,
+	}
+}
+
+// tlsEnv configures ray to use the mounted node certificate.
+func tlsEnv() []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{Name: "RAY_USE_TLS", Value: "1"},
+		{Name: "RAY_TLS_SERVER_CERT", Value: fmt.Sprintf("%s/%s", tlsMountPath, corev1.TLSCertKey)},
+		{Name: "RAY_TLS_SERVER_KEY", Value: fmt.Sprintf("%s/%s", tlsMountPath, corev1.TLSPrivateKeyKey)},
+		{Name: "RAY_TLS_CA_CERT", Value: fmt.Sprintf("%s/%s", tlsMountPath, clustertls.CAKey)},
+	}
+}
@@ -0,0 +1,28 @@
+package ray
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestClusterTLSInfo(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.TLS = &dcv1alpha1.TLSConfig{Enabled: true}
+
+	info := ClusterTLSInfo(rc)
+	assert.Equal(t, "fake-ns", info.Namespace)
+	assert.Equal(t, "test-id-ray-tls-ca", info.CASecretName)
+	assert.Equal(t, "test-id-ray-tls", info.SecretName)
+	assert.Equal(t, "test-id-ray-client-tls", info.ClientSecretName)
+	assert.Equal(t, "test-id-ray", info.CommonName)
+	assert.Contains(t, info.DNSNames, "test-id-ray-client.fake-ns.svc")
+	assert.Contains(t, info.DNSNames, "test-id-ray-head.fake-ns.svc")
+	assert.Contains(t, info.DNSNames, "*.test-id-ray-head.fake-ns.svc")
+	assert.Contains(t, info.DNSNames, "*.test-id-ray-worker.fake-ns.svc")
+	assert.Equal(t, []string{"127.0.0.1"}, info.IPAddresses)
+	assert.True(t, info.PodCertificates)
+	assert.Same(t, rc.Spec.TLS, info.Config)
+}
@@ -0,0 +1,41 @@
+package ray
+
+import (
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashboard"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+// DashboardInfo describes the Ingress or HTTPRoute that routes to the
+// dashboard port of the client service.
+func DashboardInfo(rc *dcv1alpha1.RayCluster) *dashboard.Info {
+	return &dashboard.Info{
+		Name:        InstanceObjectName(rc.Name, "dashboard"),
+		Namespace:   rc.Namespace,
+		Labels:      AddGlobalLabels(MetadataLabelsWithComponent(rc, ComponentHead), rc.Spec.GlobalLabels),
+		ClusterName: rc.Name,
+		ServiceName: InstanceObjectName(rc.Name, "client"),
+		ServicePort: dashboard.Port(rc.Spec.Dashboard, rc.Spec.DashboardPort),
+		Config:      rc.Spec.Dashboard,
+	}
+}
+
+// DashboardAuthInfo places the dashboard proxy in front of the head dashboard.
+func DashboardAuthInfo(rc *dcv1alpha1.RayCluster) *dashboard.AuthInfo {
+	return &dashboard.AuthInfo{
+		ClusterName:    rc.Name,
+		Namespace:      rc.Namespace,
+		Resource:       "rayclusters",
+		ConfigMapName:  InstanceObjectName(rc.Name, "dashboard-auth"),
+		Labels:         AddGlobalLabels(MetadataLabelsWithComponent(rc, ComponentHead), rc.Spec.GlobalLabels),
+		ServiceAccount: ServiceAccountName(rc),
+		UpstreamPort:   rc.Spec.DashboardPort,
+		Config:         rc.Spec.Dashboard,
+	}
+}
+
+// DashboardAuthEnabled returns true when the head dashboard is served behind
+// the dashboard proxy.
+func DashboardAuthEnabled(rc *dcv1alpha1.RayCluster) bool {
+	return util.BoolPtrIsTrue(rc.Spec.EnableDashboard) && rc.Spec.Dashboard.AuthEnabled()
+}
@@ -0,0 +1,18 @@
+package ray
+
+import (
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+)
+
+// EnvoyFilterInfo describes the EnvoyFilter that keeps idle client and GCS
+// connections of cluster pods open.
+func EnvoyFilterInfo(rc *dcv1alpha1.RayCluster) *istio.EnvoyFilterInfo {
+	return &istio.EnvoyFilterInfo{
+		Name:      InstanceObjectName(rc.Name, Component("envoyfilter")),
+		Namespace: rc.Namespace,
+		Labels:    AddGlobalLabels(MetadataLabels(rc), rc.Spec.GlobalLabels),
+		Selector:  SelectorLabels(rc),
+		Config:    istio.EnvoyFilterConfig(rc.Spec.IstioConfig),
+	}
+}
@@ -0,0 +1,25 @@
+package ray
+
+import (
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+)
+
+// TrafficInfo describes the Istio Sidecar and DestinationRules of the cluster.
+func TrafficInfo(rc *dcv1alpha1.RayCluster, istioMode istio.Mode) *istio.TrafficInfo {
+	return &istio.TrafficInfo{
+		Name:      InstanceObjectName(rc.Name, ComponentNone),
+		Namespace: rc.Namespace,
+		Labels:    AddGlobalLabels(MetadataLabels(rc), rc.Spec.GlobalLabels),
+		Selector:  SelectorLabels(rc),
+		Services: []string{
+			InstanceObjectName(rc.Name, "client"),
+			HeadlessHeadServiceName(rc.Name),
+			HeadlessWorkerServiceName(rc.Name),
+			components.ClientPortsServiceName(rc, Meta),
+		},
+		Mode:   istioMode,
+		Config: rc.Spec.Istio,
+	}
+}
@@ -0,0 +1,25 @@
+package ray
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+)
+
+func TestTrafficInfo(t *testing.T) {
+	rc := rayClusterFixture()
+
+	info := TrafficInfo(rc, istio.ModeSidecar)
+	assert.Equal(t, "test-id-ray", info.Name)
+	assert.Equal(t, "fake-ns", info.Namespace)
+	assert.Equal(t, SelectorLabels(rc), info.Selector)
+	assert.Equal(t, []string{
+		"test-id-ray-client",
+		"test-id-ray-head",
+		"test-id-ray-worker",
+		"test-id-ray-proxy",
+	}, info.Services)
+	assert.Equal(t, istio.ModeSidecar, info.Mode)
+}
@@ -0,0 +1,57 @@
+package ray
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/kerberos"
+)
+
+const keytabVolumeName = "kerberos-keytab"
+
+// KeytabSecretName returns the name of the secret holding an inline keytab.
+func KeytabSecretName(name string) string {
+	return InstanceObjectName(name, Component("keytab"))
+}
+
+// KeytabInfo describes the secret holding the keytab contents embedded in
+// the cluster spec. Earlier versions kept them in a configmap of the same name.
+func KeytabInfo(rc *dcv1alpha1.RayCluster) *kerberos.KeytabInfo {
+	return &kerberos.KeytabInfo{
+		SecretName:    KeytabSecretName(rc.Name),
+		ConfigMapName: KeytabSecretName(rc.Name),
+		Namespace:     rc.Namespace,
+		Labels:        AddGlobalLabels(MetadataLabels(rc), rc.Spec.GlobalLabels),
+		Config:        rc.Spec.KerberosKeytab,
+	}
+}
+
+// keytabVolume returns the volume and mount that provide the keytab file
+// inside the MountPath directory.
+func keytabVolume(rc *dcv1alpha1.RayCluster) (corev1.Volume, corev1.VolumeMount) {
+	volume := kerberos.NewKeytabVolume(keytabVolumeName, rc.Spec.KerberosKeytab, KeytabSecretName(rc.Name))
+	mount := corev1.VolumeMount{
+		Name:      keytabVolumeName,
+		ReadOnly:  true,
+		MountPath: rc.Spec.KerberosKeytab.MountPath,
+	}
+
+	return volume, mount
+}
+
+// KerberosConfigMapName returns the name of the configmap holding krb5.conf.
+func KerberosConfigMapName(name string) string {
+	return InstanceObjectName(name, Component("kerberos"))
+}
+
+// KerberosInfo describes the kerberos ticket renewal sidecar of cluster pods.
+func KerberosInfo(rc *dcv1alpha1.RayCluster) *kerberos.Info {
+	return &kerberos.Info{
+		ConfigMapName: KerberosConfigMapName(rc.Name),
+		Namespace:     rc.Namespace,
+		Labels:        AddGlobalLabels(MetadataLabels(rc), rc.Spec.GlobalLabels),
+		Image:         rc.Spec.Image,
+		KeytabVolume:  keytabVolumeName,
+		Config:        rc.Spec.Kerberos,
+	}
+}
@@ -8,12 +8,17 @@ import (
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/resources"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/clientaccess"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashboard"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
 )
 
 const (
 	descriptionCluster   = "Allows all ingress traffic between cluster nodes"
 	descriptionClient    = "Allows client ingress traffic to head client server port"
 	descriptionDashboard = "Allows client ingress traffic to head dashboard port"
+	descriptionSync      = "Allows client ingress traffic to worker file sync port"
+	descriptionMetrics   = "Allows Prometheus ingress traffic to node metrics ports"
 )
 
 // NewClusterNetworkPolicy generates a network policy that allows all nodes
@@ -54,28 +59,94 @@ func NewClusterNetworkPolicy(rc *dcv1alpha1.RayCluster) *networkingv1.NetworkPol
 // access to any pods that have been appointed with the configured client
 // server labels.
 func NewHeadClientNetworkPolicy(rc *dcv1alpha1.RayCluster) *networkingv1.NetworkPolicy {
-	return headNetworkPolicy(
+	netpol := headNetworkPolicy(
 		rc,
 		rc.Spec.ClientServerPort,
 		rc.Spec.NetworkPolicy.ClientLabels,
-		map[string]string{}, // No NamespaceSelectors
+		clientNamespaceLabels(rc),
 		Component("client"),
 		descriptionClient,
 	)
+	netpol.Spec.Ingress[0].From = append(netpol.Spec.Ingress[0].From, clientaccess.IPBlockPeers(rc.Spec.NetworkPolicy)...)
+
+	return netpol
+}
+
+// ClientPeers returns the peers granted ingress access to the head client
+// server port.
+func ClientPeers(rc *dcv1alpha1.RayCluster) []networkingv1.NetworkPolicyPeer {
+	return NewHeadClientNetworkPolicy(rc).Spec.Ingress[0].From
+}
+
+// clientNamespaceLabels returns the namespace selector clause of client pods.
+// Client pods in every namespace are selected when no labels are configured.
+func clientNamespaceLabels(rc *dcv1alpha1.RayCluster) map[string]string {
+	if rc.Spec.NetworkPolicy.ClientNamespaceLabels != nil {
+		return rc.Spec.NetworkPolicy.ClientNamespaceLabels
+	}
+
+	return map[string]string{}
 }
 
 // NewHeadDashboardNetworkPolicy generates a network policy that allows
 // dashboard access to any pods that have been appointed with configured
-// dashboard labels.
+// dashboard labels, and to the ingress controller when the dashboard is exposed.
+// Access is granted to the dashboard proxy port when authentication is enabled.
 func NewHeadDashboardNetworkPolicy(rc *dcv1alpha1.RayCluster) *networkingv1.NetworkPolicy {
-	return headNetworkPolicy(
+	port := dashboard.Port(rc.Spec.Dashboard, rc.Spec.DashboardPort)
+	netpol := headNetworkPolicy(
 		rc,
-		rc.Spec.DashboardPort,
+		port,
 		rc.Spec.NetworkPolicy.DashboardLabels,
 		rc.Spec.NetworkPolicy.DashboardNamespaceLabels,
 		Component("dashboard"),
 		descriptionDashboard,
 	)
+	netpol.Spec.Ingress = append(netpol.Spec.Ingress, dashboard.IngressRules(rc.Spec.Dashboard, port)...)
+
+	return netpol
+}
+
+// NewWorkerSyncNetworkPolicy generates a network policy that allows client
+// access to the file sync sidecar running on worker pods.
+func NewWorkerSyncNetworkPolicy(rc *dcv1alpha1.RayCluster) *networkingv1.NetworkPolicy {
+	proto := corev1.ProtocolTCP
+
+	var ingress []networkingv1.NetworkPolicyIngressRule
+	if SyncEnabled(rc) {
+		targetPort := intstr.FromInt(int(rc.Spec.Sync.Port))
+		ingress = []networkingv1.NetworkPolicyIngressRule{
+			{
+				Ports: []networkingv1.NetworkPolicyPort{
+					{
+						Protocol: &proto,
+						Port:     &targetPort,
+					},
+				},
+				From: clientaccess.Peers(rc.Spec.NetworkPolicy),
+			},
+		}
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      InstanceObjectName(rc.Name, Component("sync")),
+			Namespace: rc.Namespace,
+			Labels:    AddGlobalLabels(MetadataLabelsWithComponent(rc, ComponentWorker), rc.Spec.GlobalLabels),
+			Annotations: map[string]string{
+				resources.DescriptionAnnotationKey: descriptionSync,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: SelectorLabelsWithComponent(rc, ComponentWorker),
+			},
+			Ingress: ingress,
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+			},
+		},
+	}
 }
 
 func headNetworkPolicy(
@@ -128,3 +199,27 @@ func headNetworkPolicy(
 		},
 	}
 }
+
+// NewMetricsNetworkPolicy generates a network policy that allows Prometheus
+// to scrape the metrics ports of all cluster nodes.
+func NewMetricsNetworkPolicy(rc *dcv1alpha1.RayCluster) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      InstanceObjectName(rc.Name, Component("metrics")),
+			Namespace: rc.Namespace,
+			Labels:    AddGlobalLabels(MetadataLabels(rc), rc.Spec.GlobalLabels),
+			Annotations: map[string]string{
+				resources.DescriptionAnnotationKey: descriptionMetrics,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: SelectorLabels(rc),
+			},
+			Ingress: monitoring.IngressRules(rc.Spec.Monitoring, MetricsPorts(rc)...),
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+			},
+		},
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -191,3 +192,155 @@ func TestNewHeadDashboardNetworkPolicy(t *testing.T) {
 	}
 	assert.Equal(t, expected, netpol)
 }
+
+func TestNewWorkerSyncNetworkPolicy(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.NetworkPolicy = dcv1alpha1.NetworkPolicyConfig{
+		ClientLabels: map[string]string{
+			"ray-client": "true",
+		},
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		netpol := NewWorkerSyncNetworkPolicy(rc)
+
+		assert.Equal(t, "test-id-ray-sync", netpol.Name)
+		assert.Empty(t, netpol.Spec.Ingress)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		rc.Spec.Sync = &dcv1alpha1.SyncConfig{Enabled: true, Port: 2223, MountPath: "/mnt/sync"}
+		netpol := NewWorkerSyncNetworkPolicy(rc)
+
+		tcpProto := v1.ProtocolTCP
+		syncPort := intstr.FromInt(2223)
+		expected := networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":      "ray",
+					"app.kubernetes.io/instance":  "test-id",
+					"app.kubernetes.io/component": "worker",
+				},
+			},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					Ports: []networkingv1.NetworkPolicyPort{
+						{
+							Protocol: &tcpProto,
+							Port:     &syncPort,
+						},
+					},
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"ray-client": "true",
+								},
+							},
+						},
+					},
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				"Ingress",
+			},
+		}
+		assert.Equal(t, expected, netpol.Spec)
+	})
+}
+
+func TestNewMetricsNetworkPolicy(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.MetricsExportPort = 8080
+
+	t.Run("disabled", func(t *testing.T) {
+		netpol := NewMetricsNetworkPolicy(rc)
+
+		assert.Equal(t, "test-id-ray-metrics", netpol.Name)
+		assert.Empty(t, netpol.Spec.Ingress)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		rc.Spec.Monitoring = &dcv1alpha1.MonitoringConfig{
+			Enabled:          true,
+			PrometheusLabels: map[string]string{"app": "prometheus"},
+		}
+		netpol := NewMetricsNetworkPolicy(rc)
+
+		tcpProto := v1.ProtocolTCP
+		metricsPort := intstr.FromInt(8080)
+		expected := networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":     "ray",
+					"app.kubernetes.io/instance": "test-id",
+				},
+			},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					Ports: []networkingv1.NetworkPolicyPort{
+						{
+							Protocol: &tcpProto,
+							Port:     &metricsPort,
+						},
+					},
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"app": "prometheus",
+								},
+							},
+						},
+					},
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				"Ingress",
+			},
+		}
+		assert.Equal(t, expected, netpol.Spec)
+	})
+}
+
+func TestNewHeadDashboardNetworkPolicyExposed(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.Dashboard = &dcv1alpha1.DashboardConfig{
+		Expose: &dcv1alpha1.DashboardExposeConfig{
+			Enabled:                   true,
+			ControllerLabels:          map[string]string{"app.kubernetes.io/name": "ingress-nginx"},
+			ControllerNamespaceLabels: map[string]string{"kubernetes.io/metadata.name": "ingress-nginx"},
+		},
+	}
+	netpol := NewHeadDashboardNetworkPolicy(rc)
+
+	require.Len(t, netpol.Spec.Ingress, 2)
+	rule := netpol.Spec.Ingress[1]
+	assert.Equal(t, map[string]string{"app.kubernetes.io/name": "ingress-nginx"}, rule.From[0].PodSelector.MatchLabels)
+	assert.Equal(t, map[string]string{"kubernetes.io/metadata.name": "ingress-nginx"}, rule.From[0].NamespaceSelector.MatchLabels)
+	assert.Equal(t, int(rc.Spec.DashboardPort), rule.Ports[0].Port.IntValue())
+}
+
+func TestNewHeadDashboardNetworkPolicyAuth(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.Dashboard = &dcv1alpha1.DashboardConfig{
+		Auth: &dcv1alpha1.DashboardAuthConfig{Enabled: true, Port: 4180},
+	}
+	netpol := NewHeadDashboardNetworkPolicy(rc)
+
+	require.Len(t, netpol.Spec.Ingress, 1)
+	require.Len(t, netpol.Spec.Ingress[0].Ports, 1)
+	assert.Equal(t, 4180, netpol.Spec.Ingress[0].Ports[0].Port.IntValue())
+}
+
+func TestNewHeadClientNetworkPolicyClientPeers(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.NetworkPolicy.ClientNamespaceLabels = map[string]string{"team": "data"}
+	rc.Spec.NetworkPolicy.ClientIPBlocks = []networkingv1.IPBlock{{CIDR: "10.0.0.0/8"}}
+
+	from := NewHeadClientNetworkPolicy(rc).Spec.Ingress[0].From
+	require.Len(t, from, 2)
+	assert.Equal(t, map[string]string{"team": "data"}, from[0].NamespaceSelector.MatchLabels)
+	assert.Equal(t, &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}, from[1].IPBlock)
+	assert.Equal(t, from, ClientPeers(rc))
+}
@@ -0,0 +1,23 @@
+package ray
+
+import (
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
+)
+
+// PodGroupName returns the name of the PodGroup shared by all cluster pods.
+func PodGroupName(name string) string {
+	return InstanceObjectName(name, ComponentNone)
+}
+
+// PodGroupInfo describes a PodGroup that gang schedules the head with the
+// minimum number of workers.
+func PodGroupInfo(rc *dcv1alpha1.RayCluster) *podgroup.Info {
+	return &podgroup.Info{
+		Name:      PodGroupName(rc.Name),
+		Namespace: rc.Namespace,
+		Labels:    AddGlobalLabels(MetadataLabels(rc), rc.Spec.GlobalLabels),
+		MinMember: podgroup.MinMember(1, rc.Spec.Worker.Replicas, rc.Spec.Autoscaling),
+		Config:    rc.Spec.GangScheduling,
+	}
+}
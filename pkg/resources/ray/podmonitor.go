@@ -0,0 +1,35 @@
+package ray
+
+import (
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
+)
+
+// PodMonitorName returns the name of the PodMonitor that scrapes all cluster pods.
+func PodMonitorName(name string) string {
+	return InstanceObjectName(name, ComponentNone)
+}
+
+// MetricsPorts returns the ports on which cluster nodes export metrics, which
+// are only opened when monitoring is enabled.
+func MetricsPorts(rc *dcv1alpha1.RayCluster) []int32 {
+	if !monitoring.Enabled(rc.Spec.Monitoring) {
+		return nil
+	}
+	return []int32{rc.Spec.MetricsExportPort}
+}
+
+// PodMonitorInfo describes a PodMonitor that scrapes the metrics exported by
+// the head and every worker.
+func PodMonitorInfo(rc *dcv1alpha1.RayCluster) *monitoring.Info {
+	return &monitoring.Info{
+		Name:      PodMonitorName(rc.Name),
+		Namespace: rc.Namespace,
+		Labels:    AddGlobalLabels(MetadataLabels(rc), rc.Spec.GlobalLabels),
+		Selector:  SelectorLabels(rc),
+		Endpoints: []monitoring.Endpoint{
+			{Port: metricsPortName, Path: "/metrics"},
+		},
+		Config: rc.Spec.Monitoring,
+	}
+}
@@ -8,11 +8,15 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashboard"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
 )
 
 // NewClientService creates a ClusterIP service that points to the head
 // node that exposes the client server port, and dashboard port when enabled.
+// The dashboard proxy port replaces the dashboard port when authentication is
+// enabled.
 func NewClientService(rc *dcv1alpha1.RayCluster) *corev1.Service {
 	ports := []corev1.ServicePort{
 		{
@@ -23,10 +27,11 @@ func NewClientService(rc *dcv1alpha1.RayCluster) *corev1.Service {
 	}
 
 	if util.BoolPtrIsTrue(rc.Spec.EnableDashboard) {
+		port := dashboard.Port(rc.Spec.Dashboard, rc.Spec.DashboardPort)
 		ports = append(ports, corev1.ServicePort{
 			Name:       "tcp-dashboard",
-			Port:       rc.Spec.DashboardPort,
-			TargetPort: intstr.FromInt(int(rc.Spec.DashboardPort)),
+			Port:       port,
+			TargetPort: intstr.FromInt(int(port)),
 		})
 	}
 
@@ -84,6 +89,15 @@ func NewHeadlessHeadService(rc *dcv1alpha1.RayCluster) *corev1.Service {
 // NewHeadlessWorkerService creates a headless service that points to the
 // worker nodes and exposes cluster communication ports.
 func NewHeadlessWorkerService(rc *dcv1alpha1.RayCluster) *corev1.Service {
+	ports := workerPorts(rc)
+	if SyncEnabled(rc) {
+		ports = append(ports, corev1.ServicePort{
+			Name:       filesync.PortName,
+			Port:       rc.Spec.Sync.Port,
+			TargetPort: intstr.FromString(filesync.PortName),
+		})
+	}
+
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      HeadlessWorkerServiceName(rc.Name),
@@ -91,7 +105,7 @@ func NewHeadlessWorkerService(rc *dcv1alpha1.RayCluster) *corev1.Service {
 			Labels:    AddGlobalLabels(MetadataLabelsWithComponent(rc, ComponentWorker), rc.Spec.GlobalLabels),
 		},
 		Spec: corev1.ServiceSpec{
-			Ports:     workerPorts(rc),
+			Ports:     ports,
 			Selector:  SelectorLabelsWithComponent(rc, ComponentWorker),
 			ClusterIP: corev1.ClusterIPNone,
 		},
@@ -8,6 +8,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 )
 
 func TestNewClientService(t *testing.T) {
@@ -55,6 +57,21 @@ func TestNewClientService(t *testing.T) {
 
 		assert.Equal(t, expected, svc)
 	})
+
+	t.Run("with_dashboard_auth", func(t *testing.T) {
+		rc.Spec.Dashboard = &dcv1alpha1.DashboardConfig{
+			Auth: &dcv1alpha1.DashboardAuthConfig{Enabled: true, Port: 4180},
+		}
+		svc := NewClientService(rc)
+
+		expected.Spec.Ports[1] = corev1.ServicePort{
+			Name:       "tcp-dashboard",
+			Port:       4180,
+			TargetPort: intstr.FromInt(4180),
+		}
+
+		assert.Equal(t, expected, svc)
+	})
 }
 
 func TestNewHeadlessHeadService(t *testing.T) {
@@ -175,4 +192,19 @@ func TestNewHeadlessWorkerService(t *testing.T) {
 		},
 	}
 	assert.Equal(t, expected, svc)
+
+	t.Run("sync_enabled", func(t *testing.T) {
+		rc.Spec.Sync = &dcv1alpha1.SyncConfig{Enabled: true, Port: 2223, MountPath: "/mnt/sync"}
+
+		actual := NewHeadlessWorkerService(rc)
+		assert.Contains(t, actual.Spec.Ports, corev1.ServicePort{
+			Name:       "tcp-rsync",
+			Port:       2223,
+			TargetPort: intstr.FromString("tcp-rsync"),
+		})
+
+		for _, port := range NewHeadlessHeadService(rc).Spec.Ports {
+			assert.NotEqual(t, "tcp-rsync", port.Name)
+		}
+	})
 }
@@ -19,3 +19,13 @@ func NewServiceAccount(rc *dcv1alpha1.RayCluster) *corev1.ServiceAccount {
 		AutomountServiceAccountToken: pointer.Bool(false),
 	}
 }
+
+// ServiceAccountName returns the name of the service account used by cluster
+// pods.
+func ServiceAccountName(rc *dcv1alpha1.RayCluster) string {
+	if rc.Spec.ServiceAccount.Name != "" {
+		return rc.Spec.ServiceAccount.Name
+	}
+
+	return InstanceObjectName(rc.Name, ComponentNone)
+}
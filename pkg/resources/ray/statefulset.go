@@ -11,6 +11,15 @@ import (
 	"k8s.io/utils/pointer"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/clustertls"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashboard"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/kerberos"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podsecurity"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/workload"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
 )
 
@@ -55,10 +64,16 @@ var (
 const (
 	sharedMemoryVolumeName                    = "dshm"
 	istioSidecarIncludeInboundPortsAnnotation = "traffic.sidecar.istio.io/includeInboundPorts"
+	metricsPortName                           = "metrics"
 )
 
-func NewStatefulSet(rc *dcv1alpha1.RayCluster, comp Component, istioEnabled bool) (*appsv1.StatefulSet, error) {
-	p, err := newConfigProcessor(rc, comp, istioEnabled)
+func NewStatefulSet(
+	rc *dcv1alpha1.RayCluster,
+	comp Component,
+	istioMode istio.Mode,
+	syncImage string,
+) (*appsv1.StatefulSet, error) {
+	p, err := newConfigProcessor(rc, comp, istioMode.Sidecar())
 	if err != nil {
 		return nil, err
 	}
@@ -68,10 +83,7 @@ func NewStatefulSet(rc *dcv1alpha1.RayCluster, comp Component, istioEnabled bool
 		return nil, err
 	}
 
-	serviceAccountName := InstanceObjectName(rc.Name, ComponentNone)
-	if rc.Spec.ServiceAccount.Name != "" {
-		serviceAccountName = rc.Spec.ServiceAccount.Name
-	}
+	serviceAccountName := ServiceAccountName(rc)
 
 	replicas := p.replicas()
 	nodeAttrs := p.nodeAttributes()
@@ -88,6 +100,74 @@ func NewStatefulSet(rc *dcv1alpha1.RayCluster, comp Component, istioEnabled bool
 	volumeMounts = append(volumeMounts, nodeAttrs.VolumeMounts...)
 	pvcTemplates := processPVCTemplates(rc, nodeAttrs.VolumeClaimTemplates)
 
+	var sidecars []corev1.Container
+	if comp == ComponentWorker && SyncEnabled(rc) {
+		if syncImage == "" {
+			return nil, fmt.Errorf("sync sidecar image is not provided")
+		}
+
+		sharedVolume, sharedMount := filesync.NewSharedVolume(rc.Spec.Sync.MountPath)
+		volumes = append(volumes, sharedVolume, filesync.NewAuthVolume(SyncSecretName(rc.Name)))
+		volumeMounts = append(volumeMounts, sharedMount)
+
+		sidecars = append(sidecars, filesync.NewSidecar(&filesync.SidecarInfo{
+			Name:         SyncContainerName,
+			Image:        syncImage,
+			PullPolicy:   rc.Spec.Image.PullPolicy,
+			Port:         rc.Spec.Sync.Port,
+			SecretName:   SyncSecretName(rc.Name),
+			Modules:      filesync.Modules(rc.Spec.Sync),
+			VolumeMounts: append([]corev1.VolumeMount{sharedMount}, nodeAttrs.VolumeMounts...),
+		}))
+	}
+
+	if rc.Spec.KerberosKeytab != nil {
+		volume, mount := keytabVolume(rc)
+		volumes = append(volumes, volume)
+		volumeMounts = append(volumeMounts, mount)
+	}
+
+	initContainers := nodeAttrs.InitContainers
+	if kerberos.Enabled(rc.Spec.Kerberos) {
+		info := KerberosInfo(rc)
+		initContainer, err := kerberos.InitContainer(info)
+		if err != nil {
+			return nil, err
+		}
+		sidecar, err := kerberos.Sidecar(info)
+		if err != nil {
+			return nil, err
+		}
+
+		envVars = append(envVars, kerberos.Env()...)
+		volumes = append(volumes, kerberos.Volumes(info)...)
+		volumeMounts = append(volumeMounts, kerberos.VolumeMounts()...)
+		initContainers = append(append([]corev1.Container{}, initContainers...), initContainer)
+		sidecars = append(sidecars, sidecar)
+	}
+
+	if TLSEnabled(rc) {
+		info := ClusterTLSInfo(rc)
+		initContainer := clustertls.PodCertificateInitContainer(info, imageRef, rc.Spec.Image.PullPolicy)
+		initContainer.SecurityContext = securityContext(rc, comp)
+
+		envVars = append(envVars, tlsEnv()...)
+		volumes = append(volumes, clustertls.PodCertificateVolumes(info)...)
+		volumeMounts = append(volumeMounts, clustertls.NewVolumeMount(tlsMountPath))
+		initContainers = append(append([]corev1.Container{}, initContainers...), initContainer)
+	}
+
+	if comp == ComponentHead && DashboardAuthEnabled(rc) {
+		info := DashboardAuthInfo(rc)
+		sidecar, err := dashboard.AuthSidecar(info)
+		if err != nil {
+			return nil, err
+		}
+
+		volumes = append(volumes, dashboard.AuthVolumes(info)...)
+		sidecars = append(sidecars, sidecar)
+	}
+
 	sts := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      InstanceObjectName(rc.Name, comp),
@@ -96,13 +176,13 @@ func NewStatefulSet(rc *dcv1alpha1.RayCluster, comp Component, istioEnabled bool
 		},
 		Spec: appsv1.StatefulSetSpec{
 			ServiceName: serviceName,
-			Replicas:    pointer.Int32(replicas),
+			Replicas:    workload.Replicas(&rc.Status, pointer.Int32(replicas)),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: SelectorLabelsWithComponent(rc, comp),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      labels,
+					Labels:      util.MergeStringMaps(istio.PodLabels(istioMode), p.processLabels()),
 					Annotations: annotations,
 				},
 
@@ -111,10 +191,10 @@ func NewStatefulSet(rc *dcv1alpha1.RayCluster, comp Component, istioEnabled bool
 					NodeSelector:       nodeAttrs.NodeSelector,
 					Affinity:           nodeAttrs.Affinity,
 					Tolerations:        nodeAttrs.Tolerations,
-					InitContainers:     nodeAttrs.InitContainers,
+					InitContainers:     initContainers,
 					ImagePullSecrets:   rc.Spec.ImagePullSecrets,
 					SecurityContext:    rc.Spec.PodSecurityContext,
-					Containers: []corev1.Container{
+					Containers: append([]corev1.Container{
 						{
 							Name:            ApplicationName,
 							Command:         defaultCmd,
@@ -141,7 +221,7 @@ func NewStatefulSet(rc *dcv1alpha1.RayCluster, comp Component, istioEnabled bool
 							},
 							SecurityContext: securityContext(rc, comp),
 						},
-					},
+					}, sidecars...),
 					Volumes: volumes,
 				},
 			},
@@ -152,6 +232,8 @@ func NewStatefulSet(rc *dcv1alpha1.RayCluster, comp Component, istioEnabled bool
 			},
 		},
 	}
+	podsecurity.Harden(rc.Spec.SecurityProfile, &sts.Spec.Template.Spec)
+	podgroup.ConfigurePodTemplate(rc.Spec.GangScheduling, PodGroupName(rc.Name), &sts.Spec.Template)
 
 	return sts, nil
 }
@@ -177,20 +259,20 @@ func securityContext(rc *dcv1alpha1.RayCluster, comp Component) *corev1.Security
 	}
 }
 
-func newConfigProcessor(rc *dcv1alpha1.RayCluster, comp Component, istio bool) (configProcessor, error) {
+func newConfigProcessor(rc *dcv1alpha1.RayCluster, comp Component, istioSidecar bool) (configProcessor, error) {
 	switch comp {
 	case ComponentHead:
-		return &headProcessor{rc: rc, istio: istio}, nil
+		return &headProcessor{rc: rc, istioSidecar: istioSidecar}, nil
 	case ComponentWorker:
-		return &workerProcessor{rc: rc, istio: istio}, nil
+		return &workerProcessor{rc: rc, istioSidecar: istioSidecar}, nil
 	default:
 		return nil, fmt.Errorf("invalid ray component: %q", comp)
 	}
 }
 
 type headProcessor struct {
-	rc    *dcv1alpha1.RayCluster
-	istio bool
+	rc           *dcv1alpha1.RayCluster
+	istioSidecar bool
 }
 
 func (p *headProcessor) replicas() int32 {
@@ -214,7 +296,7 @@ func (p *headProcessor) processArgs() []string {
 	if util.BoolPtrIsTrue(rc.Spec.EnableDashboard) {
 		dashArgs := []string{
 			"--include-dashboard=true",
-			"--dashboard-host=0.0.0.0",
+			"--dashboard-host=" + dashboardHost(rc),
 			fmt.Sprintf("--dashboard-port=%d", rc.Spec.DashboardPort),
 		}
 		headArgs = append(headArgs, dashArgs...)
@@ -223,6 +305,16 @@ func (p *headProcessor) processArgs() []string {
 	return append(processArgs(rc), headArgs...)
 }
 
+// dashboardHost returns the interface the head dashboard listens on; only the
+// auth proxy can reach the dashboard when dashboard auth is enabled.
+func dashboardHost(rc *dcv1alpha1.RayCluster) string {
+	if rc.Spec.Dashboard.AuthEnabled() {
+		return "127.0.0.1"
+	}
+
+	return "0.0.0.0"
+}
+
 func (p *headProcessor) processPorts() []corev1.ContainerPort {
 	rc := p.rc
 
@@ -268,7 +360,7 @@ func (p *headProcessor) processPorts() []corev1.ContainerPort {
 		})
 	}
 
-	return ports
+	return append(ports, processMetricsPorts(rc)...)
 }
 
 func (p *headProcessor) processLabels() map[string]string {
@@ -277,7 +369,7 @@ func (p *headProcessor) processLabels() map[string]string {
 
 func (p *headProcessor) processAnnotations() map[string]string {
 	spec := p.rc.Spec
-	if !p.istio {
+	if !p.istioSidecar {
 		return spec.Head.Annotations
 	}
 
@@ -291,6 +383,10 @@ func (p *headProcessor) processAnnotations() map[string]string {
 	}
 	listeners = append(listeners, spec.RedisShardPorts...)
 	listeners = append(listeners, spec.WorkerPorts...)
+	listeners = append(listeners, MetricsPorts(p.rc)...)
+	if DashboardAuthEnabled(p.rc) {
+		listeners = append(listeners, spec.Dashboard.Auth.Port)
+	}
 
 	return util.MergeStringMaps(spec.Head.Annotations, map[string]string{
 		istioSidecarIncludeInboundPortsAnnotation: strings.Join(util.IntsToStrings(listeners), ","),
@@ -302,8 +398,8 @@ func (p *headProcessor) processServiceName() string {
 }
 
 type workerProcessor struct {
-	rc    *dcv1alpha1.RayCluster
-	istio bool
+	rc           *dcv1alpha1.RayCluster
+	istioSidecar bool
 }
 
 func (p *workerProcessor) replicas() int32 {
@@ -342,7 +438,7 @@ func (p *workerProcessor) processPorts() []corev1.ContainerPort {
 		})
 	}
 
-	return ports
+	return append(ports, processMetricsPorts(p.rc)...)
 }
 
 func (p *workerProcessor) processLabels() map[string]string {
@@ -351,7 +447,7 @@ func (p *workerProcessor) processLabels() map[string]string {
 
 func (p *workerProcessor) processAnnotations() map[string]string {
 	spec := p.rc.Spec
-	if !p.istio {
+	if !p.istioSidecar {
 		return spec.Worker.Annotations
 	}
 
@@ -360,6 +456,10 @@ func (p *workerProcessor) processAnnotations() map[string]string {
 		spec.NodeManagerPort,
 	}
 	listeners = append(listeners, spec.WorkerPorts...)
+	listeners = append(listeners, MetricsPorts(p.rc)...)
+	if DashboardAuthEnabled(p.rc) {
+		listeners = append(listeners, spec.Dashboard.Auth.Port)
+	}
 
 	return util.MergeStringMaps(spec.Worker.Annotations, map[string]string{
 		istioSidecarIncludeInboundPortsAnnotation: strings.Join(util.IntsToStrings(listeners), ","),
@@ -389,9 +489,26 @@ func processArgs(rc *dcv1alpha1.RayCluster) []string {
 		args = append(args, fmt.Sprintf("--object-store-memory=%d", *rc.Spec.ObjectStoreMemoryBytes))
 	}
 
+	if monitoring.Enabled(rc.Spec.Monitoring) {
+		args = append(args, fmt.Sprintf("--metrics-export-port=%d", rc.Spec.MetricsExportPort))
+	}
+
 	return args
 }
 
+// common head/worker metrics ports
+func processMetricsPorts(rc *dcv1alpha1.RayCluster) []corev1.ContainerPort {
+	var ports []corev1.ContainerPort
+	for _, port := range MetricsPorts(rc) {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          metricsPortName,
+			ContainerPort: port,
+		})
+	}
+
+	return ports
+}
+
 // common head/worker labels
 func processLabels(rc *dcv1alpha1.RayCluster, comp Component, extraLabels map[string]string) map[string]string {
 	labels := MetadataLabelsWithComponent(rc, comp)
@@ -13,12 +13,14 @@ import (
 	"k8s.io/utils/pointer"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/kerberos"
 )
 
 func TestNewStatefulSet(t *testing.T) {
 	t.Run("invalid_component", func(t *testing.T) {
 		rc := rayClusterFixture()
-		_, err := NewStatefulSet(rc, Component("garbage"), false)
+		_, err := NewStatefulSet(rc, Component("garbage"), "", "")
 		assert.Error(t, err)
 	})
 
@@ -27,7 +29,7 @@ func TestNewStatefulSet(t *testing.T) {
 
 		t.Run("default_values", func(t *testing.T) {
 			rc := rayClusterFixture()
-			actual, err := NewStatefulSet(rc, ComponentHead, false)
+			actual, err := NewStatefulSet(rc, ComponentHead, "", "")
 			require.NoError(t, err)
 
 			expected := &appsv1.StatefulSet{
@@ -188,7 +190,7 @@ func TestNewStatefulSet(t *testing.T) {
 			rc.Spec.EnableDashboard = pointer.Bool(true)
 			rc.Spec.DashboardPort = 8265
 
-			actual, err := NewStatefulSet(rc, ComponentHead, false)
+			actual, err := NewStatefulSet(rc, ComponentHead, "", "")
 			require.NoError(t, err)
 
 			expected := []string{
@@ -205,7 +207,7 @@ func TestNewStatefulSet(t *testing.T) {
 
 		t.Run("default_values", func(t *testing.T) {
 			rc := rayClusterFixture()
-			actual, err := NewStatefulSet(rc, ComponentWorker, false)
+			actual, err := NewStatefulSet(rc, ComponentWorker, "", "")
 			require.NoError(t, err)
 
 			expected := &appsv1.StatefulSet{
@@ -346,7 +348,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 		rc := rayClusterFixture()
 		rc.Spec.Image = &dcv1alpha1.OCIImageDefinition{}
 
-		_, err := NewStatefulSet(rc, comp, false)
+		_, err := NewStatefulSet(rc, comp, "", "")
 		assert.Error(t, err)
 	})
 
@@ -354,7 +356,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 		rc := rayClusterFixture()
 		rc.Spec.ObjectStoreMemoryBytes = pointer.Int64(100 * 1 << 20)
 
-		actual, err := NewStatefulSet(rc, comp, false)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Contains(t, actual.Spec.Template.Spec.Containers[0].Args, "--object-store-memory=104857600")
@@ -373,7 +375,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Labels = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp, false)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		for _, labels := range []map[string]string{actual.Labels, actual.Spec.Template.Labels} {
@@ -397,7 +399,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Annotations = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp, false)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Annotations)
@@ -418,12 +420,23 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			expected["traffic.sidecar.istio.io/includeInboundPorts"] = "2384,2385,11000,11001"
 		}
 
-		actual, err := NewStatefulSet(rc, comp, true)
+		actual, err := NewStatefulSet(rc, comp, istio.ModeSidecar, "")
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Annotations)
 	})
 
+	t.Run("istio_ambient", func(t *testing.T) {
+		rc := rayClusterFixture()
+
+		actual, err := NewStatefulSet(rc, comp, istio.ModeAmbient, "")
+		require.NoError(t, err)
+
+		assert.NotContains(t, actual.Spec.Template.Annotations, istioSidecarIncludeInboundPortsAnnotation)
+		assert.Equal(t, "ambient", actual.Spec.Template.Labels["istio.io/dataplane-mode"])
+		assert.NotContains(t, actual.Spec.Selector.MatchLabels, "istio.io/dataplane-mode")
+	})
+
 	t.Run("volumes_and_mounts", func(t *testing.T) {
 		rc := rayClusterFixture()
 
@@ -446,7 +459,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.VolumeMounts = expectedVolMounts
 		}
 
-		actual, err := NewStatefulSet(rc, comp, false)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Subset(t, actual.Spec.Template.Spec.Volumes, expectedVols)
@@ -471,7 +484,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.VolumeClaimTemplates = input
 		}
 
-		actual, err := NewStatefulSet(rc, comp, false)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		expected := []corev1.PersistentVolumeClaim{
@@ -505,7 +518,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Resources = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp, false)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.Containers[0].Resources)
@@ -524,7 +537,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.NodeSelector = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp, false)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.NodeSelector)
@@ -563,7 +576,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Affinity = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp, false)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.Affinity)
@@ -587,7 +600,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Tolerations = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp, false)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.Tolerations)
@@ -608,7 +621,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.InitContainers = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp, false)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.InitContainers)
@@ -623,7 +636,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			},
 		}
 
-		actual, err := NewStatefulSet(rc, comp, false)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Subset(t, actual.Spec.Template.Spec.Containers[0].Env, rc.Spec.EnvVars)
@@ -635,19 +648,275 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			RunAsUser: pointer.Int64(0),
 		}
 
-		actual, err := NewStatefulSet(rc, comp, false)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, rc.Spec.PodSecurityContext, actual.Spec.Template.Spec.SecurityContext)
 	})
 
+	t.Run("restricted_security_profile", func(t *testing.T) {
+		rc := rayClusterFixture()
+		rc.Spec.SecurityProfile = dcv1alpha1.SecurityProfileRestricted
+
+		actual, err := NewStatefulSet(rc, comp, "", "")
+		require.NoError(t, err)
+
+		podSpec := actual.Spec.Template.Spec
+		assert.Equal(t, pointer.Bool(true), podSpec.SecurityContext.RunAsNonRoot)
+		assert.Equal(t, pointer.Bool(false), podSpec.Containers[0].SecurityContext.AllowPrivilegeEscalation)
+		assert.Equal(t, []corev1.Capability{"ALL"}, podSpec.Containers[0].SecurityContext.Capabilities.Drop)
+	})
+
+	t.Run("gang_scheduling", func(t *testing.T) {
+		rc := rayClusterFixture()
+		rc.Spec.GangScheduling = &dcv1alpha1.GangSchedulingConfig{
+			Enabled:       true,
+			Provider:      dcv1alpha1.GangSchedulingCoscheduling,
+			SchedulerName: "scheduler-plugins-scheduler",
+		}
+
+		actual, err := NewStatefulSet(rc, comp, "", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, "scheduler-plugins-scheduler", actual.Spec.Template.Spec.SchedulerName)
+		assert.Equal(t, "test-id-ray", actual.Spec.Template.Labels["scheduling.x-k8s.io/pod-group"])
+	})
+
+	t.Run("queued", func(t *testing.T) {
+		rc := rayClusterFixture()
+		rc.Status.Queued = true
+
+		actual, err := NewStatefulSet(rc, comp, "", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, pointer.Int32(0), actual.Spec.Replicas)
+	})
+
 	t.Run("service_account_override", func(t *testing.T) {
 		rc := rayClusterFixture()
 		rc.Spec.ServiceAccount.Name = "user-managed-sa"
 
-		actual, err := NewStatefulSet(rc, comp, false)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, rc.Spec.ServiceAccount.Name, actual.Spec.Template.Spec.ServiceAccountName)
 	})
 }
+
+func TestNewStatefulSetSync(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.Sync = &dcv1alpha1.SyncConfig{
+		Enabled:   true,
+		Port:      2223,
+		MountPath: "/mnt/sync",
+	}
+
+	t.Run("missing_image", func(t *testing.T) {
+		_, err := NewStatefulSet(rc, ComponentWorker, "", "")
+		assert.Error(t, err)
+	})
+
+	t.Run("head", func(t *testing.T) {
+		actual, err := NewStatefulSet(rc, ComponentHead, "", "rsync:test")
+		require.NoError(t, err)
+
+		assert.Len(t, actual.Spec.Template.Spec.Containers, 1)
+	})
+
+	t.Run("worker", func(t *testing.T) {
+		actual, err := NewStatefulSet(rc, ComponentWorker, "", "rsync:test")
+		require.NoError(t, err)
+
+		podSpec := actual.Spec.Template.Spec
+		require.Len(t, podSpec.Containers, 2)
+
+		sidecar := podSpec.Containers[1]
+		assert.Equal(t, "ray-sync", sidecar.Name)
+		assert.Equal(t, "rsync:test", sidecar.Image)
+		assert.Contains(t, sidecar.Env, corev1.EnvVar{Name: "RSYNC_MODULES", Value: "sync:/mnt/sync:rw"})
+
+		mount := corev1.VolumeMount{Name: "sync-data", MountPath: "/mnt/sync"}
+		assert.Contains(t, podSpec.Containers[0].VolumeMounts, mount)
+		assert.Contains(t, sidecar.VolumeMounts, mount)
+
+		var volumes []string
+		for _, vol := range podSpec.Volumes {
+			volumes = append(volumes, vol.Name)
+		}
+		assert.Subset(t, volumes, []string{"sync-data", "sync-auth"})
+	})
+}
+
+func TestNewStatefulSetMonitoring(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.MetricsExportPort = 8080
+	rc.Spec.Monitoring = &dcv1alpha1.MonitoringConfig{Enabled: true}
+
+	for _, comp := range []Component{ComponentHead, ComponentWorker} {
+		actual, err := NewStatefulSet(rc, comp, istio.ModeSidecar, "")
+		require.NoError(t, err)
+
+		container := actual.Spec.Template.Spec.Containers[0]
+		assert.Contains(t, container.Args, "--metrics-export-port=8080", comp)
+		assert.Contains(t, container.Ports, corev1.ContainerPort{Name: "metrics", ContainerPort: 8080}, comp)
+		assert.Contains(t, actual.Spec.Template.Annotations[istioSidecarIncludeInboundPortsAnnotation], "8080", comp)
+	}
+}
+
+func TestNewStatefulSetDashboardAuth(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.EnableDashboard = pointer.Bool(true)
+	rc.Spec.Dashboard = &dcv1alpha1.DashboardConfig{
+		Auth: &dcv1alpha1.DashboardAuthConfig{
+			Enabled: true,
+			Type:    dcv1alpha1.DashboardAuthTokenReview,
+			Port:    4180,
+		},
+	}
+
+	head, err := NewStatefulSet(rc, ComponentHead, istio.ModeSidecar, "")
+	require.NoError(t, err)
+
+	podSpec := head.Spec.Template.Spec
+	require.Len(t, podSpec.Containers, 2)
+	assert.Equal(t, "dashboard-auth", podSpec.Containers[1].Name)
+	assert.Contains(t, podSpec.Containers[1].Args, "--upstream=http://127.0.0.1:8265/")
+	assert.Contains(t, podSpec.Containers[0].Args, "--dashboard-host=127.0.0.1")
+	assert.NotContains(t, podSpec.Containers[0].Args, "--dashboard-host=0.0.0.0")
+	assert.Equal(t, "test-id-ray-dashboard-auth", podSpec.Volumes[len(podSpec.Volumes)-2].ConfigMap.Name)
+	assert.NotNil(t, podSpec.Volumes[len(podSpec.Volumes)-1].Projected)
+	for _, vm := range podSpec.Containers[0].VolumeMounts {
+		assert.NotEqual(t, "dashboard-auth-token", vm.Name)
+	}
+	assert.Contains(t, head.Spec.Template.Annotations[istioSidecarIncludeInboundPortsAnnotation], "4180")
+
+	worker, err := NewStatefulSet(rc, ComponentWorker, istio.ModeSidecar, "")
+	require.NoError(t, err)
+	assert.Len(t, worker.Spec.Template.Spec.Containers, 1)
+
+	t.Run("dashboard_disabled", func(t *testing.T) {
+		rc.Spec.EnableDashboard = pointer.Bool(false)
+
+		head, err := NewStatefulSet(rc, ComponentHead, istio.ModeSidecar, "")
+		require.NoError(t, err)
+		assert.Len(t, head.Spec.Template.Spec.Containers, 1)
+	})
+}
+
+func TestNewStatefulSetTLS(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.TLS = &dcv1alpha1.TLSConfig{Enabled: true}
+
+	expectedEnv := []corev1.EnvVar{
+		{Name: "RAY_USE_TLS", Value: "1"},
+		{Name: "RAY_TLS_SERVER_CERT", Value: "/etc/ray/tls/tls.crt"},
+		{Name: "RAY_TLS_SERVER_KEY", Value: "/etc/ray/tls/tls.key"},
+		{Name: "RAY_TLS_CA_CERT", Value: "/etc/ray/tls/ca.crt"},
+	}
+
+	for _, comp := range []Component{ComponentHead, ComponentWorker} {
+		sts, err := NewStatefulSet(rc, comp, "", "")
+		require.NoError(t, err)
+
+		podSpec := sts.Spec.Template.Spec
+		assert.Subset(t, podSpec.Containers[0].Env, expectedEnv)
+		assert.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "cluster-tls",
+			MountPath: "/etc/ray/tls",
+			ReadOnly:  true,
+		})
+		assert.Equal(t, "test-id-ray-tls-ca", podSpec.Volumes[len(podSpec.Volumes)-2].Secret.SecretName)
+		assert.NotNil(t, podSpec.Volumes[len(podSpec.Volumes)-1].EmptyDir)
+
+		initContainer := podSpec.InitContainers[len(podSpec.InitContainers)-1]
+		assert.Equal(t, "cluster-tls-init", initContainer.Name)
+		assert.Equal(t, podSpec.Containers[0].Image, initContainer.Image)
+		assert.Equal(t, podSpec.Containers[0].SecurityContext, initContainer.SecurityContext)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		rc.Spec.TLS.Enabled = false
+
+		sts, err := NewStatefulSet(rc, ComponentHead, "", "")
+		require.NoError(t, err)
+		for _, env := range sts.Spec.Template.Spec.Containers[0].Env {
+			assert.NotEqual(t, "RAY_USE_TLS", env.Name)
+		}
+	})
+}
+
+func TestNewStatefulSetKeytab(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.KerberosKeytab = &dcv1alpha1.KerberosKeytabConfig{
+		SecretRef: &dcv1alpha1.KerberosKeytabSecretReference{Name: "user-keytab", Key: "keytab"},
+		MountPath: "/etc/security/keytabs",
+	}
+
+	for _, comp := range []Component{ComponentHead, ComponentWorker} {
+		sts, err := NewStatefulSet(rc, comp, "", "")
+		require.NoError(t, err)
+
+		podSpec := sts.Spec.Template.Spec
+		assert.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "kerberos-keytab",
+			ReadOnly:  true,
+			MountPath: "/etc/security/keytabs",
+		})
+		assert.Contains(t, podSpec.Volumes, corev1.Volume{
+			Name: "kerberos-keytab",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: "user-keytab",
+					Items:      []corev1.KeyToPath{{Key: "keytab", Path: "keytab"}},
+				},
+			},
+		})
+	}
+
+	t.Run("inline", func(t *testing.T) {
+		rc.Spec.KerberosKeytab.SecretRef = nil
+		rc.Spec.KerberosKeytab.Contents = []byte("keytab")
+
+		sts, err := NewStatefulSet(rc, ComponentHead, "", "")
+		require.NoError(t, err)
+
+		volumes := sts.Spec.Template.Spec.Volumes
+		assert.Equal(t, "test-id-ray-keytab", volumes[len(volumes)-1].Secret.SecretName)
+		assert.Equal(t, []byte("keytab"), kerberos.NewKeytabSecret(KeytabInfo(rc)).Data["keytab"])
+	})
+}
+
+func TestNewStatefulSetKerberos(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.KerberosKeytab = &dcv1alpha1.KerberosKeytabConfig{
+		Contents:  []byte("keytab"),
+		MountPath: "/etc/security/keytabs",
+	}
+	rc.Spec.Kerberos = &dcv1alpha1.KerberosConfig{
+		Enabled:      true,
+		Principal:    "ray@EXAMPLE.COM",
+		ConfigMapRef: &corev1.LocalObjectReference{Name: "krb5"},
+	}
+
+	for _, comp := range []Component{ComponentHead, ComponentWorker} {
+		sts, err := NewStatefulSet(rc, comp, "", "")
+		require.NoError(t, err)
+
+		podSpec := sts.Spec.Template.Spec
+		require.Len(t, podSpec.InitContainers, 1)
+		assert.Equal(t, "kerberos-init", podSpec.InitContainers[0].Name)
+		assert.Equal(t, "kerberos", podSpec.Containers[len(podSpec.Containers)-1].Name)
+
+		container := podSpec.Containers[0]
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "KRB5CCNAME", Value: "FILE:/var/run/krb5/krb5cc"})
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "KRB5_CONFIG", Value: "/etc/krb5/krb5.conf"})
+		assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+			Name:      "kerberos-config",
+			MountPath: "/etc/krb5",
+			ReadOnly:  true,
+		})
+		assert.Equal(t, "krb5", podSpec.Volumes[len(podSpec.Volumes)-1].ConfigMap.Name)
+	}
+
+	assert.Nil(t, kerberos.NewConfigMap(KerberosInfo(rc)).Data)
+}
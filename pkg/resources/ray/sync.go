@@ -0,0 +1,31 @@
+package ray
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
+)
+
+// SyncContainerName is the name of the file sync sidecar added to worker pods.
+const SyncContainerName = ApplicationName + filesync.ContainerSuffix
+
+// SyncEnabled returns true when the file sync sidecar should be added to worker pods.
+func SyncEnabled(rc *dcv1alpha1.RayCluster) bool {
+	return rc.Spec.Sync != nil && rc.Spec.Sync.Enabled
+}
+
+// SyncSecretName returns the name of the secret holding file sync credentials.
+func SyncSecretName(name string) string {
+	return InstanceObjectName(name, Component("sync"))
+}
+
+// NewSyncSecret generates a secret holding the file sync credentials.
+func NewSyncSecret(rc *dcv1alpha1.RayCluster, password []byte) *corev1.Secret {
+	return filesync.NewSecret(&filesync.SecretInfo{
+		Name:      SyncSecretName(rc.Name),
+		Namespace: rc.Namespace,
+		Labels:    AddGlobalLabels(MetadataLabels(rc), rc.Spec.GlobalLabels),
+		Password:  password,
+	})
+}
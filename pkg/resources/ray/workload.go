@@ -0,0 +1,46 @@
+package ray
+
+import (
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/workload"
+)
+
+// WorkloadName returns the name of the Workload used to queue a cluster.
+func WorkloadName(name string) string {
+	return InstanceObjectName(name, ComponentNone)
+}
+
+// WorkloadInfo describes a Workload with separate pod sets for the head and
+// worker pods. Pod sets are only built when the cluster requests a queue.
+func WorkloadInfo(rc *dcv1alpha1.RayCluster, istioMode istio.Mode, syncImage string) (*workload.Info, error) {
+	info := &workload.Info{
+		Name:      WorkloadName(rc.Name),
+		Namespace: rc.Namespace,
+		Labels:    AddGlobalLabels(MetadataLabels(rc), rc.Spec.GlobalLabels),
+		QueueName: workload.QueueName(rc),
+	}
+	if info.QueueName == "" {
+		return info, nil
+	}
+
+	head, err := NewStatefulSet(rc, ComponentHead, istioMode, syncImage)
+	if err != nil {
+		return nil, err
+	}
+	worker, err := NewStatefulSet(rc, ComponentWorker, istioMode, syncImage)
+	if err != nil {
+		return nil, err
+	}
+
+	info.PodSets = []workload.PodSet{
+		{Name: string(ComponentHead), Count: 1, Template: head.Spec.Template},
+		{
+			Name:     string(ComponentWorker),
+			Count:    workload.WorkerCount(rc.Spec.Worker.Replicas, rc.Spec.Autoscaling),
+			Template: worker.Spec.Template,
+		},
+	}
+
+	return info, nil
+}
@@ -0,0 +1,32 @@
+package spark
+
+import (
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
+)
+
+// AuthorizationPolicyInfo describes the Istio authorization policy of cluster
+// pods. Drivers connect to the master and to executors on arbitrary ports, so
+// clients are granted access to every port like in the cluster network
+// policies. Metrics are served on the web ports.
+func AuthorizationPolicyInfo(sc *dcv1alpha1.SparkCluster, istioMode istio.Mode) *istio.AuthorizationPolicyInfo {
+	info := &istio.AuthorizationPolicyInfo{
+		Name:           InstanceObjectName(sc.Name, ComponentNone),
+		Namespace:      sc.Namespace,
+		Labels:         AddGlobalLabels(MetadataLabels(sc), sc.Spec.GlobalLabels),
+		Selector:       SelectorLabels(sc),
+		ServiceAccount: ServiceAccountName(sc),
+		DashboardPorts: []int32{sc.Spec.MasterWebPort},
+		Waypoint:       istio.Waypoint(sc.Spec.IstioConfig, istioMode),
+		Services:       TrafficInfo(sc, istioMode).Services,
+	}
+	if sc.Spec.Istio != nil {
+		info.Config = sc.Spec.Istio.Authorization
+	}
+	if monitoring.Enabled(sc.Spec.Monitoring) {
+		info.MonitoringPorts = []int32{sc.Spec.MasterWebPort, sc.Spec.WorkerWebPort}
+	}
+
+	return info
+}
@@ -0,0 +1,35 @@
+package spark
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+)
+
+func TestAuthorizationPolicyInfo(t *testing.T) {
+	sc := sparkClusterFixture()
+	sc.Spec.Istio = &dcv1alpha1.IstioResourcesConfig{
+		Authorization: &dcv1alpha1.IstioAuthorizationConfig{Enabled: true},
+	}
+
+	info := AuthorizationPolicyInfo(sc, istio.ModeSidecar)
+	assert.Equal(t, "test-id-spark", info.Name)
+	assert.Equal(t, "fake-ns", info.Namespace)
+	assert.Equal(t, SelectorLabels(sc), info.Selector)
+	assert.Equal(t, "test-id-spark", info.ServiceAccount)
+	assert.Nil(t, info.ClientPorts)
+	assert.Equal(t, []int32{sc.Spec.MasterWebPort}, info.DashboardPorts)
+	assert.Nil(t, info.MonitoringPorts)
+	assert.Equal(t, TrafficInfo(sc, istio.ModeSidecar).Services, info.Services)
+	assert.Equal(t, sc.Spec.Istio.Authorization, info.Config)
+
+	t.Run("monitoring", func(t *testing.T) {
+		sc.Spec.Monitoring = &dcv1alpha1.MonitoringConfig{Enabled: true}
+
+		info := AuthorizationPolicyInfo(sc, istio.ModeSidecar)
+		assert.Equal(t, []int32{sc.Spec.MasterWebPort, sc.Spec.WorkerWebPort}, info.MonitoringPorts)
+	})
+}
@@ -9,16 +9,25 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
 )
 
+// metricsConfiguration enables the Prometheus servlet on the web UI of the
+// master and workers.
+var metricsConfiguration = map[string]string{
+	"spark.metrics.conf.*.sink.prometheusServlet.class":     "org.apache.spark.metrics.sink.PrometheusServlet",
+	"spark.metrics.conf.*.sink.prometheusServlet.path":      workerMetricsPath,
+	"spark.metrics.conf.master.sink.prometheusServlet.path": masterMetricsPath,
+}
+
 // NewFrameworkConfigMap generates a configmap which represents a spark-defaults.conf file out of provided config
 func NewFrameworkConfigMap(sc *dcv1alpha1.SparkCluster) *corev1.ConfigMap {
 	data := map[string]string{}
-	if sc.Spec.Master.DefaultConfiguration != nil {
-		data[string(ComponentMaster)] = generateSparkDefaults(sc.Spec.Master.DefaultConfiguration)
+	if defaults := frameworkConfiguration(sc, sc.Spec.Master.DefaultConfiguration); defaults != nil {
+		data[string(ComponentMaster)] = generateSparkDefaults(defaults)
 	}
-	if sc.Spec.Worker.DefaultConfiguration != nil {
-		data[string(ComponentWorker)] = generateSparkDefaults(sc.Spec.Worker.DefaultConfiguration)
+	if defaults := frameworkConfiguration(sc, sc.Spec.Worker.DefaultConfiguration); defaults != nil {
+		data[string(ComponentWorker)] = generateSparkDefaults(defaults)
 	}
 	if len(data) == 0 {
 		return nil
@@ -33,26 +42,30 @@ func NewFrameworkConfigMap(sc *dcv1alpha1.SparkCluster) *corev1.ConfigMap {
 	}
 }
 
-// NewKeyTabConfigMap generates a configmap which represents the Kerberos KeyTab configuration out of provided config
-func NewKeyTabConfigMap(sc *dcv1alpha1.SparkCluster) *corev1.ConfigMap {
-	binaryData := map[string][]byte{}
-
-	if sc.Spec.KerberosKeytab != nil {
-		binaryData["keytab"] = sc.Spec.KerberosKeytab.Contents
+// frameworkConfiguration returns the spark defaults of a node, which include
+// the metrics configuration when monitoring is enabled and the security
+// configuration when authentication is enabled. Values provided by the node
+// take precedence over the metrics configuration, but cannot weaken the
+// security configuration.
+func frameworkConfiguration(sc *dcv1alpha1.SparkCluster, defaults map[string]string) map[string]string {
+	security := securityConfiguration(sc)
+	if !monitoring.Enabled(sc.Spec.Monitoring) && security == nil {
+		return defaults
 	}
 
-	if len(binaryData) == 0 {
-		return nil
+	confs := []map[string]string{defaults, security}
+	if monitoring.Enabled(sc.Spec.Monitoring) {
+		confs = append([]map[string]string{metricsConfiguration}, confs...)
 	}
 
-	return &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      KeyTabConfigMapName(sc.Name, ComponentNone),
-			Namespace: sc.Namespace,
-			Labels:    AddGlobalLabels(MetadataLabels(sc), sc.Spec.GlobalLabels),
-		},
-		BinaryData: binaryData,
+	merged := map[string]string{}
+	for _, conf := range confs {
+		for k, v := range conf {
+			merged[k] = v
+		}
 	}
+
+	return merged
 }
 
 // looks a little weird because map iteration isn't stable in go, but we want to provide a stable interface
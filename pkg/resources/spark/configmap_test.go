@@ -8,6 +8,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/kerberos"
 )
 
 func TestNewFrameworkConfigMap(t *testing.T) {
@@ -72,6 +73,44 @@ func TestNewFrameworkConfigMap(t *testing.T) {
 		cm := NewFrameworkConfigMap(rc)
 		assert.Nil(t, cm)
 	})
+	t.Run("monitoring", func(t *testing.T) {
+		rc := sparkClusterFixture()
+		rc.Spec.Monitoring = &dcv1alpha1.MonitoringConfig{Enabled: true}
+		rc.Spec.Worker.DefaultConfiguration = map[string]string{
+			"spark.metrics.conf.*.sink.prometheusServlet.path": "/custom",
+		}
+		cm := NewFrameworkConfigMap(rc)
+
+		metrics := "spark.metrics.conf.*.sink.prometheusServlet.class org.apache.spark.metrics.sink.PrometheusServlet\n"
+		assert.Equal(t, map[string]string{
+			"master": metrics +
+				"spark.metrics.conf.*.sink.prometheusServlet.path /metrics/prometheus\n" +
+				"spark.metrics.conf.master.sink.prometheusServlet.path /metrics/master/prometheus\n",
+			"worker": metrics +
+				"spark.metrics.conf.*.sink.prometheusServlet.path /custom\n" +
+				"spark.metrics.conf.master.sink.prometheusServlet.path /metrics/master/prometheus\n",
+		}, cm.Data)
+	})
+	t.Run("security", func(t *testing.T) {
+		rc := sparkClusterFixture()
+		rc.Spec.Security = &dcv1alpha1.SparkClusterSecurity{Authenticate: true, Encrypt: true}
+		rc.Spec.Worker.DefaultConfiguration = map[string]string{
+			"spark.authenticate":                "false",
+			"spark.network.crypto.saslFallback": "true",
+			"spark.executor.cores":              "2",
+		}
+		cm := NewFrameworkConfigMap(rc)
+
+		assert.Equal(t, map[string]string{
+			"master": "spark.authenticate true\n" +
+				"spark.network.crypto.enabled true\n" +
+				"spark.network.crypto.saslFallback false\n",
+			"worker": "spark.authenticate true\n" +
+				"spark.executor.cores 2\n" +
+				"spark.network.crypto.enabled true\n" +
+				"spark.network.crypto.saslFallback false\n",
+		}, cm.Data)
+	})
 }
 
 func TestGenerateSparkDefaults(t *testing.T) {
@@ -88,7 +127,7 @@ func TestGenerateSparkDefaults(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
-func TestNewKeyTabConfigMap(t *testing.T) {
+func TestKeytabInfo(t *testing.T) {
 	t.Run("fully loaded", func(t *testing.T) {
 		rc := sparkClusterFixture()
 
@@ -97,11 +136,11 @@ func TestNewKeyTabConfigMap(t *testing.T) {
 			Contents:  []byte{'t', 'e', 's', 't', 'e', 'r'},
 		}
 
-		cm := NewKeyTabConfigMap(rc)
+		secret := kerberos.NewKeytabSecret(KeytabInfo(rc))
 
-		expected := &corev1.ConfigMap{
+		expected := &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      "test-id-keytab-spark",
+				Name:      "test-id-spark-keytab",
 				Namespace: "fake-ns",
 				Labels: map[string]string{
 					"app.kubernetes.io/name":       "spark",
@@ -110,16 +149,17 @@ func TestNewKeyTabConfigMap(t *testing.T) {
 					"app.kubernetes.io/managed-by": "distributed-compute-operator",
 				},
 			},
-			BinaryData: map[string][]byte{
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
 				"keytab": {'t', 'e', 's', 't', 'e', 'r'},
 			},
 		}
-		assert.Equal(t, expected, cm)
+		assert.Equal(t, expected, secret)
+		assert.Equal(t, "test-id-keytab-spark", kerberos.KeytabConfigMapReference(KeytabInfo(rc)).Name)
 	})
 
 	t.Run("no nodes", func(t *testing.T) {
 		rc := sparkClusterFixture()
-		cm := NewKeyTabConfigMap(rc)
-		assert.Nil(t, cm)
+		assert.Nil(t, kerberos.NewKeytabSecret(KeytabInfo(rc)).Data)
 	})
 }
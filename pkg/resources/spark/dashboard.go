@@ -0,0 +1,20 @@
+package spark
+
+import (
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashboard"
+)
+
+// DashboardInfo describes the Ingress or HTTPRoute that routes to the web UI
+// of the master service.
+func DashboardInfo(sc *dcv1alpha1.SparkCluster) *dashboard.Info {
+	return &dashboard.Info{
+		Name:        InstanceObjectName(sc.Name, "dashboard"),
+		Namespace:   sc.Namespace,
+		Labels:      AddGlobalLabels(MetadataLabelsWithComponent(sc, ComponentMaster), sc.Spec.GlobalLabels),
+		ClusterName: sc.Name,
+		ServiceName: MasterServiceName(sc.Name),
+		ServicePort: sc.Spec.MasterWebPort,
+		Config:      sc.Spec.Dashboard,
+	}
+}
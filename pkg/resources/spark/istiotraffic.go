@@ -0,0 +1,25 @@
+package spark
+
+import (
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/components"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+)
+
+// TrafficInfo describes the Istio Sidecar and DestinationRules of the cluster.
+func TrafficInfo(sc *dcv1alpha1.SparkCluster, istioMode istio.Mode) *istio.TrafficInfo {
+	return &istio.TrafficInfo{
+		Name:      InstanceObjectName(sc.Name, ComponentNone),
+		Namespace: sc.Namespace,
+		Labels:    AddGlobalLabels(MetadataLabels(sc), sc.Spec.GlobalLabels),
+		Selector:  SelectorLabels(sc),
+		Services: []string{
+			MasterServiceName(sc.Name),
+			HeadlessServiceName(sc.Name),
+			DriverServiceName(sc.Name),
+			components.ClientPortsServiceName(sc, Meta),
+		},
+		Mode:   istioMode,
+		Config: sc.Spec.Istio,
+	}
+}
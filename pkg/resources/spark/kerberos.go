@@ -0,0 +1,38 @@
+package spark
+
+import (
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/kerberos"
+)
+
+// keytabVolumeName is the name of the pod volume holding the kerberos keytab.
+const keytabVolumeName = "keytab"
+
+// KeytabSecretName returns the name of the secret holding an inline keytab.
+func KeytabSecretName(name string) string {
+	return InstanceObjectName(name, Component("keytab"))
+}
+
+// KeytabInfo describes the secret holding the keytab contents embedded in
+// the cluster spec, which earlier versions kept in a configmap.
+func KeytabInfo(sc *dcv1alpha1.SparkCluster) *kerberos.KeytabInfo {
+	return &kerberos.KeytabInfo{
+		SecretName:    KeytabSecretName(sc.Name),
+		ConfigMapName: KeyTabConfigMapName(sc.Name, ComponentNone),
+		Namespace:     sc.Namespace,
+		Labels:        AddGlobalLabels(MetadataLabels(sc), sc.Spec.GlobalLabels),
+		Config:        sc.Spec.KerberosKeytab,
+	}
+}
+
+// KerberosInfo describes the kerberos ticket renewal sidecar of cluster pods.
+func KerberosInfo(sc *dcv1alpha1.SparkCluster) *kerberos.Info {
+	return &kerberos.Info{
+		ConfigMapName: KerberosConfigMapName(sc.Name, ComponentNone),
+		Namespace:     sc.Namespace,
+		Labels:        AddGlobalLabels(MetadataLabels(sc), sc.Spec.GlobalLabels),
+		Image:         sc.Spec.Image,
+		KeytabVolume:  keytabVolumeName,
+		Config:        sc.Spec.Kerberos,
+	}
+}
@@ -8,6 +8,9 @@ import (
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/resources"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/clientaccess"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashboard"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
 )
 
 func NewClusterWorkerNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.NetworkPolicy {
@@ -15,10 +18,6 @@ func NewClusterWorkerNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.Ne
 		MatchLabels: MetadataLabelsWithComponent(sc, ComponentWorker),
 	}
 
-	driverSelector := metav1.LabelSelector{
-		MatchLabels: sc.Spec.NetworkPolicy.ClientLabels,
-	}
-
 	clusterSelector := metav1.LabelSelector{
 		MatchLabels: SelectorLabels(sc),
 	}
@@ -34,18 +33,15 @@ func NewClusterWorkerNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.Ne
 		},
 		Spec: networkingv1.NetworkPolicySpec{
 			PodSelector: workerSelector,
-			Ingress: []networkingv1.NetworkPolicyIngressRule{
+			Ingress: append([]networkingv1.NetworkPolicyIngressRule{
 				{
-					From: []networkingv1.NetworkPolicyPeer{
+					From: append([]networkingv1.NetworkPolicyPeer{
 						{
 							PodSelector: &clusterSelector,
 						},
-						{
-							PodSelector: &driverSelector,
-						},
-					},
+					}, clientaccess.Peers(sc.Spec.NetworkPolicy)...),
 				},
-			},
+			}, monitoring.IngressRules(sc.Spec.Monitoring, sc.Spec.WorkerWebPort)...),
 			PolicyTypes: []networkingv1.PolicyType{
 				networkingv1.PolicyTypeIngress,
 			},
@@ -110,10 +106,6 @@ func NewClusterDriverNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.Ne
 }
 
 func NewClusterMasterNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.NetworkPolicy {
-	driverSelector := metav1.LabelSelector{
-		MatchLabels: sc.Spec.NetworkPolicy.ClientLabels,
-	}
-
 	masterSelector := metav1.LabelSelector{
 		MatchLabels: MetadataLabelsWithComponent(sc, ComponentMaster),
 	}
@@ -134,6 +126,11 @@ func NewClusterMasterNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.Ne
 	masterDashboardPort := intstr.FromInt(int(sc.Spec.MasterWebPort))
 	clusterPort := intstr.FromInt(int(sc.Spec.ClusterPort))
 
+	webIngressRules := append(
+		dashboard.IngressRules(sc.Spec.Dashboard, sc.Spec.MasterWebPort),
+		monitoring.IngressRules(sc.Spec.Monitoring, sc.Spec.MasterWebPort)...,
+	)
+
 	return &networkingv1.NetworkPolicy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      InstanceObjectName(sc.Name, "master"),
@@ -145,16 +142,13 @@ func NewClusterMasterNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.Ne
 		},
 		Spec: networkingv1.NetworkPolicySpec{
 			PodSelector: masterSelector,
-			Ingress: []networkingv1.NetworkPolicyIngressRule{
+			Ingress: append([]networkingv1.NetworkPolicyIngressRule{
 				{
-					From: []networkingv1.NetworkPolicyPeer{
+					From: append([]networkingv1.NetworkPolicyPeer{
 						{
 							PodSelector: &workerSelector,
 						},
-						{
-							PodSelector: &driverSelector,
-						},
-					},
+					}, clientaccess.Peers(sc.Spec.NetworkPolicy)...),
 					Ports: []networkingv1.NetworkPolicyPort{
 						{
 							Protocol: &protocol,
@@ -176,7 +170,7 @@ func NewClusterMasterNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.Ne
 						},
 					},
 				},
-			},
+			}, webIngressRules...),
 			PolicyTypes: []networkingv1.PolicyType{
 				networkingv1.PolicyTypeIngress,
 			},
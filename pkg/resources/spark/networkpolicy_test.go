@@ -237,3 +237,21 @@ func TestNewClusterMasterNetworkPolicy(t *testing.T) {
 	}
 	assert.Equal(t, expected, netpol)
 }
+
+func TestNewClusterMasterNetworkPolicyClientPeers(t *testing.T) {
+	rc := sparkClusterFixture()
+	rc.Spec.NetworkPolicy.ClientLabels = map[string]string{"app.kubernetes.io/instance": "spark-driver"}
+	rc.Spec.NetworkPolicy.ClientNamespaceLabels = map[string]string{"team": "data"}
+	rc.Spec.NetworkPolicy.ClientIPBlocks = []networkingv1.IPBlock{{CIDR: "10.0.0.0/8"}}
+
+	for _, netpol := range []*networkingv1.NetworkPolicy{
+		NewClusterMasterNetworkPolicy(rc),
+		NewClusterWorkerNetworkPolicy(rc),
+	} {
+		from := netpol.Spec.Ingress[0].From
+		assert.Len(t, from, 3)
+		assert.Equal(t, map[string]string{"app.kubernetes.io/instance": "spark-driver"}, from[1].PodSelector.MatchLabels)
+		assert.Equal(t, map[string]string{"team": "data"}, from[1].NamespaceSelector.MatchLabels)
+		assert.Equal(t, &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}, from[2].IPBlock)
+	}
+}
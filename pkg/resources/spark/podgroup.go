@@ -0,0 +1,23 @@
+package spark
+
+import (
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
+)
+
+// PodGroupName returns the name of the PodGroup shared by all cluster pods.
+func PodGroupName(name string) string {
+	return InstanceObjectName(name, ComponentNone)
+}
+
+// PodGroupInfo describes a PodGroup that gang schedules the head with the
+// minimum number of workers.
+func PodGroupInfo(sc *dcv1alpha1.SparkCluster) *podgroup.Info {
+	return &podgroup.Info{
+		Name:      PodGroupName(sc.Name),
+		Namespace: sc.Namespace,
+		Labels:    AddGlobalLabels(MetadataLabels(sc), sc.Spec.GlobalLabels),
+		MinMember: podgroup.MinMember(1, sc.Spec.Worker.Replicas, sc.Spec.Autoscaling),
+		Config:    sc.Spec.GangScheduling,
+	}
+}
@@ -0,0 +1,32 @@
+package spark
+
+import (
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
+)
+
+const (
+	masterMetricsPath = "/metrics/master/prometheus"
+	workerMetricsPath = "/metrics/prometheus"
+)
+
+// PodMonitorName returns the name of the PodMonitor that scrapes all cluster pods.
+func PodMonitorName(name string) string {
+	return InstanceObjectName(name, ComponentNone)
+}
+
+// PodMonitorInfo describes a PodMonitor that scrapes the Prometheus servlets
+// served by the web UIs of the master and workers.
+func PodMonitorInfo(sc *dcv1alpha1.SparkCluster) *monitoring.Info {
+	return &monitoring.Info{
+		Name:      PodMonitorName(sc.Name),
+		Namespace: sc.Namespace,
+		Labels:    AddGlobalLabels(MetadataLabels(sc), sc.Spec.GlobalLabels),
+		Selector:  SelectorLabels(sc),
+		Endpoints: []monitoring.Endpoint{
+			{Port: "http", Path: masterMetricsPath, Component: string(ComponentMaster)},
+			{Port: "http", Path: workerMetricsPath, Component: string(ComponentWorker)},
+		},
+		Config: sc.Spec.Monitoring,
+	}
+}
@@ -0,0 +1,99 @@
+package spark
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+const (
+	// AuthSecretKey holds the shared authentication secret in the auth Secret.
+	AuthSecretKey = "auth-secret"
+
+	// authSecretEnvVar is read by spark daemons when spark.authenticate.secret
+	// is not set, which keeps the secret out of the spark-defaults.conf file.
+	authSecretEnvVar = "_SPARK_AUTH_SECRET"
+	authSecretLength = 32
+)
+
+// AuthenticationEnabled returns true when spark processes must authenticate
+// with the shared secret of the cluster.
+func AuthenticationEnabled(sc *dcv1alpha1.SparkCluster) bool {
+	return sc.Spec.Security != nil && sc.Spec.Security.Authenticate
+}
+
+// AuthSecretName returns the name of the secret holding the shared
+// authentication secret.
+func AuthSecretName(name string) string {
+	return InstanceObjectName(name, Component("auth"))
+}
+
+// NewAuthSecret generates a secret holding the shared authentication secret.
+func NewAuthSecret(sc *dcv1alpha1.SparkCluster, secret []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      AuthSecretName(sc.Name),
+			Namespace: sc.Namespace,
+			Labels:    AddGlobalLabels(MetadataLabels(sc), sc.Spec.GlobalLabels),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			AuthSecretKey: secret,
+		},
+	}
+}
+
+// AuthSecret returns the secret stored in an existing auth Secret or a new
+// random one when the Secret is missing or empty. The secret is preserved
+// across reconciliations, otherwise running drivers would be rejected.
+func AuthSecret(current *corev1.Secret) ([]byte, error) {
+	if current != nil && len(current.Data[AuthSecretKey]) != 0 {
+		return current.Data[AuthSecretKey], nil
+	}
+
+	buf := make([]byte, authSecretLength)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("cannot generate auth secret: %w", err)
+	}
+
+	secret := make([]byte, hex.EncodedLen(len(buf)))
+	hex.Encode(secret, buf)
+
+	return secret, nil
+}
+
+// securityConfiguration returns the spark defaults that enable RPC
+// authentication and encryption.
+func securityConfiguration(sc *dcv1alpha1.SparkCluster) map[string]string {
+	if !AuthenticationEnabled(sc) {
+		return nil
+	}
+
+	conf := map[string]string{
+		"spark.authenticate": "true",
+	}
+	if sc.Spec.Security.Encrypt {
+		conf["spark.network.crypto.enabled"] = "true"
+		conf["spark.network.crypto.saslFallback"] = "false"
+	}
+
+	return conf
+}
+
+// authSecretEnv exposes the shared authentication secret to spark daemons.
+func authSecretEnv(sc *dcv1alpha1.SparkCluster) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: authSecretEnvVar,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: AuthSecretName(sc.Name)},
+				Key:                  AuthSecretKey,
+			},
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package spark
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestAuthSecret(t *testing.T) {
+	generated, err := AuthSecret(nil)
+	require.NoError(t, err)
+	assert.Len(t, generated, 64)
+
+	current := &corev1.Secret{Data: map[string][]byte{AuthSecretKey: []byte("existing")}}
+	preserved, err := AuthSecret(current)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("existing"), preserved)
+}
+
+func TestNewAuthSecret(t *testing.T) {
+	sc := sparkClusterFixture()
+	secret := NewAuthSecret(sc, []byte("secret"))
+
+	assert.Equal(t, "test-id-spark-auth", secret.Name)
+	assert.Equal(t, "fake-ns", secret.Namespace)
+	assert.Equal(t, []byte("secret"), secret.Data["auth-secret"])
+}
+
+func TestNewStatefulSetAuthentication(t *testing.T) {
+	sc := sparkClusterFixture()
+	sc.Spec.Security = &dcv1alpha1.SparkClusterSecurity{Authenticate: true}
+
+	expected := corev1.EnvVar{
+		Name: "_SPARK_AUTH_SECRET",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "test-id-spark-auth"},
+				Key:                  "auth-secret",
+			},
+		},
+	}
+
+	for _, comp := range []Component{ComponentMaster, ComponentWorker} {
+		sts, err := NewStatefulSet(sc, comp, "", "")
+		require.NoError(t, err)
+
+		container := sts.Spec.Template.Spec.Containers[0]
+		assert.Contains(t, container.Env, expected)
+		assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+			Name:      "spark-config",
+			MountPath: frameworkConfigMountPath,
+			SubPath:   string(comp),
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
 )
 
 // NewMasterService creates a ClusterIP service that points to the head node.
@@ -47,7 +48,7 @@ func NewMasterService(sc *dcv1alpha1.SparkCluster) *corev1.Service {
 
 // NewHeadlessService creates a headless service that points to worker nodes
 func NewHeadlessService(sc *dcv1alpha1.SparkCluster) *corev1.Service {
-	return &corev1.Service{
+	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      HeadlessServiceName(sc.Name),
 			Namespace: sc.Namespace,
@@ -81,6 +82,16 @@ func NewHeadlessService(sc *dcv1alpha1.SparkCluster) *corev1.Service {
 			},
 		},
 	}
+	if SyncEnabled(sc) {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       filesync.PortName,
+			Port:       sc.Spec.Sync.Port,
+			TargetPort: intstr.FromString(filesync.PortName),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+
+	return svc
 }
 
 // NewSparkDriverService creates a ClusterIP service that exposes the driver UI port.
@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 )
 
 const SparkBlockManagerPortName = "spark-block-manager-port"
@@ -105,6 +107,18 @@ func TestNewHeadlessService(t *testing.T) {
 		},
 	}
 	assert.Equal(t, expected, svc)
+
+	t.Run("sync_enabled", func(t *testing.T) {
+		rc.Spec.Sync = &dcv1alpha1.SyncConfig{Enabled: true, Port: 2223, MountPath: "/mnt/sync"}
+
+		actual := NewHeadlessService(rc)
+		assert.Contains(t, actual.Spec.Ports, corev1.ServicePort{
+			Name:       "tcp-rsync",
+			Port:       2223,
+			TargetPort: intstr.FromString("tcp-rsync"),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	})
 }
 
 func TestNewSparkDriverService(t *testing.T) {
@@ -19,3 +19,13 @@ func NewServiceAccount(sc *dcv1alpha1.SparkCluster) *corev1.ServiceAccount {
 		AutomountServiceAccountToken: pointer.Bool(false),
 	}
 }
+
+// ServiceAccountName returns the name of the service account used by cluster
+// pods.
+func ServiceAccountName(sc *dcv1alpha1.SparkCluster) string {
+	if sc.Spec.ServiceAccount.Name != "" {
+		return sc.Spec.ServiceAccount.Name
+	}
+
+	return InstanceObjectName(sc.Name, ComponentNone)
+}
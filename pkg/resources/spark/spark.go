@@ -35,6 +35,10 @@ func KeyTabConfigMapName(instance string, comp Component) string {
 	return InstanceObjectName(fmt.Sprintf("%s-%s", instance, "keytab"), comp)
 }
 
+func KerberosConfigMapName(instance string, comp Component) string {
+	return InstanceObjectName(fmt.Sprintf("%s-%s", instance, "kerberos"), comp)
+}
+
 // InstanceObjectName returns the name that will be used to create most owned cluster resources.
 func InstanceObjectName(instance string, comp Component) string {
 	if comp == ComponentNone {
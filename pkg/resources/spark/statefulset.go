@@ -11,6 +11,12 @@ import (
 	"k8s.io/utils/pointer"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/kerberos"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podsecurity"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/workload"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
 )
 
@@ -18,7 +24,12 @@ const frameworkConfigMountPath = "/opt/bitnami/spark/conf/spark-defaults.conf"
 
 // NewStatefulSet generates a Deployment configured to manage Spark cluster nodes.
 // The configuration is based the provided spec and the desired Component workload.
-func NewStatefulSet(sc *dcv1alpha1.SparkCluster, comp Component) (*appsv1.StatefulSet, error) {
+func NewStatefulSet(
+	sc *dcv1alpha1.SparkCluster,
+	comp Component,
+	istioMode istio.Mode,
+	syncImage string,
+) (*appsv1.StatefulSet, error) {
 	var replicas int32
 	var nodeAttrs dcv1alpha1.SparkClusterNode
 	var volumes []corev1.Volume
@@ -64,30 +75,75 @@ func NewStatefulSet(sc *dcv1alpha1.SparkCluster, comp Component) (*appsv1.Statef
 
 	labels := AddGlobalLabels(MetadataLabelsWithComponent(sc, comp), nodeAttrs.Labels)
 	envVars := append(componentEnvVars(sc, comp), sc.Spec.EnvVars...)
+	if AuthenticationEnabled(sc) {
+		envVars = append(envVars, authSecretEnv(sc))
+	}
 	volumes = nodeAttrs.Volumes
 	volumeMounts = nodeAttrs.VolumeMounts
 	volumeClaimTemplates := processPVCTemplates(sc, nodeAttrs.VolumeClaimTemplates)
 
-	if nodeAttrs.DefaultConfiguration != nil {
+	if frameworkConfiguration(sc, nodeAttrs.DefaultConfiguration) != nil {
 		cmVolume := getConfigMapVolume("spark-config", FrameworkConfigMapName(sc.Name, ComponentNone))
 		cmVolumeMount := getConfigMapVolumeMount("spark-config", frameworkConfigMountPath, string(comp))
 
 		volumes = append(volumes, cmVolume)
 		volumeMounts = append(volumeMounts, cmVolumeMount)
 	}
-	if sc.Spec.KerberosKeytab != nil {
-		cmVolume := getConfigMapVolume("keytab", KeyTabConfigMapName(sc.Name, ComponentNone))
-		cmVolumeMount := getConfigMapVolumeMount("keytab", sc.Spec.KerberosKeytab.MountPath, string(comp))
+	if kerberos.InlineKeytab(sc.Spec.KerberosKeytab) {
+		volumes = append(volumes, kerberos.NewKeytabVolume(keytabVolumeName, sc.Spec.KerberosKeytab, KeytabSecretName(sc.Name)))
+		volumeMounts = append(volumeMounts, getConfigMapVolumeMount(keytabVolumeName, sc.Spec.KerberosKeytab.MountPath, string(comp)))
+	} else if sc.Spec.KerberosKeytab != nil {
+		volumes = append(volumes, kerberos.NewKeytabVolume(keytabVolumeName, sc.Spec.KerberosKeytab, ""))
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      keytabVolumeName,
+			ReadOnly:  true,
+			MountPath: sc.Spec.KerberosKeytab.MountPath,
+			SubPath:   kerberos.KeytabKey,
+		})
+	}
 
-		volumes = append(volumes, cmVolume)
-		volumeMounts = append(volumeMounts, cmVolumeMount)
+	var sidecars []corev1.Container
+	if comp == ComponentWorker && SyncEnabled(sc) {
+		if syncImage == "" {
+			return nil, fmt.Errorf("sync sidecar image is not provided")
+		}
+
+		sharedVolume, sharedMount := filesync.NewSharedVolume(sc.Spec.Sync.MountPath)
+		volumes = append(volumes, sharedVolume, filesync.NewAuthVolume(SyncSecretName(sc.Name)))
+		volumeMounts = append(volumeMounts, sharedMount)
+
+		sidecars = append(sidecars, filesync.NewSidecar(&filesync.SidecarInfo{
+			Name:         SyncContainerName,
+			Image:        syncImage,
+			PullPolicy:   sc.Spec.Image.PullPolicy,
+			Port:         sc.Spec.Sync.Port,
+			SecretName:   SyncSecretName(sc.Name),
+			Modules:      filesync.Modules(sc.Spec.Sync),
+			VolumeMounts: append([]corev1.VolumeMount{sharedMount}, nodeAttrs.VolumeMounts...),
+		}))
 	}
 
-	serviceAccountName := InstanceObjectName(sc.Name, ComponentNone)
-	if sc.Spec.ServiceAccount.Name != "" {
-		serviceAccountName = sc.Spec.ServiceAccount.Name
+	var initContainers []corev1.Container
+	if kerberos.Enabled(sc.Spec.Kerberos) {
+		info := KerberosInfo(sc)
+		initContainer, err := kerberos.InitContainer(info)
+		if err != nil {
+			return nil, err
+		}
+		sidecar, err := kerberos.Sidecar(info)
+		if err != nil {
+			return nil, err
+		}
+
+		envVars = append(envVars, kerberos.Env()...)
+		volumes = append(volumes, kerberos.Volumes(info)...)
+		volumeMounts = append(volumeMounts, kerberos.VolumeMounts()...)
+		initContainers = append(initContainers, initContainer)
+		sidecars = append(sidecars, sidecar)
 	}
 
+	serviceAccountName := ServiceAccountName(sc)
+
 	annotations := make(map[string]string)
 	if nodeAttrs.Annotations != nil {
 		for k, v := range nodeAttrs.Annotations {
@@ -115,6 +171,11 @@ func NewStatefulSet(sc *dcv1alpha1.SparkCluster, comp Component) (*appsv1.Statef
 		volumeMounts,
 		volumes,
 		securityContext)
+	if len(initContainers) != 0 {
+		podSpec.InitContainers = append(append([]corev1.Container{}, podSpec.InitContainers...), initContainers...)
+	}
+	podSpec.Containers = append(podSpec.Containers, sidecars...)
+	podsecurity.Harden(sc.Spec.SecurityProfile, &podSpec)
 
 	statefulSet := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -124,13 +185,13 @@ func NewStatefulSet(sc *dcv1alpha1.SparkCluster, comp Component) (*appsv1.Statef
 		},
 		Spec: appsv1.StatefulSetSpec{
 			ServiceName: InstanceObjectName(sc.Name, comp),
-			Replicas:    pointer.Int32(replicas),
+			Replicas:    workload.Replicas(&sc.Status, pointer.Int32(replicas)),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: SelectorLabelsWithComponent(sc, comp),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      util.MergeStringMaps(sc.Spec.EnvoyFilterLabels, labels),
+					Labels:      util.MergeStringMaps(istio.PodLabels(istioMode), util.MergeStringMaps(sc.Spec.EnvoyFilterLabels, labels)),
 					Annotations: annotations,
 				},
 				Spec: podSpec,
@@ -142,6 +203,8 @@ func NewStatefulSet(sc *dcv1alpha1.SparkCluster, comp Component) (*appsv1.Statef
 			PodManagementPolicy: appsv1.ParallelPodManagement,
 		},
 	}
+	podgroup.ConfigurePodTemplate(sc.Spec.GangScheduling, PodGroupName(sc.Name), &statefulSet.Spec.Template)
+
 	return statefulSet, nil
 }
 
@@ -13,12 +13,13 @@ import (
 	"k8s.io/utils/pointer"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/kerberos"
 )
 
 func TestNewStatefulSet(t *testing.T) {
 	t.Run("invalid_component", func(t *testing.T) {
 		rc := sparkClusterFixture()
-		_, err := NewStatefulSet(rc, "garbage")
+		_, err := NewStatefulSet(rc, "garbage", "", "")
 		assert.Error(t, err)
 	})
 
@@ -27,7 +28,7 @@ func TestNewStatefulSet(t *testing.T) {
 
 		t.Run("default_values", func(t *testing.T) {
 			rc := sparkClusterFixture()
-			actual, err := NewStatefulSet(rc, ComponentMaster)
+			actual, err := NewStatefulSet(rc, ComponentMaster, "", "")
 			require.NoError(t, err)
 
 			expected := &appsv1.StatefulSet{
@@ -132,7 +133,7 @@ func TestNewStatefulSet(t *testing.T) {
 
 		t.Run("default_values", func(t *testing.T) {
 			rc := sparkClusterFixture()
-			actual, err := NewStatefulSet(rc, ComponentWorker)
+			actual, err := NewStatefulSet(rc, ComponentWorker, "", "")
 			require.NoError(t, err)
 
 			expected := &appsv1.StatefulSet{
@@ -244,7 +245,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 		rc := sparkClusterFixture()
 		rc.Spec.Image = &dcv1alpha1.OCIImageDefinition{}
 
-		_, err := NewStatefulSet(rc, comp)
+		_, err := NewStatefulSet(rc, comp, "", "")
 		assert.Error(t, err)
 	})
 
@@ -261,7 +262,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Labels = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		for _, labels := range []map[string]string{actual.Labels, actual.Spec.Template.Labels} {
@@ -285,7 +286,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Annotations = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Annotations)
@@ -313,7 +314,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.VolumeMounts = expectedVolMounts
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Subset(t, actual.Spec.Template.Spec.Volumes, expectedVols)
@@ -340,7 +341,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Resources = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.Containers[0].Resources)
@@ -359,7 +360,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.NodeSelector = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.NodeSelector)
@@ -398,7 +399,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Affinity = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.Affinity)
@@ -422,7 +423,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Tolerations = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.Tolerations)
@@ -443,7 +444,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.InitContainers = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.InitContainers)
@@ -458,7 +459,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			},
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Subset(t, actual.Spec.Template.Spec.Containers[0].Env, rc.Spec.EnvVars)
@@ -470,17 +471,56 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			RunAsUser: pointer.Int64(0),
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, rc.Spec.PodSecurityContext, actual.Spec.Template.Spec.SecurityContext)
 	})
 
+	t.Run("restricted_security_profile", func(t *testing.T) {
+		rc := sparkClusterFixture()
+		rc.Spec.SecurityProfile = dcv1alpha1.SecurityProfileRestricted
+
+		actual, err := NewStatefulSet(rc, comp, "", "")
+		require.NoError(t, err)
+
+		podSpec := actual.Spec.Template.Spec
+		assert.Equal(t, pointer.Int64(1001), podSpec.SecurityContext.RunAsUser)
+		assert.Equal(t, pointer.Bool(true), podSpec.SecurityContext.RunAsNonRoot)
+		assert.Equal(t, pointer.Bool(false), podSpec.Containers[0].SecurityContext.AllowPrivilegeEscalation)
+		assert.Equal(t, []corev1.Capability{"ALL"}, podSpec.Containers[0].SecurityContext.Capabilities.Drop)
+	})
+
+	t.Run("gang_scheduling", func(t *testing.T) {
+		sc := sparkClusterFixture()
+		sc.Spec.GangScheduling = &dcv1alpha1.GangSchedulingConfig{
+			Enabled:       true,
+			Provider:      dcv1alpha1.GangSchedulingCoscheduling,
+			SchedulerName: "scheduler-plugins-scheduler",
+		}
+
+		actual, err := NewStatefulSet(sc, comp, "", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, "scheduler-plugins-scheduler", actual.Spec.Template.Spec.SchedulerName)
+		assert.Equal(t, "test-id-spark", actual.Spec.Template.Labels["scheduling.x-k8s.io/pod-group"])
+	})
+
+	t.Run("queued", func(t *testing.T) {
+		sc := sparkClusterFixture()
+		sc.Status.Queued = true
+
+		actual, err := NewStatefulSet(sc, comp, "", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, pointer.Int32(0), actual.Spec.Replicas)
+	})
+
 	t.Run("service_account_override", func(t *testing.T) {
 		rc := sparkClusterFixture()
 		rc.Spec.ServiceAccount.Name = "user-managed-sa"
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, rc.Spec.ServiceAccount.Name, actual.Spec.Template.Spec.ServiceAccountName)
@@ -504,7 +544,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			sc.Spec.Worker.VolumeClaimTemplates = input
 		}
 
-		actual, err := NewStatefulSet(sc, comp)
+		actual, err := NewStatefulSet(sc, comp, "", "")
 		require.NoError(t, err)
 
 		expected := []corev1.PersistentVolumeClaim{
@@ -560,13 +600,27 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			},
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, expectedVolumes, actual.Spec.Template.Spec.Volumes)
 		assert.Equal(t, expectedVolumeMounts, actual.Spec.Template.Spec.Containers[0].VolumeMounts)
 	})
 
+	t.Run("monitoring", func(t *testing.T) {
+		rc := sparkClusterFixture()
+		rc.Spec.Monitoring = &dcv1alpha1.MonitoringConfig{Enabled: true}
+
+		actual, err := NewStatefulSet(rc, comp, "", "")
+		require.NoError(t, err)
+
+		assert.Contains(t, actual.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "spark-config",
+			MountPath: "/opt/bitnami/spark/conf/spark-defaults.conf",
+			SubPath:   string(comp),
+		})
+	})
+
 	t.Run("keytab_config", func(t *testing.T) {
 		rc := sparkClusterFixture()
 
@@ -581,10 +635,8 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			{
 				Name: "keytab",
 				VolumeSource: corev1.VolumeSource{
-					ConfigMap: &corev1.ConfigMapVolumeSource{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: "test-id-keytab-spark",
-						},
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: "test-id-spark-keytab",
 					},
 				},
 			},
@@ -599,10 +651,124 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			},
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, "", "")
 		require.NoError(t, err)
 
 		assert.Equal(t, expectedVolumes, actual.Spec.Template.Spec.Volumes)
 		assert.Equal(t, expectedVolumeMounts, actual.Spec.Template.Spec.Containers[0].VolumeMounts)
 	})
+
+	t.Run("keytab_secret_ref", func(t *testing.T) {
+		rc := sparkClusterFixture()
+		rc.Spec.KerberosKeytab = &dcv1alpha1.KerberosKeytabConfig{
+			SecretRef: &dcv1alpha1.KerberosKeytabSecretReference{Name: "user-keytab", Key: "keytab"},
+			MountPath: "/test/path/keytab",
+		}
+
+		actual, err := NewStatefulSet(rc, comp, "", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, []corev1.Volume{
+			{
+				Name: "keytab",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: "user-keytab",
+						Items:      []corev1.KeyToPath{{Key: "keytab", Path: "keytab"}},
+					},
+				},
+			},
+		}, actual.Spec.Template.Spec.Volumes)
+		assert.Equal(t, []corev1.VolumeMount{
+			{
+				Name:      "keytab",
+				ReadOnly:  true,
+				MountPath: "/test/path/keytab",
+				SubPath:   "keytab",
+			},
+		}, actual.Spec.Template.Spec.Containers[0].VolumeMounts)
+		assert.Nil(t, kerberos.NewKeytabSecret(KeytabInfo(rc)).Data)
+	})
+}
+
+func TestNewStatefulSetSync(t *testing.T) {
+	sc := sparkClusterFixture()
+	sc.Spec.Sync = &dcv1alpha1.SyncConfig{
+		Enabled:   true,
+		Port:      2223,
+		MountPath: "/mnt/sync",
+	}
+
+	t.Run("missing_image", func(t *testing.T) {
+		_, err := NewStatefulSet(sc, ComponentWorker, "", "")
+		assert.Error(t, err)
+	})
+
+	t.Run("master", func(t *testing.T) {
+		actual, err := NewStatefulSet(sc, ComponentMaster, "", "rsync:test")
+		require.NoError(t, err)
+
+		assert.Len(t, actual.Spec.Template.Spec.Containers, 1)
+	})
+
+	t.Run("worker", func(t *testing.T) {
+		actual, err := NewStatefulSet(sc, ComponentWorker, "", "rsync:test")
+		require.NoError(t, err)
+
+		podSpec := actual.Spec.Template.Spec
+		require.Len(t, podSpec.Containers, 2)
+
+		sidecar := podSpec.Containers[1]
+		assert.Equal(t, "spark-sync", sidecar.Name)
+		assert.Equal(t, "rsync:test", sidecar.Image)
+		assert.Contains(t, sidecar.Env, corev1.EnvVar{Name: "RSYNC_MODULES", Value: "sync:/mnt/sync:rw"})
+
+		mount := corev1.VolumeMount{Name: "sync-data", MountPath: "/mnt/sync"}
+		assert.Contains(t, podSpec.Containers[0].VolumeMounts, mount)
+		assert.Contains(t, sidecar.VolumeMounts, mount)
+
+		var volumes []string
+		for _, vol := range podSpec.Volumes {
+			volumes = append(volumes, vol.Name)
+		}
+		assert.Subset(t, volumes, []string{"sync-data", "sync-auth"})
+	})
+}
+
+func TestNewStatefulSetKerberos(t *testing.T) {
+	sc := sparkClusterFixture()
+	sc.Spec.KerberosKeytab = &dcv1alpha1.KerberosKeytabConfig{
+		SecretRef: &dcv1alpha1.KerberosKeytabSecretReference{Name: "user-keytab", Key: "keytab"},
+		MountPath: "/etc/security/keytab",
+	}
+	sc.Spec.Kerberos = &dcv1alpha1.KerberosConfig{
+		Enabled:   true,
+		Principal: "spark",
+		Realm:     "EXAMPLE.COM",
+	}
+
+	for _, comp := range []Component{ComponentMaster, ComponentWorker} {
+		sts, err := NewStatefulSet(sc, comp, "", "")
+		require.NoError(t, err)
+
+		podSpec := sts.Spec.Template.Spec
+		require.Len(t, podSpec.InitContainers, 1)
+		assert.Equal(t, "kerberos-init", podSpec.InitContainers[0].Name)
+		require.Len(t, podSpec.Containers, 2)
+		assert.Equal(t, "kerberos", podSpec.Containers[1].Name)
+		assert.Contains(t, podSpec.Containers[1].VolumeMounts, corev1.VolumeMount{
+			Name:      "keytab",
+			MountPath: "/var/run/krb5-keytab",
+			ReadOnly:  true,
+		})
+
+		container := podSpec.Containers[0]
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "KRB5CCNAME", Value: "FILE:/var/run/krb5/krb5cc"})
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "KRB5_CONFIG", Value: "/etc/krb5/krb5.conf"})
+		assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+			Name:      "kerberos-ccache",
+			MountPath: "/var/run/krb5",
+		})
+		assert.Equal(t, "test-id-kerberos-spark", podSpec.Volumes[len(podSpec.Volumes)-1].ConfigMap.Name)
+	}
 }
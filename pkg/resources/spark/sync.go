@@ -0,0 +1,31 @@
+package spark
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/filesync"
+)
+
+// SyncContainerName is the name of the file sync sidecar added to worker pods.
+const SyncContainerName = ApplicationName + filesync.ContainerSuffix
+
+// SyncEnabled returns true when the file sync sidecar should be added to worker pods.
+func SyncEnabled(sc *dcv1alpha1.SparkCluster) bool {
+	return sc.Spec.Sync != nil && sc.Spec.Sync.Enabled
+}
+
+// SyncSecretName returns the name of the secret holding file sync credentials.
+func SyncSecretName(name string) string {
+	return InstanceObjectName(name, Component("sync"))
+}
+
+// NewSyncSecret generates a secret holding the file sync credentials.
+func NewSyncSecret(sc *dcv1alpha1.SparkCluster, password []byte) *corev1.Secret {
+	return filesync.NewSecret(&filesync.SecretInfo{
+		Name:      SyncSecretName(sc.Name),
+		Namespace: sc.Namespace,
+		Labels:    AddGlobalLabels(MetadataLabels(sc), sc.Spec.GlobalLabels),
+		Password:  password,
+	})
+}
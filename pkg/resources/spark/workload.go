@@ -0,0 +1,46 @@
+package spark
+
+import (
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/workload"
+)
+
+// WorkloadName returns the name of the Workload used to queue a cluster.
+func WorkloadName(name string) string {
+	return InstanceObjectName(name, ComponentNone)
+}
+
+// WorkloadInfo describes a Workload with separate pod sets for the master and
+// worker pods. Pod sets are only built when the cluster requests a queue.
+func WorkloadInfo(sc *dcv1alpha1.SparkCluster, istioMode istio.Mode, syncImage string) (*workload.Info, error) {
+	info := &workload.Info{
+		Name:      WorkloadName(sc.Name),
+		Namespace: sc.Namespace,
+		Labels:    AddGlobalLabels(MetadataLabels(sc), sc.Spec.GlobalLabels),
+		QueueName: workload.QueueName(sc),
+	}
+	if info.QueueName == "" {
+		return info, nil
+	}
+
+	head, err := NewStatefulSet(sc, ComponentMaster, istioMode, syncImage)
+	if err != nil {
+		return nil, err
+	}
+	worker, err := NewStatefulSet(sc, ComponentWorker, istioMode, syncImage)
+	if err != nil {
+		return nil, err
+	}
+
+	info.PodSets = []workload.PodSet{
+		{Name: string(ComponentMaster), Count: 1, Template: head.Spec.Template},
+		{
+			Name:     string(ComponentWorker),
+			Count:    workload.WorkerCount(sc.Spec.Worker.Replicas, sc.Spec.Autoscaling),
+			Template: worker.Spec.Template,
+		},
+	}
+
+	return info, nil
+}
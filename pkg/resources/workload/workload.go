@@ -0,0 +1,137 @@
+// Package workload builds the Workload objects used to queue clusters with a
+// Kueue-compatible admission controller. The Workload API is provided by an
+// optional CRD, so objects are unstructured.
+package workload
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+// AdmittedCondition is reported by a Workload once its pods may start.
+const AdmittedCondition = "Admitted"
+
+// GroupVersionKind is the kind of the Workload objects created for clusters.
+var GroupVersionKind = schema.GroupVersionKind{Group: "kueue.x-k8s.io", Version: "v1beta1", Kind: "Workload"}
+
+// Info describes the Workload of a cluster.
+type Info struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	// QueueName is blank when the cluster is not queued.
+	QueueName string
+	PodSets   []PodSet
+}
+
+// PodSet is a group of identical cluster pods, e.g. all workers.
+type PodSet struct {
+	Name     string
+	Count    int32
+	Template corev1.PodTemplateSpec
+}
+
+// QueueName returns the queue requested by a cluster, if any.
+func QueueName(obj client.Object) string {
+	return obj.GetLabels()[dcv1alpha1.QueueNameLabel]
+}
+
+// WorkerCount returns the number of workers a cluster may run once admitted.
+// Autoscaled clusters must be admitted with their maximum size.
+func WorkerCount(replicas *int32, as *dcv1alpha1.Autoscaling) int32 {
+	switch {
+	case as != nil:
+		return as.MaxReplicas
+	case replicas != nil:
+		return *replicas
+	}
+	return 0
+}
+
+// Replicas returns the replicas of a cluster stateful set, which are
+// withheld while the cluster is queued.
+func Replicas(csc *dcv1alpha1.ClusterStatusConfig, replicas *int32) *int32 {
+	if csc.Queued {
+		return pointer.Int32(0)
+	}
+	return replicas
+}
+
+// New returns the Workload described by info. Empty pod sets are omitted.
+func New(info *Info) (*unstructured.Unstructured, error) {
+	wl := newObject(info.Name, info.Namespace)
+	wl.SetLabels(info.Labels)
+
+	var podSets []interface{}
+	for idx := range info.PodSets {
+		ps := &info.PodSets[idx]
+		if ps.Count == 0 {
+			continue
+		}
+
+		tmpl, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&ps.Template)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %s pod template: %w", ps.Name, err)
+		}
+		podSets = append(podSets, map[string]interface{}{
+			"name":     ps.Name,
+			"count":    int64(ps.Count),
+			"template": tmpl,
+		})
+	}
+
+	wl.Object["spec"] = map[string]interface{}{
+		"queueName": info.QueueName,
+		"podSets":   podSets,
+	}
+
+	return wl, nil
+}
+
+// Reference returns a Workload suitable for lookups and deletion.
+func Reference(name, namespace string) *unstructured.Unstructured {
+	return newObject(name, namespace)
+}
+
+// FetchAdmitted returns true when the named Workload has been admitted.
+func FetchAdmitted(ctx context.Context, c client.Reader, name, namespace string) (bool, error) {
+	wl := newObject(name, namespace)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(wl), wl); err != nil {
+		if util.IgnoreMissing(err) == nil {
+			return false, nil
+		}
+		return false, fmt.Errorf("cannot fetch workload: %w", err)
+	}
+
+	conditions, _, err := unstructured.NestedSlice(wl.Object, "status", "conditions")
+	if err != nil {
+		return false, err
+	}
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if ok && cond["type"] == AdmittedCondition {
+			return cond["status"] == string(corev1.ConditionTrue), nil
+		}
+	}
+
+	return false, nil
+}
+
+func newObject(name, namespace string) *unstructured.Unstructured {
+	wl := &unstructured.Unstructured{}
+	wl.SetGroupVersionKind(GroupVersionKind)
+	wl.SetName(name)
+	wl.SetNamespace(namespace)
+
+	return wl
+}
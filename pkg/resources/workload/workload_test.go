@@ -0,0 +1,138 @@
+package workload
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestQueueName(t *testing.T) {
+	rc := &dcv1alpha1.RayCluster{}
+	assert.Empty(t, QueueName(rc))
+
+	rc.Labels = map[string]string{dcv1alpha1.QueueNameLabel: "team-a"}
+	assert.Equal(t, "team-a", QueueName(rc))
+}
+
+func TestWorkerCount(t *testing.T) {
+	assert.Equal(t, int32(3), WorkerCount(pointer.Int32(3), nil))
+	assert.Equal(t, int32(8), WorkerCount(pointer.Int32(3), &dcv1alpha1.Autoscaling{MaxReplicas: 8}))
+	assert.Equal(t, int32(0), WorkerCount(nil, nil))
+}
+
+func TestReplicas(t *testing.T) {
+	csc := &dcv1alpha1.ClusterStatusConfig{}
+	assert.Equal(t, pointer.Int32(3), Replicas(csc, pointer.Int32(3)))
+
+	csc.Queued = true
+	assert.Equal(t, pointer.Int32(0), Replicas(csc, pointer.Int32(3)))
+}
+
+func TestNew(t *testing.T) {
+	template := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "worker",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+					},
+				},
+			},
+		},
+	}
+	info := &Info{
+		Name:      "test-ray",
+		Namespace: "ns",
+		Labels:    map[string]string{"app": "ray"},
+		QueueName: "team-a",
+		PodSets: []PodSet{
+			{Name: "head", Count: 1, Template: template},
+			{Name: "worker", Count: 0, Template: template},
+		},
+	}
+
+	wl, err := New(info)
+	require.NoError(t, err)
+
+	assert.Equal(t, "kueue.x-k8s.io/v1beta1", wl.GetAPIVersion())
+	assert.Equal(t, "Workload", wl.GetKind())
+	assert.Equal(t, "test-ray", wl.GetName())
+	assert.Equal(t, map[string]string{"app": "ray"}, wl.GetLabels())
+
+	queueName, _, _ := unstructured.NestedString(wl.Object, "spec", "queueName")
+	assert.Equal(t, "team-a", queueName)
+
+	podSets, _, _ := unstructured.NestedSlice(wl.Object, "spec", "podSets")
+	require.Len(t, podSets, 1, "empty pod sets must be omitted")
+
+	podSet := podSets[0].(map[string]interface{})
+	assert.Equal(t, "head", podSet["name"])
+	assert.Equal(t, int64(1), podSet["count"])
+
+	containers, _, _ := unstructured.NestedSlice(podSet, "template", "spec", "containers")
+	require.Len(t, containers, 1)
+	cpu, _, _ := unstructured.NestedString(containers[0].(map[string]interface{}), "resources", "requests", "cpu")
+	assert.Equal(t, "2", cpu)
+}
+
+func TestFetchAdmitted(t *testing.T) {
+	newWorkload := func(conditions ...metav1.Condition) *unstructured.Unstructured {
+		wl := Reference("test", "ns")
+
+		var conds []interface{}
+		for _, c := range conditions {
+			conds = append(conds, map[string]interface{}{"type": c.Type, "status": string(c.Status)})
+		}
+		if conds != nil {
+			wl.Object["status"] = map[string]interface{}{"conditions": conds}
+		}
+		return wl
+	}
+
+	testcases := []struct {
+		name     string
+		objects  []*unstructured.Unstructured
+		admitted bool
+	}{
+		{
+			name: "missing",
+		},
+		{
+			name:    "pending",
+			objects: []*unstructured.Unstructured{newWorkload()},
+		},
+		{
+			name:     "admitted",
+			objects:  []*unstructured.Unstructured{newWorkload(metav1.Condition{Type: AdmittedCondition, Status: metav1.ConditionTrue})},
+			admitted: true,
+		},
+		{
+			name:    "evicted",
+			objects: []*unstructured.Unstructured{newWorkload(metav1.Condition{Type: AdmittedCondition, Status: metav1.ConditionFalse})},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder()
+			for _, obj := range tc.objects {
+				builder.WithObjects(obj)
+			}
+
+			admitted, err := FetchAdmitted(context.Background(), builder.Build(), "test", "ns")
+			require.NoError(t, err)
+			assert.Equal(t, tc.admitted, admitted)
+		})
+	}
+}
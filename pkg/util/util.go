@@ -5,6 +5,9 @@ import (
 	"strconv"
 
 	"github.com/distribution/reference"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 )
@@ -26,6 +29,7 @@ func MergeStringMaps(src, dst map[string]string) map[string]string {
 }
 
 // ParseImageDefinition generates a fully-qualified image reference to an OCI image.
+// The "latest" tag is implied unless the definition has a tag or a digest.
 // An error will be returned when the image definition is invalid.
 func ParseImageDefinition(def *v1alpha1.OCIImageDefinition) (string, error) {
 	ref := def.Repository
@@ -36,6 +40,9 @@ func ParseImageDefinition(def *v1alpha1.OCIImageDefinition) (string, error) {
 	if def.Tag != "" {
 		ref = fmt.Sprintf("%s:%s", ref, def.Tag)
 	}
+	if def.Digest != "" {
+		ref = fmt.Sprintf("%s@%s", ref, def.Digest)
+	}
 
 	named, err := reference.ParseNormalizedNamed(ref)
 	if err != nil {
@@ -77,3 +84,27 @@ func RemoveFromSlice(s []string, i int) []string {
 	}
 	return s
 }
+
+// PodSecurityPolicyAvailable returns true when the API server serves the
+// PodSecurityPolicy API, which was removed in Kubernetes 1.25.
+func PodSecurityPolicyAvailable(mapper meta.RESTMapper) (bool, error) {
+	gk := schema.GroupKind{Group: "policy", Kind: "PodSecurityPolicy"}
+
+	if _, err := mapper.RESTMapping(gk, "v1beta1"); err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("cannot discover pod security policy API: %w", err)
+	}
+
+	return true, nil
+}
+
+// IgnoreMissing returns nil when err reports a missing object, or a missing
+// API for objects whose CRDs are optional.
+func IgnoreMissing(err error) error {
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	}
+	return err
+}
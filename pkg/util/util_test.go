@@ -1,9 +1,15 @@
 package util
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 )
@@ -99,6 +105,21 @@ func TestParseImageDefinition(t *testing.T) {
 			},
 			expected: "docker.io/library/test-repo:latest",
 		},
+		{
+			input: &dcv1alpha1.OCIImageDefinition{
+				Repository: "test-repo",
+				Digest:     "sha256:" + strings.Repeat("a", 64),
+			},
+			expected: "docker.io/library/test-repo@sha256:" + strings.Repeat("a", 64),
+		},
+		{
+			input: &dcv1alpha1.OCIImageDefinition{
+				Repository: "test-repo",
+				Tag:        "test-tag",
+				Digest:     "sha256:" + strings.Repeat("a", 64),
+			},
+			expected: "docker.io/library/test-repo:test-tag@sha256:" + strings.Repeat("a", 64),
+		},
 		{
 			input: &dcv1alpha1.OCIImageDefinition{
 				Registry: "test-reg:5000",
@@ -160,3 +181,26 @@ func TestRemoveFromSlice(t *testing.T) {
 	assert.EqualValues(t, []string{"Penn", "Teller"}, RemoveFromSlice([]string{"Penn", "Teller"}, 10))
 	assert.EqualValues(t, []string{"Penn", "Teller"}, RemoveFromSlice([]string{"Penn", "Teller"}, -1))
 }
+
+func TestPodSecurityPolicyAvailable(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+
+	available, err := PodSecurityPolicyAvailable(mapper)
+	require.NoError(t, err)
+	assert.False(t, available)
+
+	mapper.Add(schema.GroupVersionKind{Group: "policy", Version: "v1beta1", Kind: "PodSecurityPolicy"}, meta.RESTScopeRoot)
+
+	available, err = PodSecurityPolicyAvailable(mapper)
+	require.NoError(t, err)
+	assert.True(t, available)
+}
+
+func TestIgnoreMissing(t *testing.T) {
+	gr := schema.GroupResource{Group: "kueue.x-k8s.io", Resource: "workloads"}
+
+	assert.NoError(t, IgnoreMissing(nil))
+	assert.NoError(t, IgnoreMissing(apierrors.NewNotFound(gr, "test")))
+	assert.NoError(t, IgnoreMissing(&meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: gr.Group, Kind: "Workload"}}))
+	assert.Error(t, IgnoreMissing(errors.New("boom")))
+}